@@ -3,10 +3,12 @@ module github.com/fivetentaylor/pointy
 go 1.25.3
 
 require (
+	cloud.google.com/go/aiplatform v1.67.0
 	code.sajari.com/docconv/v2 v2.0.0-pre.4
 	github.com/99designs/gqlgen v0.17.35
 	github.com/a-h/templ v0.2.771
 	github.com/advancedlogic/GoOse v0.0.0-20231203033844-ae6b36caf275
+	github.com/alicebob/miniredis/v2 v2.31.1
 	github.com/andybalholm/cascadia v1.3.2
 	github.com/aws/aws-sdk-go v1.46.5
 	github.com/charmbracelet/log v0.3.1
@@ -31,6 +33,7 @@ require (
 	github.com/joho/godotenv v1.5.1
 	github.com/jpoz/conveyor v0.0.0-20241110224150-2b7c2a4ed624
 	github.com/lib/pq v1.10.9
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/posthog/posthog-go v1.2.14
 	github.com/redis/go-redis/v9 v9.7.0
 	github.com/riandyrn/otelchi v0.9.0
@@ -49,6 +52,7 @@ require (
 	golang.org/x/net v0.47.0
 	golang.org/x/oauth2 v0.20.0
 	golang.org/x/text v0.31.0
+	google.golang.org/api v0.180.0
 	google.golang.org/protobuf v1.36.1
 	gorm.io/driver/postgres v1.5.2
 	gorm.io/gen v0.3.23
@@ -59,7 +63,6 @@ require (
 require (
 	cloud.google.com/go v0.113.0 // indirect
 	cloud.google.com/go/ai v0.6.0 // indirect
-	cloud.google.com/go/aiplatform v1.67.0 // indirect
 	cloud.google.com/go/auth v0.4.1 // indirect
 	cloud.google.com/go/auth/oauth2adapt v0.2.2 // indirect
 	cloud.google.com/go/compute/metadata v0.3.0 // indirect
@@ -73,6 +76,7 @@ require (
 	github.com/PuerkitoBio/goquery v1.8.1 // indirect
 	github.com/agnivade/levenshtein v1.1.1 // indirect
 	github.com/ajg/form v1.5.1 // indirect
+	github.com/alicebob/gopher-json v0.0.0-20200520072559-a9ecdc9d1d3a // indirect
 	github.com/andybalholm/brotli v1.1.0 // indirect
 	github.com/araddon/dateparse v0.0.0-20200409225146-d820a6159ab1 // indirect
 	github.com/aymanbagabas/go-osc52/v2 v2.0.1 // indirect
@@ -134,7 +138,6 @@ require (
 	github.com/pierrec/lz4/v4 v4.1.17 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
 	github.com/pkoukk/tiktoken-go v0.1.6 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/power-devops/perfstat v0.0.0-20240221224432-82ca36839d55 // indirect
 	github.com/richardlehane/mscfb v1.0.3 // indirect
 	github.com/richardlehane/msoleps v1.0.3 // indirect
@@ -150,6 +153,7 @@ require (
 	github.com/tklauser/numcpus v0.8.0 // indirect
 	github.com/urfave/cli/v2 v2.25.5 // indirect
 	github.com/xrash/smetrics v0.0.0-20201216005158-039620a65673 // indirect
+	github.com/yuin/gopher-lua v1.1.0 // indirect
 	github.com/yusufpapurcu/wmi v1.2.4 // indirect
 	go.opencensus.io v0.24.0 // indirect
 	go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc v0.51.0 // indirect
@@ -176,7 +180,6 @@ require (
 	golang.org/x/sys v0.38.0 // indirect
 	golang.org/x/time v0.5.0 // indirect
 	golang.org/x/tools v0.39.0 // indirect
-	google.golang.org/api v0.180.0 // indirect
 	google.golang.org/genproto v0.0.0-20240401170217-c3f982113cda // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20240701130421-f6361c86f094 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20240701130421-f6361c86f094 // indirect
@@ -90,9 +90,12 @@ func Routes(r chi.Router) {
 	r.Get("/payment/subscription/plans", SubscriptionPlans)
 	r.Get("/intro_doc", GetIntroDoc)
 	r.Post("/intro_doc", PostIntroDoc)
+	r.Get("/webhooks", GetWebhookDeliveries)
+	r.Post("/webhooks/deliveries/{deliveryID}/retry", RetryWebhookDelivery)
 
 	// check dags
 	r.Get("/checks/dags", GetDagChecks)
+	r.Get("/checks/dags/feedback", GetDagFeedback)
 	r.Get("/checks/dags/new/document/{docId}/thread/{threadId}", NewDagCheck)
 	r.Get("/checks/dags/{key}", GetChecksForDag)
 	r.Get("/checks/dags/{key}/{id}/results/{resultId}", ViewResultForDagCheck)
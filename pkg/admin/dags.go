@@ -110,6 +110,41 @@ func GetDagChecks(w http.ResponseWriter, r *http.Request) {
 	templates.DagChecks(DagMap).Render(r.Context(), w)
 }
 
+// GetDagFeedback totals the thumbs up and down users gave the AI messages
+// each stored prompt wrote
+func GetDagFeedback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	dydb := env.Dynamo(ctx)
+
+	tbl := env.Query(ctx).Prompt
+	prompts, err := tbl.Order(tbl.PromptName).Find()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	feedback := make([]templates.PromptFeedback, len(prompts))
+	for i, prompt := range prompts {
+		feedback[i].PromptName = prompt.PromptName
+
+		all, err := dydb.GetAIFeedbackForPrompt(prompt.PromptName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		for _, f := range all {
+			if f.Positive {
+				feedback[i].Positive++
+			} else {
+				feedback[i].Negative++
+			}
+		}
+	}
+
+	templates.DagFeedback(feedback).Render(ctx, w)
+}
+
 func GetChecksForDag(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	log := env.Log(r.Context())
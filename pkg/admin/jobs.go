@@ -43,6 +43,32 @@ func StartJobs(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprint(w, fmt.Sprintf("snapshot job started for document: %s", docID))
 		w.WriteHeader(http.StatusOK)
 		return
+	case "embed":
+		docID := r.FormValue("document_id")
+		_, err := env.Background(ctx).Enqueue(ctx, &wire.EmbedDocument{DocId: docID})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		attachmentTbl := env.Query(ctx).DocumentAttachment
+		files, err := attachmentTbl.Where(attachmentTbl.DocumentID.Eq(docID)).Find()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		for _, file := range files {
+			_, err := env.Background(ctx).Enqueue(ctx, &wire.EmbedAttachment{AttachmentId: file.ID})
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		fmt.Fprint(w, fmt.Sprintf("embed jobs started for document: %s and %d attachments", docID, len(files)))
+		w.WriteHeader(http.StatusOK)
+		return
 	case "snapshot-all":
 		// get form data
 		version := r.FormValue("version")
@@ -0,0 +1,103 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/fivetentaylor/pointy/pkg/admin/templates"
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/service/webhooks"
+)
+
+const webhookDeliveryLimit = 200
+
+func GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	status := r.URL.Query().Get("status")
+
+	entries, err := getWebhookDeliveryEntries(ctx, status)
+	if err != nil {
+		env.SLog(ctx).Error("error getting webhook deliveries", "error", err)
+		http.Error(w, "Failed to fetch webhook deliveries", http.StatusInternalServerError)
+		return
+	}
+
+	err = templates.WebhookDeliveries(entries, status).Render(ctx, w)
+	if err != nil {
+		http.Error(w, "Failed to render webhook deliveries template", http.StatusInternalServerError)
+		return
+	}
+}
+
+func RetryWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	deliveryID := chi.URLParam(r, "deliveryID")
+
+	err := webhooks.Redeliver(ctx, deliveryID)
+	if err != nil {
+		env.SLog(ctx).Error("error retrying webhook delivery", "deliveryID", deliveryID, "error", err)
+		http.Error(w, "Failed to retry webhook delivery", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/webhooks", http.StatusSeeOther)
+}
+
+func getWebhookDeliveryEntries(ctx context.Context, status string) ([]templates.WebhookDeliveryEntry, error) {
+	q := env.Query(ctx)
+
+	deliveryTbl := q.WebhookDelivery
+	query := deliveryTbl.Order(deliveryTbl.CreatedAt.Desc()).Limit(webhookDeliveryLimit)
+	if status != "" {
+		query = query.Where(deliveryTbl.Status.Eq(status))
+	}
+
+	deliveries, err := query.Find()
+	if err != nil {
+		return nil, err
+	}
+
+	webhookIDs := []string{}
+	for _, delivery := range deliveries {
+		webhookIDs = append(webhookIDs, delivery.WebhookID)
+	}
+
+	webhookTbl := q.Webhook
+	hooks, err := webhookTbl.Where(webhookTbl.ID.In(webhookIDs...)).Find()
+	if err != nil {
+		return nil, err
+	}
+
+	urls := map[string]string{}
+	for _, hook := range hooks {
+		urls[hook.ID] = hook.URL
+	}
+
+	entries := make([]templates.WebhookDeliveryEntry, len(deliveries))
+	for i, delivery := range deliveries {
+		entries[i] = templates.WebhookDeliveryEntry{
+			ID:             delivery.ID,
+			WebhookID:      delivery.WebhookID,
+			URL:            urls[delivery.WebhookID],
+			Event:          delivery.Event,
+			Status:         delivery.Status,
+			Attempts:       delivery.Attempts,
+			ResponseStatus: delivery.ResponseStatus,
+			Payload:        delivery.Payload,
+			CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+		}
+
+		if delivery.Error != nil {
+			entries[i].Error = *delivery.Error
+		}
+
+		if !delivery.NextAttemptAt.IsZero() {
+			entries[i].NextAttemptAt = delivery.NextAttemptAt.Format(time.RFC3339)
+		}
+	}
+
+	return entries, nil
+}
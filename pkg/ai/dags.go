@@ -7,6 +7,7 @@ import (
 	"github.com/fivetentaylor/pointy/pkg/env"
 	"github.com/fivetentaylor/pointy/pkg/models"
 	"github.com/fivetentaylor/pointy/pkg/service/messaging"
+	"github.com/fivetentaylor/pointy/pkg/service/notifications"
 	"github.com/fivetentaylor/pointy/pkg/stackerr"
 	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
 )
@@ -89,12 +90,28 @@ func complete(ctx context.Context, d *dag.Dag) {
 		return
 	}
 
+	revised := msg.LifecycleStage == dynamo.MessageLifecycleStageRevised
 	msg.LifecycleStage = dynamo.MessageLifecycleStageCompleted
 	err = messaging.UpdateMessage(ctx, msg)
 	if err != nil {
 		log.Error("error updating message", "error", err)
 		return
 	}
+
+	if !revised {
+		return
+	}
+
+	thread, err := env.Dynamo(ctx).GetThreadWithoutUserId(msg.DocID, threadID)
+	if err != nil {
+		log.Error("error getting thread", "error", err)
+		return
+	}
+
+	err = notifications.NotifyRevision(ctx, thread.UserID, msg.DocID, threadID, msg.MessageID)
+	if err != nil {
+		log.Error("error adding revision to inbox", "error", err)
+	}
 }
 
 func failure(ctx context.Context, node dag.Node, err error) {
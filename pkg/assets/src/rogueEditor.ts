@@ -82,7 +82,8 @@ export type SubscribableAttr =
   | "curSpanFormat"
   | "selectedHtml"
   | "lastEdit"
-  | "cursors";
+  | "cursors"
+  | "offlineMerge";
 
 export type EditorMode =
   | "diff"
@@ -90,6 +91,7 @@ export type EditorMode =
   | "paste"
   | "edit"
   | "xray"
+  | "blame"
   | "scrub";
 
 const supportedTextMimeTypes = [
@@ -190,6 +192,11 @@ export class RogueEditor extends HTMLElement {
   private _addressDescription: string | null = null;
   private _showDiffHighlights: boolean = false;
   private _enabled: boolean = false;
+  private _readOnly: boolean = false; // the server rejects ops for read and comment access
+  private _lastSeq: number | null = null; // delta log seq from the last loaded event
+  private _catchingUp: boolean = false; // the server is sending only the ops we missed
+  private _offlineBaseAddress: string | null = null; // our address when we reconnected
+  private _offlineBatchIds: Id[] = []; // ops sent in the pending offline batch
   private _cursors: Record<string, AuthorInfo> = {}; // authorID => authorInfo
   private _editing: boolean = false; // Has the user send ops since the subscription
   private _opStats: OpStats | null = null;
@@ -535,6 +542,13 @@ export class RogueEditor extends HTMLElement {
     this.renderRogue();
   }
 
+  toggleBlameMode() {
+    this._editorMode = this._editorMode === "blame" ? "edit" : "blame";
+    this._address = null;
+    this.notifySubscribers("editorMode", this._editorMode);
+    this.renderRogue();
+  }
+
   setAddressDescription(value: string) {
     if (this.debug) {
       console.log("setAddressDescription", value);
@@ -2614,6 +2628,7 @@ export class RogueEditor extends HTMLElement {
     }
     this.connected = false;
     this.loaded = false;
+    this._lastSeq = null;
   };
 
   getCurHtml(firstID: Id, lastID: Id, includeIDs: boolean): any {
@@ -2679,6 +2694,9 @@ export class RogueEditor extends HTMLElement {
           html = this.rogue.GetHtmlXRay(firstID, lastID, includeIDs);
           console.log("🎨 getHtmlXRay", this.address);
           break;
+        case "blame":
+          html = this.rogue.GetHtmlBlame(firstID, lastID, includeIDs);
+          break;
         default:
           html = this.rogue.GetHtml(firstID, lastID, includeIDs);
           if (this.debug) {
@@ -2763,6 +2781,9 @@ export class RogueEditor extends HTMLElement {
       case "xray":
         this.disable();
         break;
+      case "blame":
+        this.disable();
+        break;
       case "scrub":
         this.disable();
         break;
@@ -2784,11 +2805,17 @@ export class RogueEditor extends HTMLElement {
       console.log("Connected to WS Server");
     }
 
+    // if we already have the doc, the server only needs to send what we missed
+    const catchUp = this.rogue && this._lastSeq !== null;
+    this._offlineBaseAddress = catchUp ? this.currentContentAddress() : null;
+
     this.network?.send(
       JSON.stringify({
         type: "subscribe",
         docID: this.docID,
         authorID: this.operationManager?.authorId,
+        contentAddress: catchUp ? this.currentContentAddress() : undefined,
+        lastSeq: catchUp ? this._lastSeq : undefined,
       }),
     );
 
@@ -2805,7 +2832,14 @@ export class RogueEditor extends HTMLElement {
       return;
     }
 
-    if (this.operationManager.hasOperations()) {
+    if (
+      this._catchingUp &&
+      this._offlineBaseAddress &&
+      this.operationManager.hasOperations()
+    ) {
+      console.log("onLoaded: Send operations made while offline as a batch");
+      this.sendOfflineBatch(this._offlineBaseAddress);
+    } else if (this.operationManager.hasOperations()) {
       console.log("onLoaded: Merge and send all operations while offline");
       // Merge and send all operations while offline
       const localOps = this.operationManager.getAllOperationsOrderedByIndex();
@@ -2822,7 +2856,7 @@ export class RogueEditor extends HTMLElement {
     }
 
     this.loaded = true;
-    if (!this.address) {
+    if (!this.address && !this._readOnly) {
       this.enable();
     } else {
       this.enabled = false;
@@ -2847,11 +2881,15 @@ export class RogueEditor extends HTMLElement {
         throw new Error("No operation manager");
       }
       this.operationManager.authorId = op.authorID;
-      // possibly not the best way to do this, but
-      // currently the broken websocket connection sends
-      // a new auth event and we need to rebuild the rogue
-      // a better fix could be to modify subscribe and say
-      // "hey I already have a rogue ready to go"
+      this.setAccessLevel(op.accessLevel);
+
+      // the server is only sending the ops we missed, keep our rogue
+      this._catchingUp = !!op.catchUp && !!this.rogue;
+      if (this._catchingUp) {
+        return;
+      }
+
+      // otherwise a snapshot is coming and we need to rebuild the rogue
       if (this.rogue) {
         this.rogue.Exit();
       }
@@ -2878,12 +2916,35 @@ export class RogueEditor extends HTMLElement {
           this.notifySubscribers("cursors", this._cursors);
           this.removeHighlight("cursor-" + op.authorID);
           break;
+        case "opError":
+          console.error("op rejected by server", op.code, op.opID, op.message);
+          // a rejected offline batch stays in local storage
+          this._offlineBatchIds = [];
+          break;
         case "event":
           if (op.event === "loaded") {
+            this._lastSeq = op.data?.seq ?? null;
             this.recvEventBuffer.push({ event: "loaded" });
             setTimeout(this.drainBuffer.bind(this), 0);
           }
 
+          if (op.event === "offlineBatchMerged") {
+            for (const id of this._offlineBatchIds) {
+              this.operationManager?.removeOperation(id);
+            }
+            this._offlineBatchIds = [];
+            this.notifySubscribers("offlineMerge", op.data);
+          }
+
+          if (op.event === "accessChanged") {
+            this.setAccessLevel(op.data?.accessLevel);
+          }
+
+          if (op.event === "accessRevoked") {
+            this._readOnly = true;
+            this.disable();
+          }
+
           if (op.event === "ping") {
             this.send(
               JSON.stringify({
@@ -2904,6 +2965,22 @@ export class RogueEditor extends HTMLElement {
     setTimeout(this.drainBuffer.bind(this), 0);
   }
 
+  setAccessLevel(accessLevel: string | undefined) {
+    // older servers don't send an access level, assume edit access
+    this._readOnly =
+      !!accessLevel && !["write", "owner", "admin"].includes(accessLevel);
+
+    if (!this.loaded) {
+      return;
+    }
+
+    if (this._readOnly) {
+      this.disable();
+    } else if (!this.address) {
+      this.enable();
+    }
+  }
+
   upsertOtherAuthorCursor(msg: any) {
     if (!this.rogue) {
       return;
@@ -3107,6 +3184,42 @@ export class RogueEditor extends HTMLElement {
     this.editing = true;
   }
 
+  // sendOfflineBatch sends all the stored operations as a single multi op so
+  // the server merges them together and reports where they landed
+  sendOfflineBatch(baseAddress: string) {
+    if (!this.operationManager || !this.operationManager.authed) {
+      console.error("Not connected!");
+      return;
+    }
+
+    const stored = this.operationManager.getAllOperationsOrderedByIndex();
+    const mops: Op[] = [];
+    for (const op of stored) {
+      // multi ops can't be nested
+      if (op[0] === 6) {
+        mops.push(...(op[2] as Op[]));
+      } else {
+        mops.push(op as Op);
+      }
+    }
+
+    if (mops.length === 0) {
+      return;
+    }
+
+    this._offlineBatchIds = stored.map((op) => op[1]);
+
+    const batch = [6, mops[0][1], mops];
+    this.send(
+      JSON.stringify({
+        type: "offlineBatch",
+        op: JSON.stringify(batch),
+        contentAddress: baseAddress,
+      }),
+    );
+    this.editing = true;
+  }
+
   sendEvent(event: string, data?: any) {
     if (this.debug) {
       console.log("[wire] send event", JSON.stringify(event));
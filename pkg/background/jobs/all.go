@@ -2,6 +2,9 @@ package jobs
 
 var AllJobs = []any{
 	AccessDocJob,
+	EmbedAttachmentJob,
+	EmbedDocumentJob,
+	IndexDocumentJob,
 	PingJob,
 	ProactiveAiMessageJob,
 	RespondToThreadJob,
@@ -15,4 +18,8 @@ var AllJobs = []any{
 	NotifyFirstOpenJob,
 	NotifyNewTimelineCommentJob,
 	NotifyNewMentionShareJob,
+	DeliverWebhookJob,
+	AutoVersionsJob,
+	SendDigestsJob,
+	SendTaskRemindersJob,
 }
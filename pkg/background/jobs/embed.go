@@ -0,0 +1,41 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/fivetentaylor/pointy/pkg/background/wire"
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/service/embeddings"
+)
+
+func EmbedDocumentJob(ctx context.Context, arg *wire.EmbedDocument) error {
+	log := env.Log(ctx)
+
+	log.Info("embed document started", "arg", arg)
+
+	err := embeddings.EmbedDocument(ctx, arg.DocId)
+	if err != nil {
+		log.Errorf("error embedding document: %s", err)
+		return err
+	}
+
+	log.Info("embed document completed", "arg", arg)
+
+	return nil
+}
+
+func EmbedAttachmentJob(ctx context.Context, arg *wire.EmbedAttachment) error {
+	log := env.Log(ctx)
+
+	log.Info("embed attachment started", "arg", arg)
+
+	err := embeddings.EmbedAttachment(ctx, arg.AttachmentId)
+	if err != nil {
+		log.Errorf("error embedding attachment: %s", err)
+		return err
+	}
+
+	log.Info("embed attachment completed", "arg", arg)
+
+	return nil
+}
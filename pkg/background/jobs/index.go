@@ -0,0 +1,54 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/fivetentaylor/pointy/pkg/background/wire"
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/rogue"
+	"github.com/fivetentaylor/pointy/pkg/service/document"
+	"github.com/fivetentaylor/pointy/pkg/service/embeddings"
+)
+
+func IndexDocumentJob(ctx context.Context, arg *wire.IndexDocument) error {
+	log := env.Log(ctx)
+
+	log.Info("index document started", "arg", arg)
+
+	// edits from here on schedule another index
+	err := rogue.ClearIndexPending(ctx, arg.DocId)
+	if err != nil {
+		log.Errorf("error clearing index pending: %s", err)
+	}
+
+	ds := rogue.NewDocStore(env.S3(ctx), env.Query(ctx), env.Redis(ctx))
+	_, doc, err := ds.GetCurrentDoc(ctx, arg.DocId)
+	if err != nil {
+		log.Errorf("error getting current doc: %s", err)
+		return err
+	}
+
+	err = document.IndexContent(ctx, arg.DocId, doc)
+	if err != nil {
+		log.Errorf("error indexing doc content: %s", err)
+		return err
+	}
+
+	// the embeddings for semantic search are only stale if the text changed
+	// since they were made, formatting changes don't affect them
+	changed, err := embeddings.ContentChanged(ctx, arg.DocId, doc)
+	if err != nil {
+		log.Errorf("error checking embedded content: %s", err)
+	}
+
+	if changed {
+		_, err = env.Background(ctx).Enqueue(ctx, &wire.EmbedDocument{DocId: arg.DocId})
+		if err != nil {
+			log.Errorf("error enqueueing embed document job: %s", err)
+		}
+	}
+
+	log.Info("index document completed", "arg", arg)
+
+	return nil
+}
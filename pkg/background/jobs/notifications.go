@@ -3,6 +3,7 @@ package jobs
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/fivetentaylor/pointy/pkg/background/wire"
 	"github.com/fivetentaylor/pointy/pkg/env"
@@ -51,3 +52,17 @@ func NotifyNewMentionShareJob(ctx context.Context, args *wire.NotifyNewMentionSh
 
 	return nil
 }
+
+// SendDigestsJob emails the hourly and daily digests that are due, the worker
+// enqueues it every notifications.DigestSweepInterval
+func SendDigestsJob(ctx context.Context, args *wire.SendDigests) error {
+	log := env.Log(ctx)
+
+	err := notifications.SendDigests(ctx, time.Now())
+	if err != nil {
+		log.Errorf("error sending digests: %s", err)
+		return err
+	}
+
+	return nil
+}
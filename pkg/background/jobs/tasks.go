@@ -0,0 +1,24 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/fivetentaylor/pointy/pkg/background/wire"
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/service/tasks"
+)
+
+// SendTaskRemindersJob emails assignees about their comment tasks that are
+// due, the worker enqueues it every tasks.ReminderSweepInterval
+func SendTaskRemindersJob(ctx context.Context, args *wire.SendTaskReminders) error {
+	log := env.Log(ctx)
+
+	err := tasks.SendReminders(ctx, time.Now())
+	if err != nil {
+		log.Errorf("error sending task reminders: %s", err)
+		return err
+	}
+
+	return nil
+}
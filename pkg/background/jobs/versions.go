@@ -0,0 +1,33 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/fivetentaylor/pointy/pkg/background/wire"
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/service/versions"
+)
+
+// AutoVersionsJob makes the automatic versions that are due and thins out
+// old ones, the worker enqueues it every versions.SweepInterval
+func AutoVersionsJob(ctx context.Context, arg *wire.AutoVersions) error {
+	log := env.Log(ctx)
+
+	now := time.Now()
+	policy := versions.PolicyFromEnv(ctx)
+
+	err := versions.CreateDue(ctx, now, policy)
+	if err != nil {
+		log.Errorf("error creating auto versions: %s", err)
+		return err
+	}
+
+	err = versions.PruneAll(ctx, now, policy)
+	if err != nil {
+		log.Errorf("error pruning auto versions: %s", err)
+		return err
+	}
+
+	return nil
+}
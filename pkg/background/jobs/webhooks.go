@@ -0,0 +1,21 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/fivetentaylor/pointy/pkg/background/wire"
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/service/webhooks"
+)
+
+func DeliverWebhookJob(ctx context.Context, arg *wire.DeliverWebhook) error {
+	log := env.Log(ctx)
+
+	err := webhooks.Deliver(ctx, arg.DeliveryId)
+	if err != nil {
+		log.Errorf("error delivering webhook: %s", err)
+		return err
+	}
+
+	return nil
+}
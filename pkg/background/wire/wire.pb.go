@@ -1174,6 +1174,308 @@ func (x *NotifyFirstOpen) GetReaderId() string {
 	return ""
 }
 
+type EmbedDocument struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DocId string `protobuf:"bytes,1,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
+}
+
+func (x *EmbedDocument) Reset() {
+	*x = EmbedDocument{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_background_wire_wire_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmbedDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbedDocument) ProtoMessage() {}
+
+func (x *EmbedDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_background_wire_wire_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbedDocument.ProtoReflect.Descriptor instead.
+func (*EmbedDocument) Descriptor() ([]byte, []int) {
+	return file_pkg_background_wire_wire_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *EmbedDocument) GetDocId() string {
+	if x != nil {
+		return x.DocId
+	}
+	return ""
+}
+
+type EmbedAttachment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AttachmentId string `protobuf:"bytes,1,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+}
+
+func (x *EmbedAttachment) Reset() {
+	*x = EmbedAttachment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_background_wire_wire_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmbedAttachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbedAttachment) ProtoMessage() {}
+
+func (x *EmbedAttachment) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_background_wire_wire_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbedAttachment.ProtoReflect.Descriptor instead.
+func (*EmbedAttachment) Descriptor() ([]byte, []int) {
+	return file_pkg_background_wire_wire_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *EmbedAttachment) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+type IndexDocument struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DocId string `protobuf:"bytes,1,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
+}
+
+func (x *IndexDocument) Reset() {
+	*x = IndexDocument{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_background_wire_wire_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IndexDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexDocument) ProtoMessage() {}
+
+func (x *IndexDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_background_wire_wire_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexDocument.ProtoReflect.Descriptor instead.
+func (*IndexDocument) Descriptor() ([]byte, []int) {
+	return file_pkg_background_wire_wire_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *IndexDocument) GetDocId() string {
+	if x != nil {
+		return x.DocId
+	}
+	return ""
+}
+
+type DeliverWebhook struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeliveryId string `protobuf:"bytes,1,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+}
+
+func (x *DeliverWebhook) Reset() {
+	*x = DeliverWebhook{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_background_wire_wire_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliverWebhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliverWebhook) ProtoMessage() {}
+
+func (x *DeliverWebhook) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_background_wire_wire_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliverWebhook.ProtoReflect.Descriptor instead.
+func (*DeliverWebhook) Descriptor() ([]byte, []int) {
+	return file_pkg_background_wire_wire_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeliverWebhook) GetDeliveryId() string {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return ""
+}
+
+type AutoVersions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AutoVersions) Reset() {
+	*x = AutoVersions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_background_wire_wire_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AutoVersions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutoVersions) ProtoMessage() {}
+
+func (x *AutoVersions) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_background_wire_wire_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutoVersions.ProtoReflect.Descriptor instead.
+func (*AutoVersions) Descriptor() ([]byte, []int) {
+	return file_pkg_background_wire_wire_proto_rawDescGZIP(), []int{22}
+}
+
+type SendDigests struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SendDigests) Reset() {
+	*x = SendDigests{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_background_wire_wire_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendDigests) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendDigests) ProtoMessage() {}
+
+func (x *SendDigests) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_background_wire_wire_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendDigests.ProtoReflect.Descriptor instead.
+func (*SendDigests) Descriptor() ([]byte, []int) {
+	return file_pkg_background_wire_wire_proto_rawDescGZIP(), []int{23}
+}
+
+type SendTaskReminders struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SendTaskReminders) Reset() {
+	*x = SendTaskReminders{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_background_wire_wire_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendTaskReminders) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendTaskReminders) ProtoMessage() {}
+
+func (x *SendTaskReminders) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_background_wire_wire_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendTaskReminders.ProtoReflect.Descriptor instead.
+func (*SendTaskReminders) Descriptor() ([]byte, []int) {
+	return file_pkg_background_wire_wire_proto_rawDescGZIP(), []int{24}
+}
+
 var File_pkg_background_wire_wire_proto protoreflect.FileDescriptor
 
 var file_pkg_background_wire_wire_proto_rawDesc = []byte{
@@ -1297,18 +1599,33 @@ var file_pkg_background_wire_wire_proto_rawDesc = []byte{
 	0x65, 0x6e, 0x12, 0x15, 0x0a, 0x06, 0x64, 0x6f, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x05, 0x64, 0x6f, 0x63, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x61,
 	0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x49, 0x64, 0x2a, 0x72, 0x0a, 0x16, 0x50, 0x72, 0x6f, 0x61, 0x63, 0x74,
-	0x69, 0x76, 0x65, 0x41, 0x69, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x2a, 0x0a, 0x26, 0x50, 0x52, 0x4f, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x41, 0x49,
-	0x5f, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4e, 0x45,
-	0x57, 0x5f, 0x44, 0x4f, 0x43, 0x55, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x00, 0x12, 0x2c, 0x0a, 0x28,
-	0x50, 0x52, 0x4f, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x41, 0x49, 0x5f, 0x4d, 0x45, 0x53,
-	0x53, 0x41, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x41, 0x53, 0x54, 0x45, 0x5f,
-	0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x10, 0x01, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x65, 0x61, 0x6d, 0x72, 0x65, 0x76,
-	0x69, 0x73, 0x6f, 0x2f, 0x63, 0x6f, 0x64, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x62, 0x61, 0x63,
-	0x6b, 0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x2f, 0x77, 0x69, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x26, 0x0a, 0x0d, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x44,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x64, 0x6f, 0x63, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x64, 0x6f, 0x63, 0x49, 0x64, 0x22, 0x36,
+	0x0a, 0x0f, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e,
+	0x74, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68,
+	0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x26, 0x0a, 0x0d, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x44,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x64, 0x6f, 0x63, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x64, 0x6f, 0x63, 0x49, 0x64, 0x22, 0x31,
+	0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b,
+	0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x49,
+	0x64, 0x22, 0x0e, 0x0a, 0x0c, 0x41, 0x75, 0x74, 0x6f, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x22, 0x0d, 0x0a, 0x0b, 0x53, 0x65, 0x6e, 0x64, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73,
+	0x22, 0x13, 0x0a, 0x11, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x6d, 0x69,
+	0x6e, 0x64, 0x65, 0x72, 0x73, 0x2a, 0x72, 0x0a, 0x16, 0x50, 0x72, 0x6f, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x41, 0x69, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x2a, 0x0a, 0x26, 0x50, 0x52, 0x4f, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x41, 0x49, 0x5f,
+	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4e, 0x45, 0x57,
+	0x5f, 0x44, 0x4f, 0x43, 0x55, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x00, 0x12, 0x2c, 0x0a, 0x28, 0x50,
+	0x52, 0x4f, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x41, 0x49, 0x5f, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x41, 0x53, 0x54, 0x45, 0x5f, 0x52,
+	0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x10, 0x01, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x69, 0x76, 0x65, 0x74, 0x65, 0x6e, 0x74,
+	0x61, 0x79, 0x6c, 0x6f, 0x72, 0x2f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x79, 0x2f, 0x70, 0x6b, 0x67,
+	0x2f, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x2f, 0x77, 0x69, 0x72, 0x65,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1324,7 +1641,7 @@ func file_pkg_background_wire_wire_proto_rawDescGZIP() []byte {
 }
 
 var file_pkg_background_wire_wire_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_pkg_background_wire_wire_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_pkg_background_wire_wire_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_pkg_background_wire_wire_proto_goTypes = []any{
 	(ProactiveAiMessageType)(0),      // 0: wire.ProactiveAiMessageType
 	(*Ping)(nil),                     // 1: wire.Ping
@@ -1345,11 +1662,18 @@ var file_pkg_background_wire_wire_proto_goTypes = []any{
 	(*NotifyNewTimelineComment)(nil), // 16: wire.NotifyNewTimelineComment
 	(*NotifyNewMentionShare)(nil),    // 17: wire.NotifyNewMentionShare
 	(*NotifyFirstOpen)(nil),          // 18: wire.NotifyFirstOpen
-	nil,                              // 19: wire.RunDag.StateEntry
+	(*EmbedDocument)(nil),            // 19: wire.EmbedDocument
+	(*EmbedAttachment)(nil),          // 20: wire.EmbedAttachment
+	(*IndexDocument)(nil),            // 21: wire.IndexDocument
+	(*DeliverWebhook)(nil),           // 22: wire.DeliverWebhook
+	(*AutoVersions)(nil),             // 23: wire.AutoVersions
+	(*SendDigests)(nil),              // 24: wire.SendDigests
+	(*SendTaskReminders)(nil),        // 25: wire.SendTaskReminders
+	nil,                              // 26: wire.RunDag.StateEntry
 }
 var file_pkg_background_wire_wire_proto_depIdxs = []int32{
 	0,  // 0: wire.ProactiveAiMessage.type:type_name -> wire.ProactiveAiMessageType
-	19, // 1: wire.RunDag.state:type_name -> wire.RunDag.StateEntry
+	26, // 1: wire.RunDag.state:type_name -> wire.RunDag.StateEntry
 	2,  // [2:2] is the sub-list for method output_type
 	2,  // [2:2] is the sub-list for method input_type
 	2,  // [2:2] is the sub-list for extension type_name
@@ -1579,6 +1903,90 @@ func file_pkg_background_wire_wire_proto_init() {
 				return nil
 			}
 		}
+		file_pkg_background_wire_wire_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*EmbedDocument); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_background_wire_wire_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*EmbedAttachment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_background_wire_wire_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*IndexDocument); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_background_wire_wire_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*DeliverWebhook); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_background_wire_wire_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*AutoVersions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_background_wire_wire_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*SendDigests); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_background_wire_wire_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*SendTaskReminders); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1586,7 +1994,7 @@ func file_pkg_background_wire_wire_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_background_wire_wire_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   19,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
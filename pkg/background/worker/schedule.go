@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// schedule enqueues msg every interval. When several workers are running a
+// redis lock makes sure only one of them does.
+func (w *Worker) schedule(name string, interval time.Duration, msg proto.Message) {
+	lockKey := "worker:schedule:" + name
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+			ok, err := w.redis.SetNX(w.ctx, lockKey, "1", interval-interval/10).Result()
+			if err != nil {
+				w.log.Error("error locking schedule", "name", name, "error", err)
+				continue
+			}
+
+			if !ok {
+				continue
+			}
+
+			_, err = w.bg.Enqueue(w.ctx, msg)
+			if err != nil {
+				w.log.Error("error enqueueing scheduled job", "name", name, "error", err)
+			}
+		}
+	}
+}
@@ -17,12 +17,16 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/fivetentaylor/pointy/pkg/background/jobs"
+	"github.com/fivetentaylor/pointy/pkg/background/wire"
 	"github.com/fivetentaylor/pointy/pkg/client"
 	"github.com/fivetentaylor/pointy/pkg/config"
 	"github.com/fivetentaylor/pointy/pkg/env"
 	"github.com/fivetentaylor/pointy/pkg/graph/loaders"
 	"github.com/fivetentaylor/pointy/pkg/pubsub"
 	"github.com/fivetentaylor/pointy/pkg/query"
+	"github.com/fivetentaylor/pointy/pkg/service/notifications"
+	"github.com/fivetentaylor/pointy/pkg/service/tasks"
+	"github.com/fivetentaylor/pointy/pkg/service/versions"
 	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
 	"github.com/fivetentaylor/pointy/pkg/storage/s3"
 	"github.com/fivetentaylor/pointy/pkg/utils"
@@ -175,6 +179,9 @@ func (w *Worker) Attach(ctx context.Context, job *cwire.Job, next func(ctx conte
 
 func (w *Worker) Run() error {
 	w.log.Info("running worker", "addr", w.cfg.Addr)
+	go w.schedule("auto_versions", versions.SweepInterval, &wire.AutoVersions{})
+	go w.schedule("digests", notifications.DigestSweepInterval, &wire.SendDigests{})
+	go w.schedule("task_reminders", tasks.ReminderSweepInterval, &wire.SendTaskReminders{})
 	err := w.Conveyor.Run(w.ctx)
 	w.log.Info("worker stopped", "error", err)
 	return err
@@ -2,13 +2,27 @@ package constants
 
 // Restricted at the DB level, update document_access table if you change these
 const (
-	AccessLevelNone  = "none" // No access, does not exist in DB
-	AccessLevelRead  = "read"
-	AccessLevelWrite = "write"
-	AccessLevelOwner = "owner"
-	AccessLevelAdmin = "admin"
+	AccessLevelNone    = "none" // No access, does not exist in DB
+	AccessLevelRead    = "read"
+	AccessLevelComment = "comment"
+	AccessLevelWrite   = "write"
+	AccessLevelOwner   = "owner"
+	AccessLevelAdmin   = "admin"
 )
 
-var AccessLevels = []string{AccessLevelRead, AccessLevelWrite, AccessLevelOwner, AccessLevelAdmin}
+var AccessLevels = []string{AccessLevelRead, AccessLevelComment, AccessLevelWrite, AccessLevelOwner, AccessLevelAdmin}
 
 var AccessLevelsWithEdit = []string{AccessLevelWrite, AccessLevelOwner, AccessLevelAdmin}
+
+// Scopes an api token can be granted, stored comma separated in api_tokens
+const (
+	APITokenScopeReadDocs  = "read_docs"
+	APITokenScopeWriteDocs = "write_docs"
+	APITokenScopeComment   = "comment"
+	APITokenScopeAdmin     = "admin"
+)
+
+var APITokenScopes = []string{APITokenScopeReadDocs, APITokenScopeWriteDocs, APITokenScopeComment, APITokenScopeAdmin}
+
+// APITokenPrefix starts every api token so they can be told apart from jwts
+const APITokenPrefix = "pty_"
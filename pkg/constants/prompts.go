@@ -8,4 +8,6 @@ const (
 	PromptNewDoc                 = "new doc message"
 	PromptSummarizeDocDiff       = "summarize doc diff"
 	PromptSummarizeCommentThread = "summarize comment thread"
+	PromptThreadAsk              = "threadv2-ask"
+	PromptThreadRevise           = "threadv2-revise"
 )
@@ -8,6 +8,7 @@ const (
 	MsgUpsertChanFormat              = "chanChanMsgs:%s"             // channelID
 	ChannelUpsertChanFormat          = "chanDocChans:%s"             // docID
 	DocUpdateChanFormat              = "chanDocUpdates:%s"           // docID (rogue updates)
+	DocAccessChanFormat              = "chanDocAccess:%s"            // docID (access level changes)
 	ActivityUnreadChanFormat         = "chanUnreadActivity:%s"       // userID
 	UnreadChannelUpdateChanFormat    = "chanUnreadChannels:%s:%s"    // docID, userID
 	UnreadMessageUpdateChanFormat    = "chanUnreadMessages:%s:%s"    // docID, userID
@@ -15,12 +16,19 @@ const (
 	ChannelTimelineEventUpdateFormat = "chanTimelineEventsUpdate:%s" // docID
 	ChannelTimelineEventInsertFormat = "chanTimelineEventsInsert:%s" // docID
 	ChannelTimelineEventDeleteFormat = "chanTimelineEventsDelete:%s" // docID
+	DocPresenceChanFormat            = "chanDocPresence:%s"          // docID
+	NotificationChanFormat           = "chanNotifications:%s"        // userID
 )
 
 const (
-	DocCounterKeyFormat     = "doc:%s:counter"           // docID
-	DocEventsKeyFormat      = "doc:%s:events"            // docID
-	DocActiveConnectionsKey = "doc:%s:connections"       // docID
-	DocUserConnectionKey    = "doc:%s:user:%s:author:%s" // docID, userID, authorID
-	DocUserLastMessageKey   = "doc:%s:user:%s:message"   // docID, userID
+	DocCounterKeyFormat     = "doc:%s:counter"            // docID
+	DocEventsKeyFormat      = "doc:%s:events"             // docID
+	DocActiveConnectionsKey = "doc:%s:connections"        // docID
+	DocUserConnectionKey    = "doc:%s:user:%s:author:%s"  // docID, userID, authorID
+	DocUserLastMessageKey   = "doc:%s:user:%s:message"    // docID, userID
+	DocPresenceKey          = "doc:%s:presence"           // docID
+	DocIndexPendingKey      = "doc:%s:index_pending"      // docID
+	DocUserAPIAuthorKey     = "doc:%s:user:%s:api_author" // docID, userID
+	DocAuthorEditLockKey    = "doc:%s:author:%s:editing"  // docID, authorID
+	DocEmbeddedHashKey      = "doc:%s:embedded_hash"      // docID
 )
@@ -6,6 +6,7 @@ import (
 
 	"github.com/fivetentaylor/pointy/pkg/env"
 	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/service/versions"
 	v3 "github.com/fivetentaylor/pointy/rogue/v3"
 )
 
@@ -43,6 +44,12 @@ func ApplyUpdate(
 		afterId = input.EditTarget.AfterID
 	}
 
+	// checkpoint the document so the revision can be undone from its versions
+	_, err = versions.CreateAuto(ctx, input.DocId, input.Document, versions.NameBeforeAI)
+	if err != nil {
+		log.Error("error creating version before revision", "error", err)
+	}
+
 	updatedText := input.Update
 	mop := v3.MultiOp{}
 	if input.EditTarget != nil {
@@ -93,6 +93,7 @@ func (n *AskNode) Run(ctx context.Context) (Node, error) {
 		return nil, fmt.Errorf("error getting output message: %s", err)
 	}
 
+	outMsg.MessageMetadata.PromptName = constants.PromptThreadAsk
 	err = messaging.UpdateMessage(ctx, outMsg)
 	if err != nil {
 		return nil, fmt.Errorf("error updating message: %s", err)
@@ -110,7 +111,7 @@ func (n *AskNode) Run(ctx context.Context) (Node, error) {
 	_, err = n.GenerateStoredPrompt(
 		ctx,
 		input.ThreadId,
-		"threadv2-ask",
+		constants.PromptThreadAsk,
 		data,
 		llms.WithStreamingFunc(
 			n.receiveStreamFunc(ctx, buffer, outMsg),
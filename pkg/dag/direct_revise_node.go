@@ -63,6 +63,7 @@ func (n *DirectReviseNode) Run(ctx context.Context) (Node, error) {
 		outMsg.LifecycleReason = "Making selected changes..."
 	}
 	outMsg.LifecycleStage = dynamo.MessageLifecycleStagePending
+	outMsg.MessageMetadata.PromptName = constants.PromptThreadRevise
 	err = messaging.UpdateMessage(ctx, outMsg)
 	if err != nil {
 		return nil, fmt.Errorf("error updating message before streaming: %s", err)
@@ -75,7 +76,7 @@ func (n *DirectReviseNode) Run(ctx context.Context) (Node, error) {
 
 	_, err = n.GenerateStoredPrompt(
 		ctx,
-		input.ThreadId, "threadv2-revise",
+		input.ThreadId, constants.PromptThreadRevise,
 		data,
 		llms.WithStreamingFunc(
 			n.receiveStreamFunc(ctx, input, document, outMsg, state, appliedUpdateIds),
@@ -0,0 +1,181 @@
+package dag
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"strings"
+	"unicode"
+
+	aiplatform "cloud.google.com/go/aiplatform/apiv1"
+	"cloud.google.com/go/aiplatform/apiv1/aiplatformpb"
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/llms/openai"
+	"google.golang.org/api/option"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/fivetentaylor/pointy/pkg/stackerr"
+)
+
+const (
+	DefaultOpenAIEmbeddingModel = "text-embedding-3-small"
+	DefaultVertexEmbeddingModel = "text-embedding-004"
+
+	TestEmbeddingDimensions = 256
+)
+
+// GetEmbedder returns a client that embeds text with the adapter's provider,
+// Model is the embedding model, not the chat model
+func (n *DefaultLLMAdapter) GetEmbedder(ctx context.Context) (embeddings.EmbedderClient, error) {
+	switch n.GetProvider() {
+	case OpenAI:
+		model := n.Model
+		if model == "" {
+			model = DefaultOpenAIEmbeddingModel
+		}
+		return n.getOpenAIEmbedder(ctx, model)
+	case VertexAI:
+		model := n.Model
+		if model == "" {
+			model = DefaultVertexEmbeddingModel
+		}
+		return n.getVertexAIEmbedder(ctx, model)
+	case TestProvider:
+		return &TestEmbedder{Dimensions: TestEmbeddingDimensions}, nil
+	default:
+		return nil, stackerr.New(fmt.Errorf("provider %q does not support embeddings", n.GetProvider()))
+	}
+}
+
+func (n *DefaultLLMAdapter) getOpenAIEmbedder(ctx context.Context, model string) (*openai.LLM, error) {
+	if os.Getenv("OPENAI_API_KEY") == "" {
+		return nil, stackerr.New(fmt.Errorf("OPENAI_API_KEY is not set"))
+	}
+
+	return openai.New(
+		openai.WithEmbeddingModel(model),
+		openai.WithBaseURL("https://api.openai.com/v1"),
+		openai.WithToken(os.Getenv("OPENAI_API_KEY")),
+		openai.WithHTTPClient(n.GetHTTPClient(ctx)),
+	)
+}
+
+// VertexEmbedder embeds text with a Vertex AI embedding model. langchaingo's
+// vertex client always embeds with textembedding-gecko, whatever its default
+// embedding model is set to, so the model is called directly.
+type VertexEmbedder struct {
+	client   *aiplatform.PredictionClient
+	endpoint string
+}
+
+func (n *DefaultLLMAdapter) getVertexAIEmbedder(ctx context.Context, model string) (*VertexEmbedder, error) {
+	settings, err := getVertexSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	// the prediction client only supports grpc
+	client, err := aiplatform.NewPredictionClient(ctx,
+		option.WithEndpoint(fmt.Sprintf("%s-aiplatform.googleapis.com:443", settings.Location)),
+		option.WithCredentialsJSON(settings.Credentials),
+	)
+	if err != nil {
+		return nil, stackerr.New(fmt.Errorf("failed to create vertex prediction client: %w", err))
+	}
+
+	return &VertexEmbedder{
+		client: client,
+		endpoint: fmt.Sprintf(
+			"projects/%s/locations/%s/publishers/google/models/%s",
+			settings.ProjectID, settings.Location, model,
+		),
+	}, nil
+}
+
+func (v *VertexEmbedder) CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error) {
+	instances := make([]*structpb.Value, len(texts))
+	for i, text := range texts {
+		instance, err := structpb.NewValue(map[string]any{"content": text})
+		if err != nil {
+			return nil, stackerr.New(fmt.Errorf("error building embedding request: %w", err))
+		}
+		instances[i] = instance
+	}
+
+	resp, err := v.client.Predict(ctx, &aiplatformpb.PredictRequest{
+		Endpoint:  v.endpoint,
+		Instances: instances,
+	})
+	if err != nil {
+		return nil, stackerr.New(fmt.Errorf("error creating embeddings: %w", err))
+	}
+
+	out := make([][]float32, len(resp.Predictions))
+	for i, prediction := range resp.Predictions {
+		embedding := prediction.GetStructValue().GetFields()["embeddings"].GetStructValue()
+		values := embedding.GetFields()["values"].GetListValue().GetValues()
+		if len(values) == 0 {
+			return nil, stackerr.New(fmt.Errorf("embedding %d has no values", i))
+		}
+
+		vec := make([]float32, len(values))
+		for j, value := range values {
+			vec[j] = float32(value.GetNumberValue())
+		}
+		out[i] = vec
+	}
+
+	return out, nil
+}
+
+// TestEmbedder is a deterministic local stand in for an embedding model, it
+// hashes each word into a bucket so texts that share words are similar
+type TestEmbedder struct {
+	Dimensions int
+}
+
+func (t *TestEmbedder) CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, len(texts))
+	for i, text := range texts {
+		out[i] = t.embed(text)
+	}
+
+	return out, nil
+}
+
+func (t *TestEmbedder) embed(text string) []float32 {
+	vec := make([]float32, t.Dimensions)
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		h := fnv.New32a()
+		h.Write([]byte(word))
+		sum := h.Sum32()
+
+		// the top bit picks the sign so unrelated words tend to cancel out
+		if sum&(1<<31) == 0 {
+			vec[sum%uint32(t.Dimensions)]++
+		} else {
+			vec[sum%uint32(t.Dimensions)]--
+		}
+	}
+
+	var norm float64
+	for _, v := range vec {
+		norm += float64(v * v)
+	}
+	if norm == 0 {
+		return vec
+	}
+
+	norm = math.Sqrt(norm)
+	for i := range vec {
+		vec[i] = float32(float64(vec[i]) / norm)
+	}
+
+	return vec
+}
@@ -0,0 +1,54 @@
+package dag_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fivetentaylor/pointy/pkg/dag"
+)
+
+func dot(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+func TestTestEmbedder(t *testing.T) {
+	adapter := &dag.DefaultLLMAdapter{Provider: dag.TestProvider}
+	embedder, err := adapter.GetEmbedder(context.Background())
+	require.NoError(t, err)
+
+	vecs, err := embedder.CreateEmbedding(context.Background(), []string{
+		"Onboarding churn is up this quarter",
+		"onboarding CHURN, is up this quarter!",
+		"the recipe needs more garlic",
+		"",
+	})
+	require.NoError(t, err)
+	require.Len(t, vecs, 4)
+
+	for _, v := range vecs {
+		assert.Len(t, v, dag.TestEmbeddingDimensions)
+	}
+
+	// case and punctuation don't matter and vectors are unit length
+	assert.Equal(t, vecs[0], vecs[1])
+	assert.InDelta(t, 1.0, dot(vecs[0], vecs[0]), 1e-5)
+	assert.Greater(t, dot(vecs[0], vecs[1]), dot(vecs[0], vecs[2]))
+	assert.Equal(t, float32(0), dot(vecs[3], vecs[3]))
+
+	again, err := embedder.CreateEmbedding(context.Background(), []string{"Onboarding churn is up this quarter"})
+	require.NoError(t, err)
+	assert.Equal(t, vecs[0], again[0])
+}
+
+func TestGetEmbedderUnsupported(t *testing.T) {
+	adapter := &dag.DefaultLLMAdapter{Provider: dag.Anthropic}
+	_, err := adapter.GetEmbedder(context.Background())
+	require.Error(t, err)
+}
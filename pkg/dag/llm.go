@@ -186,6 +186,29 @@ func (n *DefaultLLMAdapter) getTest(ctx context.Context, model string) (*TestLLM
 }
 
 func (n *DefaultLLMAdapter) getVertexAI(ctx context.Context, model string) (*vertex.Vertex, error) {
+	settings, err := getVertexSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	return vertex.New(ctx,
+		googleai.WithCloudProject(settings.ProjectID),
+		googleai.WithCloudLocation(settings.Location),
+		googleai.WithCredentialsJSON(settings.Credentials),
+		// googleai.WithCredentialsFile(credsFiles),
+		googleai.WithDefaultModel(model),
+	)
+}
+
+// vertexSettings are what every vertex client is created with, they come
+// from the environment
+type vertexSettings struct {
+	ProjectID   string
+	Location    string
+	Credentials []byte
+}
+
+func getVertexSettings() (*vertexSettings, error) {
 	creds := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
 	if creds == "" {
 		return nil, stackerr.New(fmt.Errorf("GOOGLE_APPLICATION_CREDENTIALS is not set"))
@@ -214,13 +237,11 @@ func (n *DefaultLLMAdapter) getVertexAI(ctx context.Context, model string) (*ver
 		location = "us-central1"
 	}
 
-	return vertex.New(ctx,
-		googleai.WithCloudProject(projectID),
-		googleai.WithCloudLocation(location),
-		googleai.WithCredentialsJSON(decodedCreds),
-		// googleai.WithCredentialsFile(credsFiles),
-		googleai.WithDefaultModel(model),
-	)
+	return &vertexSettings{
+		ProjectID:   projectID,
+		Location:    location,
+		Credentials: decodedCreds,
+	}, nil
 }
 
 func (n *DefaultLLMAdapter) GetHTTPClient(ctx context.Context) *HTTPLogger {
@@ -155,6 +155,7 @@ func (n *ReviseNode) Run(ctx context.Context) (Node, error) {
 		outMsg.LifecycleReason = "Making selected changes..."
 	}
 	outMsg.LifecycleStage = dynamo.MessageLifecycleStagePending
+	outMsg.MessageMetadata.PromptName = constants.PromptThreadRevise
 	err = messaging.UpdateMessage(ctx, outMsg)
 	if err != nil {
 		return nil, fmt.Errorf("error updating message before streaming: %s", err)
@@ -164,7 +165,7 @@ func (n *ReviseNode) Run(ctx context.Context) (Node, error) {
 
 	_, err = n.GenerateStoredPrompt(
 		ctx,
-		input.ThreadId, "threadv2-revise",
+		input.ThreadId, constants.PromptThreadRevise,
 		data,
 		llms.WithStreamingFunc(
 			n.receiveStreamFunc(ctx, input, document, outMsg, appliedUpdateIds),
@@ -10,6 +10,7 @@ import (
 	"github.com/fivetentaylor/pointy/pkg/env"
 	"github.com/fivetentaylor/pointy/pkg/models"
 	"github.com/fivetentaylor/pointy/pkg/service/messaging"
+	"github.com/fivetentaylor/pointy/pkg/service/versions"
 	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
 	"github.com/fivetentaylor/pointy/pkg/utils"
 	v3 "github.com/fivetentaylor/pointy/rogue/v3"
@@ -58,6 +59,7 @@ func (n *SerialReviseNode) Run(ctx context.Context) (Node, error) {
 	saveLogFile(ctx, "chunks.json", chunks)
 
 	outMsg.LifecycleStage = dynamo.MessageLifecycleStageRevising
+	outMsg.MessageMetadata.PromptName = constants.PromptThreadRevise
 
 	for i, chunk := range chunks {
 		outMsg.LifecycleReason = fmt.Sprintf("Revising %d of %d", i+1, len(chunks))
@@ -74,7 +76,7 @@ func (n *SerialReviseNode) Run(ctx context.Context) (Node, error) {
 
 		_, err = n.GenerateStoredPrompt(
 			ctx,
-			input.ThreadId, "threadv2-revise",
+			input.ThreadId, constants.PromptThreadRevise,
 			data,
 			llms.WithStreamingFunc(
 				n.receiveStreamFunc(ctx, input, document, outMsg, chunk),
@@ -284,6 +286,12 @@ func (n *SerialReviseNode) applyUpdate(
 		}
 	}
 
+	// checkpoint the document so the revision can be undone from its versions
+	_, err = versions.CreateAuto(ctx, input.DocId, document, versions.NameBeforeAI)
+	if err != nil {
+		env.SLog(ctx).Error("error creating version before revision", "error", err)
+	}
+
 	mop, _, err := document.ApplyMarkdownDiff(input.AuthorId, update, beforeID, editTarget.AfterID)
 	if err != nil {
 		return nil, fmt.Errorf("error applying diff: %s", err)
@@ -5,13 +5,18 @@ import (
 	"fmt"
 
 	"github.com/fivetentaylor/pointy/pkg/models"
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
 )
 
+// ApplySuggestion appends the suggestion to the end of the document, when
+// asSuggestion is set the text is added as a pending suggestion by the author
+// that collaborators can accept or reject instead of a direct edit
 func ApplySuggestion(
 	ctx context.Context,
 	docId string,
 	authorId string,
 	attachment *models.Attachment_Suggestion,
+	asSuggestion bool,
 ) error {
 	suggestion := attachment.Suggestion
 	document, err := GetDocument(ctx, docId, authorId)
@@ -19,23 +24,44 @@ func ApplySuggestion(
 		return fmt.Errorf("error getting document: %s", err)
 	}
 
-	op, err := document.Insert(document.VisSize-1, "\n\n")
-	if err != nil {
-		return fmt.Errorf("error inserting new line: %s", err)
-	}
+	var startID v3.ID
+	if asSuggestion {
+		mop, err := document.SuggestInsert(document.VisSize-1, "\n\n")
+		if err != nil {
+			return fmt.Errorf("error suggesting new line: %s", err)
+		}
 
-	err = PublishOp(ctx, docId, op)
-	if err != nil {
-		return fmt.Errorf("error publishing op: %s", err)
+		err = PublishOp(ctx, docId, mop)
+		if err != nil {
+			return fmt.Errorf("error publishing op: %s", err)
+		}
+
+		startID = mop.Mops[0].(v3.InsertOp).ID
+	} else {
+		op, err := document.Insert(document.VisSize-1, "\n\n")
+		if err != nil {
+			return fmt.Errorf("error inserting new line: %s", err)
+		}
+
+		err = PublishOp(ctx, docId, op)
+		if err != nil {
+			return fmt.Errorf("error publishing op: %s", err)
+		}
+
+		startID = op.ID
 	}
 
-	startID := op.ID
 	endID, err := document.VisRightOf(startID)
 	if err != nil {
 		return fmt.Errorf("error getting end id: %s", err)
 	}
 
-	mop, _, err := document.ApplyMarkdownDiff(authorId, suggestion.Content, startID, endID)
+	var mop v3.MultiOp
+	if asSuggestion {
+		mop, err = document.SuggestMarkdownDiff(authorId, suggestion.Content, startID, endID)
+	} else {
+		mop, _, err = document.ApplyMarkdownDiff(authorId, suggestion.Content, startID, endID)
+	}
 	if err != nil {
 		return fmt.Errorf("error applying diff: %s", err)
 	}
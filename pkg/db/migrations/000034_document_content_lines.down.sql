@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_document_content_lines_content_tsv;
+
+DROP TABLE IF EXISTS document_content_lines CASCADE;
@@ -0,0 +1,14 @@
+-- Full text index of document contents, one row per line of the latest snapshot
+CREATE TABLE document_content_lines (
+    document_id UUID NOT NULL,
+    position INTEGER NOT NULL,
+    start_id TEXT NOT NULL,
+    end_id TEXT NOT NULL,
+    content TEXT NOT NULL,
+    content_tsv TSVECTOR GENERATED ALWAYS AS (to_tsvector('english', content)) STORED,
+
+    PRIMARY KEY (document_id, position),
+    FOREIGN KEY (document_id) REFERENCES documents(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_document_content_lines_content_tsv ON document_content_lines USING gin (content_tsv);
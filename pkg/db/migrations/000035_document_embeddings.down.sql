@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_document_embeddings_attachment_id;
+DROP INDEX IF EXISTS idx_document_embeddings_document_id;
+
+DROP TABLE IF EXISTS document_embeddings CASCADE;
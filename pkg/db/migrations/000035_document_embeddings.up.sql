@@ -0,0 +1,19 @@
+-- Embeddings of document and attachment chunks for semantic search
+CREATE TABLE document_embeddings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    document_id UUID NOT NULL,
+    attachment_id UUID,
+    position INTEGER NOT NULL,
+    start_id TEXT,
+    end_id TEXT,
+    content TEXT NOT NULL,
+    model TEXT NOT NULL,
+    embedding REAL[] NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (document_id) REFERENCES documents(id) ON DELETE CASCADE,
+    FOREIGN KEY (attachment_id) REFERENCES document_attachments(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_document_embeddings_document_id ON document_embeddings(document_id);
+CREATE INDEX idx_document_embeddings_attachment_id ON document_embeddings(attachment_id);
@@ -0,0 +1,2 @@
+ALTER TABLE documents
+DROP COLUMN merged_at;
@@ -0,0 +1,2 @@
+ALTER TABLE documents
+ADD COLUMN merged_at timestamp with time zone;
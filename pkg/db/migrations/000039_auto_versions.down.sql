@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_document_versions_auto_created_at;
+
+ALTER TABLE document_versions
+DROP COLUMN IF EXISTS is_auto;
@@ -0,0 +1,4 @@
+ALTER TABLE document_versions
+ADD COLUMN is_auto boolean DEFAULT false NOT NULL;
+
+CREATE INDEX idx_document_versions_auto_created_at ON document_versions (document_id, created_at) WHERE is_auto;
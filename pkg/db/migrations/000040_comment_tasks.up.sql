@@ -0,0 +1,22 @@
+-- A timeline comment turned into a task. done follows the comment thread's
+-- resolution, reminded is set once the assignee's been emailed on the due date.
+CREATE TABLE comment_tasks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    document_id UUID NOT NULL,
+    event_id TEXT NOT NULL,
+    assignee_id UUID NOT NULL,
+    assigned_by_id UUID NOT NULL,
+    due_date DATE NOT NULL,
+    done BOOLEAN NOT NULL DEFAULT FALSE,
+    reminded BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (document_id) REFERENCES documents(id) ON DELETE CASCADE,
+    FOREIGN KEY (assignee_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (assigned_by_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE (document_id, event_id)
+);
+
+CREATE INDEX idx_comment_tasks_assignee_id ON comment_tasks(assignee_id) WHERE NOT done;
+CREATE INDEX idx_comment_tasks_due_date ON comment_tasks(due_date) WHERE NOT done AND NOT reminded;
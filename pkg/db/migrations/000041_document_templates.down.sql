@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_document_templates_user_id;
+
+DROP TABLE IF EXISTS document_templates CASCADE;
@@ -0,0 +1,20 @@
+-- Documents saved as templates. address pins the content the template was
+-- saved with, placeholders is the comma separated {{name}} fields in it.
+-- Shared templates can be used by everyone the owner collaborates with.
+CREATE TABLE document_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    document_id UUID NOT NULL,
+    address TEXT NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    placeholders TEXT NOT NULL DEFAULT '',
+    shared BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (document_id) REFERENCES documents(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_document_templates_user_id ON document_templates(user_id);
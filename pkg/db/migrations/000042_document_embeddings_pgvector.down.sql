@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_document_embeddings_hnsw_768;
+DROP INDEX IF EXISTS idx_document_embeddings_hnsw_1536;
+
+ALTER TABLE document_embeddings ALTER COLUMN embedding TYPE REAL[] USING embedding::real[];
+
+DROP EXTENSION IF EXISTS vector;
@@ -0,0 +1,15 @@
+-- Store embeddings as pgvector vectors so search can use an ANN index
+CREATE EXTENSION IF NOT EXISTS vector;
+
+ALTER TABLE document_embeddings ALTER COLUMN embedding TYPE vector USING embedding::vector;
+
+-- Vectors from different models have different dimensions and an index needs
+-- a fixed one, so there's an index for each of the embedding models we use.
+-- Embeddings are normalized, so inner product ranks like cosine similarity.
+CREATE INDEX idx_document_embeddings_hnsw_1536 ON document_embeddings
+    USING hnsw ((embedding::vector(1536)) vector_ip_ops)
+    WHERE vector_dims(embedding) = 1536;
+
+CREATE INDEX idx_document_embeddings_hnsw_768 ON document_embeddings
+    USING hnsw ((embedding::vector(768)) vector_ip_ops)
+    WHERE vector_dims(embedding) = 768;
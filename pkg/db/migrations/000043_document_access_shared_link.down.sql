@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_document_access_shared_link_id;
+
+ALTER TABLE document_access
+DROP COLUMN IF EXISTS shared_link_id;
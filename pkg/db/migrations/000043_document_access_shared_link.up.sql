@@ -0,0 +1,4 @@
+ALTER TABLE document_access
+ADD COLUMN shared_link_id integer REFERENCES shared_document_links (id) ON DELETE SET NULL;
+
+CREATE INDEX idx_document_access_shared_link_id ON document_access (shared_link_id);
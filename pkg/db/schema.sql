@@ -154,6 +154,27 @@ SET default_tablespace = '';
 
 SET default_table_access_method = heap;
 
+--
+-- Name: api_tokens; Type: TABLE; Schema: public; Owner: dev
+--
+
+CREATE TABLE public.api_tokens (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    user_id uuid NOT NULL,
+    name text NOT NULL,
+    token_hash text NOT NULL,
+    token_prefix text NOT NULL,
+    scopes text NOT NULL,
+    document_ids text,
+    expires_at timestamp with time zone,
+    last_used_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+ALTER TABLE public.api_tokens OWNER TO dev;
+
 --
 -- Name: author_ids; Type: TABLE; Schema: public; Owner: dev
 --
@@ -235,7 +256,8 @@ CREATE TABLE public.document_access (
     user_id uuid NOT NULL,
     access_level text NOT NULL,
     last_accessed_at timestamp with time zone,
-    CONSTRAINT document_access_access_level_check CHECK ((access_level = ANY (ARRAY['comment'::text, 'write'::text, 'owner'::text, 'admin'::text])))
+    shared_link_id integer,
+    CONSTRAINT document_access_access_level_check CHECK ((access_level = ANY (ARRAY['read'::text, 'comment'::text, 'write'::text, 'owner'::text, 'admin'::text])))
 );
 
 
@@ -282,6 +304,42 @@ CREATE TABLE public.document_attachments (
 
 ALTER TABLE public.document_attachments OWNER TO dev;
 
+--
+-- Name: document_content_lines; Type: TABLE; Schema: public; Owner: dev
+--
+
+CREATE TABLE public.document_content_lines (
+    document_id uuid NOT NULL,
+    "position" integer NOT NULL,
+    start_id text NOT NULL,
+    end_id text NOT NULL,
+    content text NOT NULL,
+    content_tsv tsvector GENERATED ALWAYS AS (to_tsvector('english'::regconfig, content)) STORED
+);
+
+
+ALTER TABLE public.document_content_lines OWNER TO dev;
+
+--
+-- Name: document_embeddings; Type: TABLE; Schema: public; Owner: dev
+--
+
+CREATE TABLE public.document_embeddings (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    document_id uuid NOT NULL,
+    attachment_id uuid,
+    "position" integer NOT NULL,
+    start_id text,
+    end_id text,
+    content text NOT NULL,
+    model text NOT NULL,
+    embedding real[] NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP
+);
+
+
+ALTER TABLE public.document_embeddings OWNER TO dev;
+
 --
 -- Name: document_versions; Type: TABLE; Schema: public; Owner: dev
 --
@@ -294,7 +352,8 @@ CREATE TABLE public.document_versions (
     created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
     updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
     created_by uuid NOT NULL,
-    updated_by uuid NOT NULL
+    updated_by uuid NOT NULL,
+    is_auto boolean DEFAULT false NOT NULL
 );
 
 
@@ -316,7 +375,8 @@ CREATE TABLE public.documents (
     root_parent_id uuid NOT NULL,
     parent_address text,
     is_folder boolean DEFAULT false,
-    folder_id uuid
+    folder_id uuid,
+    merged_at timestamp with time zone
 );
 
 
@@ -565,6 +625,46 @@ CREATE TABLE public.waitlist_users (
 
 ALTER TABLE public.waitlist_users OWNER TO dev;
 
+--
+-- Name: webhook_deliveries; Type: TABLE; Schema: public; Owner: dev
+--
+
+CREATE TABLE public.webhook_deliveries (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    webhook_id uuid NOT NULL,
+    event text NOT NULL,
+    payload text NOT NULL,
+    status text DEFAULT 'pending'::text NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    response_status integer,
+    error text,
+    next_attempt_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+ALTER TABLE public.webhook_deliveries OWNER TO dev;
+
+--
+-- Name: webhooks; Type: TABLE; Schema: public; Owner: dev
+--
+
+CREATE TABLE public.webhooks (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    user_id uuid NOT NULL,
+    document_id uuid,
+    url text NOT NULL,
+    secret text NOT NULL,
+    events text DEFAULT ''::text NOT NULL,
+    active boolean DEFAULT true NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+ALTER TABLE public.webhooks OWNER TO dev;
+
 --
 -- Name: author_ids id; Type: DEFAULT; Schema: public; Owner: dev
 --
@@ -600,6 +700,22 @@ ALTER TABLE ONLY public.prompts ALTER COLUMN id SET DEFAULT nextval('public.prom
 ALTER TABLE ONLY public.shared_document_links ALTER COLUMN id SET DEFAULT nextval('public.shared_document_links_id_seq'::regclass);
 
 
+--
+-- Name: api_tokens api_tokens_pkey; Type: CONSTRAINT; Schema: public; Owner: dev
+--
+
+ALTER TABLE ONLY public.api_tokens
+    ADD CONSTRAINT api_tokens_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: api_tokens api_tokens_token_hash_key; Type: CONSTRAINT; Schema: public; Owner: dev
+--
+
+ALTER TABLE ONLY public.api_tokens
+    ADD CONSTRAINT api_tokens_token_hash_key UNIQUE (token_hash);
+
+
 --
 -- Name: author_ids author_ids_author_id_document_id_key; Type: CONSTRAINT; Schema: public; Owner: dev
 --
@@ -656,6 +772,22 @@ ALTER TABLE ONLY public.document_attachments
     ADD CONSTRAINT document_attachments_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: document_content_lines document_content_lines_pkey; Type: CONSTRAINT; Schema: public; Owner: dev
+--
+
+ALTER TABLE ONLY public.document_content_lines
+    ADD CONSTRAINT document_content_lines_pkey PRIMARY KEY (document_id, "position");
+
+
+--
+-- Name: document_embeddings document_embeddings_pkey; Type: CONSTRAINT; Schema: public; Owner: dev
+--
+
+ALTER TABLE ONLY public.document_embeddings
+    ADD CONSTRAINT document_embeddings_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: document_versions document_versions_pkey; Type: CONSTRAINT; Schema: public; Owner: dev
 --
@@ -784,6 +916,29 @@ ALTER TABLE ONLY public.waitlist_users
     ADD CONSTRAINT waitlist_users_email_key UNIQUE (email);
 
 
+--
+-- Name: webhook_deliveries webhook_deliveries_pkey; Type: CONSTRAINT; Schema: public; Owner: dev
+--
+
+ALTER TABLE ONLY public.webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: webhooks webhooks_pkey; Type: CONSTRAINT; Schema: public; Owner: dev
+--
+
+ALTER TABLE ONLY public.webhooks
+    ADD CONSTRAINT webhooks_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: idx_api_tokens_user_id; Type: INDEX; Schema: public; Owner: dev
+--
+
+CREATE INDEX idx_api_tokens_user_id ON public.api_tokens USING btree (user_id);
+
+
 --
 -- Name: idx_author_document; Type: INDEX; Schema: public; Owner: dev
 --
@@ -805,6 +960,13 @@ CREATE INDEX idx_comments_document_id ON public.comments USING btree (document_i
 CREATE INDEX idx_comments_user_thread ON public.comments USING btree (user_id, thread_id);
 
 
+--
+-- Name: idx_document_access_shared_link_id; Type: INDEX; Schema: public; Owner: dev
+--
+
+CREATE INDEX idx_document_access_shared_link_id ON public.document_access USING btree (shared_link_id);
+
+
 --
 -- Name: idx_document_attachments_document_id; Type: INDEX; Schema: public; Owner: dev
 --
@@ -819,6 +981,27 @@ CREATE INDEX idx_document_attachments_document_id ON public.document_attachments
 CREATE INDEX idx_document_attachments_user_id ON public.document_attachments USING btree (user_id);
 
 
+--
+-- Name: idx_document_content_lines_content_tsv; Type: INDEX; Schema: public; Owner: dev
+--
+
+CREATE INDEX idx_document_content_lines_content_tsv ON public.document_content_lines USING gin (content_tsv);
+
+
+--
+-- Name: idx_document_embeddings_attachment_id; Type: INDEX; Schema: public; Owner: dev
+--
+
+CREATE INDEX idx_document_embeddings_attachment_id ON public.document_embeddings USING btree (attachment_id);
+
+
+--
+-- Name: idx_document_embeddings_document_id; Type: INDEX; Schema: public; Owner: dev
+--
+
+CREATE INDEX idx_document_embeddings_document_id ON public.document_embeddings USING btree (document_id);
+
+
 --
 -- Name: idx_document_versions_document_id; Type: INDEX; Schema: public; Owner: dev
 --
@@ -826,6 +1009,13 @@ CREATE INDEX idx_document_attachments_user_id ON public.document_attachments USI
 CREATE INDEX idx_document_versions_document_id ON public.document_versions USING btree (document_id);
 
 
+--
+-- Name: idx_document_versions_auto_created_at; Type: INDEX; Schema: public; Owner: dev
+--
+
+CREATE INDEX idx_document_versions_auto_created_at ON public.document_versions USING btree (document_id, created_at) WHERE is_auto;
+
+
 --
 -- Name: idx_documents_folder_id; Type: INDEX; Schema: public; Owner: dev
 --
@@ -889,6 +1079,34 @@ CREATE INDEX idx_user_subscriptions_plan_id ON public.user_subscriptions USING b
 CREATE INDEX idx_user_subscriptions_user_id ON public.user_subscriptions USING btree (user_id);
 
 
+--
+-- Name: idx_webhook_deliveries_created_at; Type: INDEX; Schema: public; Owner: dev
+--
+
+CREATE INDEX idx_webhook_deliveries_created_at ON public.webhook_deliveries USING btree (created_at);
+
+
+--
+-- Name: idx_webhook_deliveries_webhook_id; Type: INDEX; Schema: public; Owner: dev
+--
+
+CREATE INDEX idx_webhook_deliveries_webhook_id ON public.webhook_deliveries USING btree (webhook_id);
+
+
+--
+-- Name: idx_webhooks_document_id; Type: INDEX; Schema: public; Owner: dev
+--
+
+CREATE INDEX idx_webhooks_document_id ON public.webhooks USING btree (document_id);
+
+
+--
+-- Name: idx_webhooks_user_id; Type: INDEX; Schema: public; Owner: dev
+--
+
+CREATE INDEX idx_webhooks_user_id ON public.webhooks USING btree (user_id);
+
+
 --
 -- Name: documents set_root_parent_id_trigger; Type: TRIGGER; Schema: public; Owner: dev
 --
@@ -910,6 +1128,14 @@ CREATE TRIGGER update_document_versions_updated_at BEFORE UPDATE ON public.docum
 CREATE TRIGGER update_prompts_updated_at BEFORE UPDATE ON public.prompts FOR EACH ROW EXECUTE FUNCTION public.update_updated_at_column();
 
 
+--
+-- Name: api_tokens api_tokens_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: dev
+--
+
+ALTER TABLE ONLY public.api_tokens
+    ADD CONSTRAINT api_tokens_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
 --
 -- Name: comments comments_document_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: dev
 --
@@ -934,6 +1160,14 @@ ALTER TABLE ONLY public.document_access
     ADD CONSTRAINT document_access_document_id_fkey FOREIGN KEY (document_id) REFERENCES public.documents(id);
 
 
+--
+-- Name: document_access document_access_shared_link_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: dev
+--
+
+ALTER TABLE ONLY public.document_access
+    ADD CONSTRAINT document_access_shared_link_id_fkey FOREIGN KEY (shared_link_id) REFERENCES public.shared_document_links(id) ON DELETE SET NULL;
+
+
 --
 -- Name: document_access document_access_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: dev
 --
@@ -958,6 +1192,30 @@ ALTER TABLE ONLY public.document_attachments
     ADD CONSTRAINT document_attachments_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
 
 
+--
+-- Name: document_content_lines document_content_lines_document_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: dev
+--
+
+ALTER TABLE ONLY public.document_content_lines
+    ADD CONSTRAINT document_content_lines_document_id_fkey FOREIGN KEY (document_id) REFERENCES public.documents(id) ON DELETE CASCADE;
+
+
+--
+-- Name: document_embeddings document_embeddings_attachment_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: dev
+--
+
+ALTER TABLE ONLY public.document_embeddings
+    ADD CONSTRAINT document_embeddings_attachment_id_fkey FOREIGN KEY (attachment_id) REFERENCES public.document_attachments(id) ON DELETE CASCADE;
+
+
+--
+-- Name: document_embeddings document_embeddings_document_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: dev
+--
+
+ALTER TABLE ONLY public.document_embeddings
+    ADD CONSTRAINT document_embeddings_document_id_fkey FOREIGN KEY (document_id) REFERENCES public.documents(id) ON DELETE CASCADE;
+
+
 --
 -- Name: document_versions document_versions_created_by_fkey; Type: FK CONSTRAINT; Schema: public; Owner: dev
 --
@@ -1054,6 +1312,30 @@ ALTER TABLE ONLY public.shared_document_links
     ADD CONSTRAINT shared_document_links_inviter_id_fkey FOREIGN KEY (inviter_id) REFERENCES public.users(id);
 
 
+--
+-- Name: webhook_deliveries webhook_deliveries_webhook_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: dev
+--
+
+ALTER TABLE ONLY public.webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_webhook_id_fkey FOREIGN KEY (webhook_id) REFERENCES public.webhooks(id) ON DELETE CASCADE;
+
+
+--
+-- Name: webhooks webhooks_document_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: dev
+--
+
+ALTER TABLE ONLY public.webhooks
+    ADD CONSTRAINT webhooks_document_id_fkey FOREIGN KEY (document_id) REFERENCES public.documents(id) ON DELETE CASCADE;
+
+
+--
+-- Name: webhooks webhooks_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: dev
+--
+
+ALTER TABLE ONLY public.webhooks
+    ADD CONSTRAINT webhooks_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
 --
 -- PostgreSQL database dump complete
 --
@@ -43,6 +43,24 @@ var userClaimKey = ContextKey[*models.UserClaims]{}
 var UserClaimCtx = WithValueFunc(userClaimKey)
 var UserClaim = ValueFuncWithError(userClaimKey)
 
+// apiTokenClaimKey holds the claim of a request made with an api token until
+// a route registered with a scope makes it the user claim. It has its own
+// type so it can't collide with userClaimKey.
+type apiTokenClaimKeyType struct{}
+
+var apiTokenClaimKey = apiTokenClaimKeyType{}
+
+func APITokenClaimCtx(ctx context.Context, claims *models.UserClaims) context.Context {
+	return context.WithValue(ctx, apiTokenClaimKey, claims)
+}
+
+// APITokenClaim returns the claim of a request made with an api token, ok is
+// false for any other request
+func APITokenClaim(ctx context.Context) (claims *models.UserClaims, ok bool) {
+	claims, ok = ctx.Value(apiTokenClaimKey).(*models.UserClaims)
+	return claims, ok
+}
+
 var redisKey = ContextKey[*redis.Client]{}
 var RedisCtx = WithValueFunc(redisKey)
 var Redis = ValueFunc(redisKey)
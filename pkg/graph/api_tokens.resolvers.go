@@ -0,0 +1,141 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.35
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/graph/model"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/service/apitokens"
+)
+
+// Prefix is the resolver for the prefix field.
+func (r *aPITokenResolver) Prefix(ctx context.Context, obj *models.APIToken) (string, error) {
+	return obj.TokenPrefix, nil
+}
+
+// Scopes is the resolver for the scopes field.
+func (r *aPITokenResolver) Scopes(ctx context.Context, obj *models.APIToken) ([]model.APITokenScope, error) {
+	scopes := []model.APITokenScope{}
+	for _, scope := range apitokens.Scopes(obj) {
+		scopes = append(scopes, model.APITokenScope(strings.ToUpper(scope)))
+	}
+
+	return scopes, nil
+}
+
+// DocumentIds is the resolver for the documentIds field.
+func (r *aPITokenResolver) DocumentIds(ctx context.Context, obj *models.APIToken) ([]string, error) {
+	return apitokens.DocumentIDs(obj), nil
+}
+
+// ExpiresAt is the resolver for the expiresAt field.
+func (r *aPITokenResolver) ExpiresAt(ctx context.Context, obj *models.APIToken) (*time.Time, error) {
+	if obj.ExpiresAt.IsZero() {
+		return nil, nil
+	}
+
+	return &obj.ExpiresAt, nil
+}
+
+// LastUsedAt is the resolver for the lastUsedAt field.
+func (r *aPITokenResolver) LastUsedAt(ctx context.Context, obj *models.APIToken) (*time.Time, error) {
+	if obj.LastUsedAt.IsZero() {
+		return nil, nil
+	}
+
+	return &obj.LastUsedAt, nil
+}
+
+// CreateAPIToken is the resolver for the createApiToken field.
+func (r *mutationResolver) CreateAPIToken(ctx context.Context, input model.CreateAPITokenInput) (*model.CreatedAPIToken, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	userTbl := env.Query(ctx).User
+	user, err := userTbl.Where(userTbl.ID.Eq(currentUser.Id)).First()
+	if err != nil {
+		log.Error("error getting user", "userID", currentUser.Id, "error", err)
+		return nil, fmt.Errorf("sorry, we could not create your token")
+	}
+
+	scopes := []string{}
+	for _, scope := range input.Scopes {
+		scopes = append(scopes, strings.ToLower(string(scope)))
+	}
+
+	token, apiToken, err := apitokens.Create(ctx, user, apitokens.CreateInput{
+		Name:        input.Name,
+		Scopes:      scopes,
+		DocumentIDs: input.DocumentIds,
+		ExpiresAt:   input.ExpiresAt,
+	})
+	if err != nil {
+		log.Error("error creating api token", "userID", currentUser.Id, "error", err)
+		return nil, fmt.Errorf("sorry, we could not create your token: %w", err)
+	}
+
+	return &model.CreatedAPIToken{
+		Token:    token,
+		APIToken: apiToken,
+	}, nil
+}
+
+// RevokeAPIToken is the resolver for the revokeApiToken field.
+func (r *mutationResolver) RevokeAPIToken(ctx context.Context, id string) (bool, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return false, fmt.Errorf("please login")
+	}
+
+	err = apitokens.Revoke(ctx, currentUser.Id, id)
+	if errors.Is(err, apitokens.ErrNotFound) {
+		return false, fmt.Errorf("token not found")
+	}
+	if err != nil {
+		log.Error("error revoking api token", "userID", currentUser.Id, "tokenID", id, "error", err)
+		return false, fmt.Errorf("sorry, we could not revoke your token")
+	}
+
+	return true, nil
+}
+
+// APITokens is the resolver for the apiTokens field.
+func (r *queryResolver) APITokens(ctx context.Context) ([]*models.APIToken, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	tokens, err := apitokens.List(ctx, currentUser.Id)
+	if err != nil {
+		log.Error("error listing api tokens", "userID", currentUser.Id, "error", err)
+		return nil, fmt.Errorf("sorry, we could not get your tokens")
+	}
+
+	return tokens, nil
+}
+
+// APIToken returns APITokenResolver implementation.
+func (r *Resolver) APIToken() APITokenResolver { return &aPITokenResolver{r} }
+
+type aPITokenResolver struct{ *Resolver }
@@ -0,0 +1,105 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/graph/model"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+// blameModels looks up the users behind the blame spans' authors
+func blameModels(ctx context.Context, documentID string, spans []v3.BlameSpan) ([]*model.BlameSpan, error) {
+	authors := []string{}
+	for _, span := range spans {
+		authors = append(authors, span.Author, span.LastAuthor)
+	}
+
+	userFor, err := authorUsers(ctx, documentID, authors)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.BlameSpan, len(spans))
+	for i, span := range spans {
+		result[i] = &model.BlameSpan{
+			StartID:      span.StartID.String(),
+			EndID:        span.EndID.String(),
+			AuthorID:     span.Author,
+			User:         userFor(span.Author),
+			IsAi:         span.IsAI(),
+			LastAuthorID: span.LastAuthor,
+			LastUser:     userFor(span.LastAuthor),
+			FirstSeq:     span.FirstSeq,
+			LastSeq:      span.LastSeq,
+			Text:         span.Text,
+		}
+	}
+
+	return result, nil
+}
+
+// authorUsers looks up the users behind rogue authors, ai authors are the
+// user that asked for the edit. System authors like the document's initial
+// newline have no user.
+func authorUsers(ctx context.Context, documentID string, authors []string) (func(author string) *models.User, error) {
+	authorIDs := []int32{}
+	for _, author := range authors {
+		if id, ok := blameAuthorID(author); ok {
+			authorIDs = append(authorIDs, id)
+		}
+	}
+
+	authorTbl := env.Query(ctx).AuthorID
+	authorRows, err := authorTbl.
+		Where(authorTbl.DocumentID.Eq(documentID)).
+		Where(authorTbl.AuthorID.In(authorIDs...)).
+		Find()
+	if err != nil {
+		return nil, fmt.Errorf("error getting authors: %w", err)
+	}
+
+	userIDs := make([]string, len(authorRows))
+	for i, author := range authorRows {
+		userIDs[i] = author.UserID
+	}
+
+	userTbl := env.Query(ctx).User
+	users, err := userTbl.Where(userTbl.ID.In(userIDs...)).Find()
+	if err != nil {
+		return nil, fmt.Errorf("error getting users: %w", err)
+	}
+
+	userMap := map[string]*models.User{}
+	for _, user := range users {
+		userMap[user.ID] = user
+	}
+
+	byAuthorID := map[int32]*models.User{}
+	for _, author := range authorRows {
+		byAuthorID[author.AuthorID] = userMap[author.UserID]
+	}
+
+	return func(author string) *models.User {
+		id, ok := blameAuthorID(author)
+		if !ok {
+			return nil
+		}
+
+		return byAuthorID[id]
+	}, nil
+}
+
+// blameAuthorID parses the author_ids id out of a rogue author
+func blameAuthorID(author string) (int32, bool) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(author, "!"), 16, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	return int32(id), true
+}
@@ -0,0 +1,61 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.35
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/graph/model"
+	"github.com/fivetentaylor/pointy/pkg/query"
+	"github.com/fivetentaylor/pointy/pkg/rogue"
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+// DocumentBlame is the resolver for the documentBlame field.
+func (r *queryResolver) DocumentBlame(ctx context.Context, documentID string, address *string) ([]*model.BlameSpan, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	_, err = query.GetReadableDocumentForUser(env.Query(ctx), documentID, currentUser.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	ds := rogue.NewDocStore(env.S3(ctx), env.Query(ctx), env.Redis(ctx))
+	_, doc, err := ds.GetCurrentDoc(ctx, documentID)
+	if err != nil {
+		log.Error("error getting document", "documentID", documentID, "error", err)
+		return nil, fmt.Errorf("sorry, we could not get the document")
+	}
+
+	var ca *v3.ContentAddress
+	if address != nil {
+		ca, err = v3.ParseContentAddress(*address)
+		if err != nil || !doc.ValidAddress(*ca) {
+			return nil, fmt.Errorf("invalid address")
+		}
+	}
+
+	spans, err := doc.Blame(v3.RootID, v3.LastID, ca)
+	if err != nil {
+		log.Error("error getting blame", "documentID", documentID, "error", err)
+		return nil, fmt.Errorf("sorry, we could not get who wrote the document")
+	}
+
+	result, err := blameModels(ctx, documentID, spans)
+	if err != nil {
+		log.Error("error getting blame users", "documentID", documentID, "error", err)
+		return nil, fmt.Errorf("sorry, we could not get who wrote the document")
+	}
+
+	return result, nil
+}
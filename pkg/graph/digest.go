@@ -0,0 +1,28 @@
+package graph
+
+import (
+	"github.com/fivetentaylor/pointy/pkg/graph/model"
+	"github.com/fivetentaylor/pointy/pkg/models"
+)
+
+func digestFrequencyModel(freq models.DigestFrequency) model.EmailDigestFrequency {
+	switch freq {
+	case models.DigestFrequency_DigestHourly:
+		return model.EmailDigestFrequencyHourly
+	case models.DigestFrequency_DigestDaily:
+		return model.EmailDigestFrequencyDaily
+	}
+
+	return model.EmailDigestFrequencyImmediate
+}
+
+func digestFrequencyProto(freq model.EmailDigestFrequency) models.DigestFrequency {
+	switch freq {
+	case model.EmailDigestFrequencyHourly:
+		return models.DigestFrequency_DigestHourly
+	case model.EmailDigestFrequencyDaily:
+		return models.DigestFrequency_DigestDaily
+	}
+
+	return models.DigestFrequency_DigestImmediate
+}
@@ -6,9 +6,13 @@ package graph
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/charmbracelet/log"
 	"github.com/fivetentaylor/pointy/pkg/env"
 	"github.com/fivetentaylor/pointy/pkg/graph/loaders"
@@ -16,7 +20,9 @@ import (
 	"github.com/fivetentaylor/pointy/pkg/models"
 	"github.com/fivetentaylor/pointy/pkg/query"
 	"github.com/fivetentaylor/pointy/pkg/rogue"
+	"github.com/fivetentaylor/pointy/pkg/service/branches"
 	"github.com/fivetentaylor/pointy/pkg/service/document"
+	"github.com/fivetentaylor/pointy/pkg/service/embeddings"
 	"github.com/fivetentaylor/pointy/pkg/service/pubsub"
 	"github.com/fivetentaylor/pointy/pkg/service/timeline"
 	"github.com/fivetentaylor/pointy/pkg/stackerr"
@@ -515,6 +521,31 @@ func (r *mutationResolver) CopyDocument(ctx context.Context, id string, isBranch
 		copyDoc.Title = fmt.Sprintf("%s (branch)", srcDoc.Title)
 		copyDoc.ParentID = &srcDoc.ID
 		copyDoc.RootParentID = srcDoc.RootParentID
+
+		// branches are merged back relative to where they were forked
+		if address == nil {
+			_, srcRogue, err := ds.GetCurrentDoc(ctx, id)
+			if err != nil {
+				log.Errorf("error getting document: %v", err)
+				return nil, fmt.Errorf("sorry, we could not duplicate your document")
+			}
+
+			fullAddress, err := srcRogue.GetFullAddress()
+			if err != nil {
+				log.Errorf("error getting document address: %v", err)
+				return nil, fmt.Errorf("sorry, we could not duplicate your document")
+			}
+
+			addressBytes, err := json.Marshal(fullAddress)
+			if err != nil {
+				log.Errorf("error marshalling document address: %v", err)
+				return nil, fmt.Errorf("sorry, we could not duplicate your document")
+			}
+
+			forkAddress := string(addressBytes)
+			address = &forkAddress
+		}
+		copyDoc.ParentAddress = address
 	} else {
 		copyDoc.Title = fmt.Sprintf("%s (copy)", srcDoc.Title)
 	}
@@ -534,6 +565,73 @@ func (r *mutationResolver) CopyDocument(ctx context.Context, id string, isBranch
 	return dupDoc, nil
 }
 
+// MergeBranch is the resolver for the mergeBranch field.
+func (r *mutationResolver) MergeBranch(ctx context.Context, branchID string) (*model.BranchMergeResult, error) {
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Errorf("error getting current user: %s", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	result, err := branches.Merge(ctx, currentUser.Id, branchID)
+	if err != nil {
+		log.Errorf("error merging branch: %s", stackerr.Wrap(err))
+		switch {
+		case errors.Is(err, branches.ErrNotBranch):
+			return nil, fmt.Errorf("sorry, this document is not a branch")
+		case errors.Is(err, branches.ErrAlreadyMerged):
+			return nil, fmt.Errorf("sorry, this branch has already been merged")
+		}
+		return nil, fmt.Errorf("sorry, we could not merge your branch")
+	}
+
+	conflicts := make([]*model.BranchMergeConflict, 0, len(result.Conflicts))
+	for _, c := range result.Conflicts {
+		conflicts = append(conflicts, &model.BranchMergeConflict{
+			StartID:  c.StartID.String(),
+			EndID:    c.EndID.String(),
+			HTML:     c.Html,
+			DiffHTML: c.DiffHtml,
+		})
+	}
+
+	return &model.BranchMergeResult{
+		Document:  result.Parent,
+		Branch:    result.Branch,
+		Conflicts: conflicts,
+	}, nil
+}
+
+// ImportDocument is the resolver for the importDocument field.
+func (r *mutationResolver) ImportDocument(ctx context.Context, file graphql.Upload) (*models.Document, error) {
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Errorf("error getting current user: %s", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	if file.Size > document.MaxImportSize {
+		return nil, fmt.Errorf("sorry, that file is too large to import")
+	}
+
+	data, err := io.ReadAll(file.File)
+	if err != nil {
+		log.Errorf("error reading import: %s", err)
+		return nil, fmt.Errorf("sorry, we could not import your document")
+	}
+
+	doc, err := document.Import(ctx, currentUser.Id, file.Filename, data)
+	if err != nil {
+		log.Errorf("error importing document: %s", stackerr.Wrap(err))
+		if errors.As(err, &document.ErrUnsupportedImport{}) {
+			return nil, fmt.Errorf("sorry, only .docx and .odt files can be imported")
+		}
+		return nil, fmt.Errorf("sorry, we could not import your document")
+	}
+
+	return doc, nil
+}
+
 // MoveDocument is the resolver for the moveDocument field.
 func (r *mutationResolver) MoveDocument(ctx context.Context, id string, folderID *string) (*models.Document, error) {
 	log := env.Log(ctx)
@@ -806,6 +904,77 @@ func (r *queryResolver) SearchDocuments(ctx context.Context, query string, limit
 	return connection, nil
 }
 
+// SearchDocumentContents is the resolver for the searchDocumentContents field.
+func (r *queryResolver) SearchDocumentContents(ctx context.Context, query string, limit *int, offset *int) ([]*model.DocumentSearchResult, error) {
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Errorf("error getting current user: %s", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	if limit == nil {
+		limit = &DefaultDocumentLimit
+	}
+	if offset == nil {
+		offset = &DefaultOffset
+	}
+
+	results, err := document.SearchContent(ctx, query, currentUser.Id, *limit, *offset)
+	if err != nil {
+		log.Errorf("error searching document contents: %s", err)
+		return nil, fmt.Errorf("sorry, we could not search your documents")
+	}
+
+	out := make([]*model.DocumentSearchResult, len(results))
+	for i, result := range results {
+		out[i] = &model.DocumentSearchResult{
+			Document: result.Document,
+			Rank:     result.Rank,
+			Snippet:  result.Snippet,
+			StartID:  result.StartID,
+			EndID:    result.EndID,
+		}
+	}
+
+	return out, nil
+}
+
+// SemanticSearch is the resolver for the semanticSearch field.
+func (r *queryResolver) SemanticSearch(ctx context.Context, query string, limit *int, offset *int) ([]*model.SemanticSearchResult, error) {
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Errorf("error getting current user: %s", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	if limit == nil {
+		limit = &DefaultDocumentLimit
+	}
+	if offset == nil {
+		offset = &DefaultOffset
+	}
+
+	results, err := embeddings.Search(ctx, query, currentUser.Id, *limit, *offset)
+	if err != nil {
+		log.Errorf("error running semantic search: %s", err)
+		return nil, fmt.Errorf("sorry, we could not search your documents")
+	}
+
+	out := make([]*model.SemanticSearchResult, len(results))
+	for i, result := range results {
+		out[i] = &model.SemanticSearchResult{
+			DocumentID:   result.DocumentID,
+			AttachmentID: result.AttachmentID,
+			StartID:      result.StartID,
+			EndID:        result.EndID,
+			Content:      result.Content,
+			Score:        result.Score,
+		}
+	}
+
+	return out, nil
+}
+
 // Document is the resolver for the document field.
 func (r *queryResolver) Document(ctx context.Context, id string) (*models.Document, error) {
 	log := env.Log(ctx)
@@ -34,6 +34,7 @@ func NewHandler() http.Handler {
 	})
 	userSrv.SetQueryCache(lru.New(1000))
 	userSrv.Use(extension.Introspection{})
+	userSrv.AroundRootFields(APITokenScopes)
 
 	wDataLoaders := loaders.Middleware(userSrv)
 
@@ -0,0 +1,71 @@
+package loaders
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/fivetentaylor/pointy/pkg/service/rogue"
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+type CommentAnchorInput struct {
+	DocumentID     string
+	StartID        string
+	EndID          string
+	ContentAddress string
+}
+
+// getCommentAnchors implements a batch function that resolves many comment
+// anchors, loading each document only once, for use in a dataloader
+func getCommentAnchors(ctx context.Context, inputs []CommentAnchorInput) ([]*v3.Anchor, []error) {
+	out := make([]*v3.Anchor, len(inputs))
+	errs := make([]error, len(inputs))
+
+	docs := map[string]*v3.Rogue{}
+	for i, input := range inputs {
+		doc, ok := docs[input.DocumentID]
+		if !ok {
+			var err error
+			doc, err = rogue.CurrentDocument(ctx, input.DocumentID)
+			if err != nil {
+				return nil, []error{fmt.Errorf("error loading document %s: %w", input.DocumentID, err)}
+			}
+			docs[input.DocumentID] = doc
+		}
+
+		out[i], errs[i] = resolveCommentAnchor(doc, input)
+	}
+
+	return out, errs
+}
+
+func resolveCommentAnchor(doc *v3.Rogue, input CommentAnchorInput) (*v3.Anchor, error) {
+	startID, err := v3.ParseID(input.StartID)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing start id %q: %w", input.StartID, err)
+	}
+
+	endID, err := v3.ParseID(input.EndID)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing end id %q: %w", input.EndID, err)
+	}
+
+	var address *v3.ContentAddress
+	if input.ContentAddress != "" {
+		address = &v3.ContentAddress{}
+		err = json.Unmarshal([]byte(input.ContentAddress), address)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing content address: %w", err)
+		}
+	}
+
+	return doc.ResolveAnchor(startID, endID, address)
+}
+
+// GetCommentAnchor returns where the text a comment was attached to is in
+// the current document
+func GetCommentAnchor(ctx context.Context, input CommentAnchorInput) (*v3.Anchor, error) {
+	loaders := For(ctx)
+	return loaders.CommentAnchorLoader.Load(ctx, input)
+}
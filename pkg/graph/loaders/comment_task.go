@@ -0,0 +1,55 @@
+package loaders
+
+import (
+	"context"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+)
+
+type CommentTaskInput struct {
+	DocumentID string
+	EventID    string
+}
+
+// getCommentTasks implements a batch function that retrieves the tasks on
+// many comments, for use in a dataloader. Comments without a task are nil.
+func getCommentTasks(ctx context.Context, inputs []CommentTaskInput) ([]*models.CommentTask, []error) {
+	log := env.Log(ctx)
+	tbl := env.Query(ctx).CommentTask
+
+	docIDs := []string{}
+	eventIDs := []string{}
+	for _, input := range inputs {
+		docIDs = append(docIDs, input.DocumentID)
+		eventIDs = append(eventIDs, input.EventID)
+	}
+
+	found, err := tbl.WithContext(ctx).
+		Where(tbl.DocumentID.In(docIDs...), tbl.EventID.In(eventIDs...)).
+		Find()
+	if err != nil {
+		log.Errorf("error getting comment tasks: %s", err)
+		return nil, []error{err}
+	}
+
+	taskMap := make(map[CommentTaskInput]*models.CommentTask)
+	for _, task := range found {
+		taskMap[CommentTaskInput{DocumentID: task.DocumentID, EventID: task.EventID}] = task
+	}
+
+	// Ensure the result slice is in the same order as the input keys
+	out := make([]*models.CommentTask, len(inputs))
+	for i, input := range inputs {
+		out[i] = taskMap[input]
+	}
+
+	return out, nil
+}
+
+// GetCommentTask returns the task on a comment efficiently, nil if there
+// isn't one
+func GetCommentTask(ctx context.Context, documentID, eventID string) (*models.CommentTask, error) {
+	loaders := For(ctx)
+	return loaders.CommentTaskLoader.Load(ctx, CommentTaskInput{DocumentID: documentID, EventID: eventID})
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
 	"github.com/vikstrous/dataloadgen"
 )
 
@@ -32,6 +34,9 @@ type Loaders struct {
 	UserLoader           *dataloadgen.Loader[string, *models.User]
 	DocumentOwnerLoader  *dataloadgen.Loader[string, *models.User]
 	DocumentAccessLoader *dataloadgen.Loader[DocumentAccessInput, string]
+	CommentAnchorLoader  *dataloadgen.Loader[CommentAnchorInput, *v3.Anchor]
+	CommentTaskLoader    *dataloadgen.Loader[CommentTaskInput, *models.CommentTask]
+	ThreadRepliesLoader  *dataloadgen.Loader[ThreadRepliesInput, []*dynamo.TimelineEvent]
 }
 
 // NewLoaders instantiates data loaders for the middleware
@@ -43,5 +48,8 @@ func NewLoaders() *Loaders {
 		UserLoader:           dataloadgen.NewLoader(ur.getUsers, dataloadgen.WithWait(time.Millisecond)),
 		DocumentOwnerLoader:  dataloadgen.NewLoader(dor.getDocumentOwners, dataloadgen.WithWait(time.Millisecond)),
 		DocumentAccessLoader: dataloadgen.NewLoader(getDocumentAccesss, dataloadgen.WithWait(time.Millisecond)),
+		CommentAnchorLoader:  dataloadgen.NewLoader(getCommentAnchors, dataloadgen.WithWait(time.Millisecond)),
+		CommentTaskLoader:    dataloadgen.NewLoader(getCommentTasks, dataloadgen.WithWait(time.Millisecond)),
+		ThreadRepliesLoader:  dataloadgen.NewLoader(getThreadReplies, dataloadgen.WithWait(time.Millisecond)),
 	}
 }
@@ -0,0 +1,55 @@
+package loaders
+
+import (
+	"context"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
+)
+
+type ThreadRepliesInput struct {
+	DocumentID string
+	EventID    string
+}
+
+// getThreadReplies implements a batch function that retrieves the replies of
+// many comment threads, fetching the replies of each document in one query,
+// for use in a dataloader
+func getThreadReplies(ctx context.Context, inputs []ThreadRepliesInput) ([][]*dynamo.TimelineEvent, []error) {
+	log := env.Log(ctx)
+	dydb := env.Dynamo(ctx)
+
+	repliesByDoc := map[string]map[string][]*dynamo.TimelineEvent{}
+	for _, input := range inputs {
+		if _, ok := repliesByDoc[input.DocumentID]; ok {
+			continue
+		}
+
+		replies, err := dydb.GetAllDocumentTimelineReplies(input.DocumentID)
+		if err != nil {
+			log.Errorf("error getting replies for document %s: %s", input.DocumentID, err)
+			return nil, []error{err}
+		}
+
+		byThread := map[string][]*dynamo.TimelineEvent{}
+		for _, reply := range replies {
+			byThread[reply.ReplyToID] = append(byThread[reply.ReplyToID], reply)
+		}
+		repliesByDoc[input.DocumentID] = byThread
+	}
+
+	// Ensure the result slice is in the same order as the input keys
+	out := make([][]*dynamo.TimelineEvent, len(inputs))
+	for i, input := range inputs {
+		out[i] = repliesByDoc[input.DocumentID][input.EventID]
+	}
+
+	return out, nil
+}
+
+// GetThreadReplies returns the replies to a comment efficiently, in the order
+// they were created
+func GetThreadReplies(ctx context.Context, documentID, eventID string) ([]*dynamo.TimelineEvent, error) {
+	loaders := For(ctx)
+	return loaders.ThreadRepliesLoader.Load(ctx, ThreadRepliesInput{DocumentID: documentID, EventID: eventID})
+}
@@ -264,6 +264,11 @@ func (r *messageResolver) ReplyingUsers(ctx context.Context, obj *dynamo.Message
 	return loaders.GetUsers(ctx, obj.ReplyingUserIds)
 }
 
+// Reactions is the resolver for the reactions field.
+func (r *messageResolver) Reactions(ctx context.Context, obj *dynamo.Message) ([]*model.ReactionGroup, error) {
+	return reactionGroups(ctx, obj.MessageID)
+}
+
 // CreateAskAiThread is the resolver for the createAskAiThread field.
 func (r *mutationResolver) CreateAskAiThread(ctx context.Context, documentID string) (*dynamo.Thread, error) {
 	log := env.SLog(ctx)
@@ -438,15 +443,7 @@ func (r *notificationResolver) Payload(ctx context.Context, obj *dynamo.Notifica
 	switch val := obj.Payload.Value.(type) {
 	case *models.NotificationPayload_Comment:
 		comment := model.CommentNotificationPayloadValue{}
-		switch val.Comment.Type {
-		case models.CommentType_Comment:
-			comment.CommentType = model.CommentNotificationTypeComment
-		case models.CommentType_Reply:
-			comment.CommentType = model.CommentNotificationTypeReply
-		case models.CommentType_Mention:
-			comment.CommentType = model.CommentNotificationTypeMention
-		}
-
+		comment.CommentType = commentNotificationType(val.Comment.Type)
 		comment.DocumentID = val.Comment.DocumentId
 		comment.ChannelID = val.Comment.ChannelId
 		comment.ContainerID = val.Comment.ContainerId
@@ -454,6 +451,34 @@ func (r *notificationResolver) Payload(ctx context.Context, obj *dynamo.Notifica
 		comment.AuthorID = val.Comment.AuthorId
 
 		return &comment, nil
+	case *models.NotificationPayload_TimelineComment:
+		comment := model.TimelineCommentNotificationPayloadValue{
+			CommentType: commentNotificationType(val.TimelineComment.Type),
+			DocumentID:  val.TimelineComment.DocumentId,
+			EventID:     val.TimelineComment.EventId,
+			AuthorID:    val.TimelineComment.AuthorId,
+		}
+		if val.TimelineComment.ReplyToId != "" {
+			comment.ReplyToID = &val.TimelineComment.ReplyToId
+		}
+
+		return &comment, nil
+	case *models.NotificationPayload_Share:
+		share := model.ShareNotificationPayloadValue{
+			DocumentID: val.Share.DocumentId,
+			FromUserID: val.Share.FromUserId,
+		}
+		if val.Share.EventId != "" {
+			share.EventID = &val.Share.EventId
+		}
+
+		return &share, nil
+	case *models.NotificationPayload_Revision:
+		return &model.RevisionNotificationPayloadValue{
+			DocumentID: val.Revision.DocumentId,
+			ThreadID:   val.Revision.ThreadId,
+			MessageID:  val.Revision.MessageId,
+		}, nil
 	}
 
 	return nil, errors.New("unknown notification payload type")
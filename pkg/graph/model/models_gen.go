@@ -30,6 +30,15 @@ type AiContent struct {
 	Feedback          *string `json:"feedback,omitempty"`
 }
 
+type AssignCommentTaskInput struct {
+	DocumentID string `json:"documentId"`
+	EventID    string `json:"eventId"`
+	// someone the document is already shared with, assigning a task doesn't share the document
+	AssigneeID string `json:"assigneeId"`
+	// only the date is kept, in UTC
+	DueDate time.Time `json:"dueDate"`
+}
+
 type AttachedRevisoDocument struct {
 	ID    string `json:"id"`
 	Title string `json:"title"`
@@ -71,6 +80,39 @@ type BillingPortalSession struct {
 	URL string `json:"url"`
 }
 
+type BlameSpan struct {
+	StartID string `json:"startId"`
+	EndID   string `json:"endId"`
+	// the rogue author of the text, ai authors start with !
+	AuthorID string `json:"authorId"`
+	// the user that wrote the text, or asked the ai to
+	User *models.User `json:"user,omitempty"`
+	IsAi bool         `json:"isAi"`
+	// the author that last brought the text back after it was deleted, e.g. with undo or by restoring a version
+	LastAuthorID string       `json:"lastAuthorId"`
+	LastUser     *models.User `json:"lastUser,omitempty"`
+	FirstSeq     int          `json:"firstSeq"`
+	LastSeq      int          `json:"lastSeq"`
+	Text         string       `json:"text"`
+}
+
+type BranchMergeConflict struct {
+	StartID string `json:"startId"`
+	EndID   string `json:"endId"`
+	// the html of the blocks after the merge
+	HTML string `json:"html"`
+	// just the merge's changes to the blocks
+	DiffHTML string `json:"diffHtml"`
+}
+
+type BranchMergeResult struct {
+	// the parent the branch was merged into
+	Document *models.Document `json:"document"`
+	Branch   *models.Document `json:"branch"`
+	// parts of the parent that were also edited after the branch was forked and should be reviewed
+	Conflicts []*BranchMergeConflict `json:"conflicts"`
+}
+
 type Chain struct {
 	ID       string            `json:"id"`
 	Messages []*dynamo.Message `json:"messages"`
@@ -99,6 +141,28 @@ type ContentAddress struct {
 	Payload    string `json:"payload"`
 }
 
+type CreateAPITokenInput struct {
+	Name   string          `json:"name"`
+	Scopes []APITokenScope `json:"scopes"`
+	// limits the token to these documents, all of the user's documents if empty
+	DocumentIds []string   `json:"documentIds,omitempty"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+}
+
+type CreateWebhookInput struct {
+	URL string `json:"url"`
+	// limits the webhook to one document, all of the user's documents if empty
+	DocumentID *string `json:"documentId,omitempty"`
+	// limits the events sent, every event if empty
+	Events []WebhookEvent `json:"events,omitempty"`
+}
+
+type CreatedAPIToken struct {
+	// the token itself, it's only ever returned here
+	Token    string           `json:"token"`
+	APIToken *models.APIToken `json:"apiToken"`
+}
+
 type DocumentConnection struct {
 	TotalCount int             `json:"totalCount"`
 	Edges      []*DocumentEdge `json:"edges"`
@@ -127,6 +191,17 @@ type DocumentScreenshots struct {
 	DarkURL  string `json:"darkUrl"`
 }
 
+type DocumentSearchResult struct {
+	Document *models.Document `json:"document"`
+	Rank     float64          `json:"rank"`
+	// html escaped text of the best matching line, matches are wrapped in <mark>
+	Snippet string `json:"snippet"`
+	// id of the first character of the matching line
+	StartID string `json:"startID"`
+	// id of the newline that ends the matching line
+	EndID string `json:"endID"`
+}
+
 type EditTimelineMessageInput struct {
 	Content           string  `json:"content"`
 	ContentAddress    *string `json:"contentAddress,omitempty"`
@@ -181,11 +256,40 @@ type MutationResponse struct {
 }
 
 type NotificationConnection struct {
-	Edges []*dynamo.Notification `json:"edges"`
+	Edges    []*dynamo.Notification `json:"edges"`
+	PageInfo *PageInfo              `json:"pageInfo"`
 }
 
 type PageInfo struct {
 	HasNextPage bool `json:"hasNextPage"`
+	// pass as after to get the next page, only set for cursor paginated connections
+	EndCursor *string `json:"endCursor,omitempty"`
+}
+
+type Presence struct {
+	User  *models.User  `json:"user"`
+	State PresenceState `json:"state"`
+	// the start of the block the user's selection is in
+	BlockID      *string   `json:"blockId,omitempty"`
+	LastActiveAt time.Time `json:"lastActiveAt"`
+}
+
+// everyone who reacted with the same emoji
+type ReactionGroup struct {
+	Emoji       string         `json:"emoji"`
+	Count       int            `json:"count"`
+	ReactedByMe bool           `json:"reactedByMe"`
+	UserIds     []string       `json:"userIds"`
+	Users       []*models.User `json:"users"`
+}
+
+type ReactionInput struct {
+	DocumentID string `json:"documentId"`
+	// the timeline comment's event id or the ask ai message's id
+	MessageID string `json:"messageId"`
+	// the ask ai thread the message is in
+	ThreadID *string `json:"threadId,omitempty"`
+	Emoji    string  `json:"emoji"`
 }
 
 type Revision struct {
@@ -202,6 +306,25 @@ type Revision struct {
 
 func (Revision) IsAttachmentValue() {}
 
+type RevisionNotificationPayloadValue struct {
+	DocumentID string `json:"documentId"`
+	ThreadID   string `json:"threadId"`
+	MessageID  string `json:"messageId"`
+}
+
+func (RevisionNotificationPayloadValue) IsNotificationPayloadValue() {}
+
+type SaveDocumentTemplateInput struct {
+	DocumentID string `json:"documentId"`
+	// saves the document as it was at this content address, as it is now if empty
+	Address *string `json:"address,omitempty"`
+	// defaults to the document's title, it can have placeholders too
+	Title       *string `json:"title,omitempty"`
+	Description *string `json:"description,omitempty"`
+	// lets everyone in the user's domain use the template, it needs edit access to the document
+	Shared *bool `json:"shared,omitempty"`
+}
+
 type Selection struct {
 	ID      string `json:"id"`
 	Start   string `json:"start"`
@@ -217,6 +340,27 @@ type SelectionInput struct {
 	Content string `json:"content"`
 }
 
+type SemanticSearchResult struct {
+	DocumentID string `json:"documentID"`
+	// set when the chunk is from one of the document's attachments
+	AttachmentID *string `json:"attachmentID,omitempty"`
+	// ids of the first and last characters of the chunk, not set for attachments
+	StartID *string `json:"startID,omitempty"`
+	EndID   *string `json:"endID,omitempty"`
+	Content string  `json:"content"`
+	Score   float64 `json:"score"`
+}
+
+type ShareNotificationPayloadValue struct {
+	DocumentID string `json:"documentId"`
+	FromUserID string `json:"fromUserId"`
+	// the comment the user was mentioned in, if that's how it was shared
+	EventID  *string      `json:"eventId,omitempty"`
+	FromUser *models.User `json:"fromUser"`
+}
+
+func (ShareNotificationPayloadValue) IsNotificationPayloadValue() {}
+
 type SignedImageURL struct {
 	URL       string    `json:"url"`
 	ExpiresAt time.Time `json:"expiresAt"`
@@ -243,6 +387,23 @@ type TLAttributeChangeV1 struct {
 
 func (TLAttributeChangeV1) IsTLEventPayload() {}
 
+type TLBranchMergeV1 struct {
+	BranchID             string `json:"branchId"`
+	ParentID             string `json:"parentId"`
+	ContentAddressBefore string `json:"contentAddressBefore"`
+	ContentAddressAfter  string `json:"contentAddressAfter"`
+	ConcurrentSpans      int    `json:"concurrentSpans"`
+}
+
+func (TLBranchMergeV1) IsTLEventPayload() {}
+
+type TLCommentAnchor struct {
+	StartID  string `json:"startId"`
+	EndID    string `json:"endId"`
+	Orphaned bool   `json:"orphaned"`
+	Changed  bool   `json:"changed"`
+}
+
 type TLEmpty struct {
 	Placeholder string `json:"placeholder"`
 }
@@ -278,10 +439,21 @@ type TLMessageV1 struct {
 	DocumentID        string                  `json:"documentId"`
 	EventID           string                  `json:"eventId"`
 	Replies           []*dynamo.TimelineEvent `json:"replies"`
+	Reactions         []*ReactionGroup        `json:"reactions"`
+	Task              *models.CommentTask     `json:"task,omitempty"`
 }
 
 func (TLMessageV1) IsTLEventPayload() {}
 
+type TLOfflineEditsV1 struct {
+	ContentAddressBase   string `json:"contentAddressBase"`
+	ContentAddressBefore string `json:"contentAddressBefore"`
+	ContentAddressAfter  string `json:"contentAddressAfter"`
+	ConcurrentSpans      int    `json:"concurrentSpans"`
+}
+
+func (TLOfflineEditsV1) IsTLEventPayload() {}
+
 type TLPasteV1 struct {
 	ContentAddressBefore string `json:"contentAddressBefore"`
 	ContentAddressAfter  string `json:"contentAddressAfter"`
@@ -289,6 +461,19 @@ type TLPasteV1 struct {
 
 func (TLPasteV1) IsTLEventPayload() {}
 
+type TLRestoreV1 struct {
+	// a content address as json
+	ContentAddressTarget string `json:"contentAddressTarget"`
+	ContentAddressBefore string `json:"contentAddressBefore"`
+	ContentAddressAfter  string `json:"contentAddressAfter"`
+	StartID              string `json:"startId"`
+	EndID                string `json:"endId"`
+	// set when this restore undid another one
+	UndoOfEventID *string `json:"undoOfEventId,omitempty"`
+}
+
+func (TLRestoreV1) IsTLEventPayload() {}
+
 type TLUpdateV1 struct {
 	EventID                 string        `json:"eventId"`
 	Title                   string        `json:"title"`
@@ -304,6 +489,24 @@ type TLUpdateV1 struct {
 
 func (TLUpdateV1) IsTLEventPayload() {}
 
+type TemplateValueInput struct {
+	// the placeholder's name, client_name for {{client_name}}
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type TimelineCommentNotificationPayloadValue struct {
+	CommentType CommentNotificationType `json:"commentType"`
+	DocumentID  string                  `json:"documentId"`
+	EventID     string                  `json:"eventId"`
+	// the comment this replies to
+	ReplyToID *string      `json:"replyToId,omitempty"`
+	AuthorID  string       `json:"authorId"`
+	Author    *models.User `json:"author"`
+}
+
+func (TimelineCommentNotificationPayloadValue) IsNotificationPayloadValue() {}
+
 type TimelineMessageInput struct {
 	ReplyTo           *string `json:"replyTo,omitempty"`
 	AuthorID          string  `json:"authorId"`
@@ -332,7 +535,84 @@ type UpdateUserInput struct {
 }
 
 type UpdateUserPreferenceInput struct {
-	EnableActivityNotifications *bool `json:"enableActivityNotifications,omitempty"`
+	EnableActivityNotifications *bool                 `json:"enableActivityNotifications,omitempty"`
+	DigestFrequency             *EmailDigestFrequency `json:"digestFrequency,omitempty"`
+}
+
+type VersionChange struct {
+	Kind VersionChangeKind `json:"kind"`
+	// deleted text has the ids it had in the from version
+	StartID string `json:"startId"`
+	EndID   string `json:"endId"`
+	// the rogue authors that made the change, ai authors start with !
+	AuthorIds []string       `json:"authorIds"`
+	Users     []*models.User `json:"users"`
+	Text      string         `json:"text"`
+	Words     int            `json:"words"`
+}
+
+type VersionComparison struct {
+	Changes       []*VersionChange `json:"changes"`
+	WordsInserted int              `json:"wordsInserted"`
+	WordsDeleted  int              `json:"wordsDeleted"`
+	// the document with insertions and deletions marked up
+	HTML string `json:"html"`
+	// a unified diff of the document as markdown
+	Markdown string `json:"markdown"`
+}
+
+// a version of a document, set one field or none for the current version
+type VersionRef struct {
+	FlaggedVersionID *string `json:"flaggedVersionId,omitempty"`
+	// a timeline event with edits, e.g. an update, the version is after its edits
+	TimelineEventID *string `json:"timelineEventId,omitempty"`
+	// a content address as json
+	Address *string `json:"address,omitempty"`
+}
+
+type APITokenScope string
+
+const (
+	APITokenScopeReadDocs  APITokenScope = "READ_DOCS"
+	APITokenScopeWriteDocs APITokenScope = "WRITE_DOCS"
+	APITokenScopeComment   APITokenScope = "COMMENT"
+	APITokenScopeAdmin     APITokenScope = "ADMIN"
+)
+
+var AllAPITokenScope = []APITokenScope{
+	APITokenScopeReadDocs,
+	APITokenScopeWriteDocs,
+	APITokenScopeComment,
+	APITokenScopeAdmin,
+}
+
+func (e APITokenScope) IsValid() bool {
+	switch e {
+	case APITokenScopeReadDocs, APITokenScopeWriteDocs, APITokenScopeComment, APITokenScopeAdmin:
+		return true
+	}
+	return false
+}
+
+func (e APITokenScope) String() string {
+	return string(e)
+}
+
+func (e *APITokenScope) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = APITokenScope(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid APITokenScope", str)
+	}
+	return nil
+}
+
+func (e APITokenScope) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
 type AttachmentInputType string
@@ -511,6 +791,49 @@ func (e CommentNotificationType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type EmailDigestFrequency string
+
+const (
+	EmailDigestFrequencyImmediate EmailDigestFrequency = "IMMEDIATE"
+	EmailDigestFrequencyHourly    EmailDigestFrequency = "HOURLY"
+	EmailDigestFrequencyDaily     EmailDigestFrequency = "DAILY"
+)
+
+var AllEmailDigestFrequency = []EmailDigestFrequency{
+	EmailDigestFrequencyImmediate,
+	EmailDigestFrequencyHourly,
+	EmailDigestFrequencyDaily,
+}
+
+func (e EmailDigestFrequency) IsValid() bool {
+	switch e {
+	case EmailDigestFrequencyImmediate, EmailDigestFrequencyHourly, EmailDigestFrequencyDaily:
+		return true
+	}
+	return false
+}
+
+func (e EmailDigestFrequency) String() string {
+	return string(e)
+}
+
+func (e *EmailDigestFrequency) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = EmailDigestFrequency(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid EmailDigestFrequency", str)
+	}
+	return nil
+}
+
+func (e EmailDigestFrequency) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type LifecycleStage string
 
 const (
@@ -642,6 +965,49 @@ func (e MsgLlm) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type PresenceState string
+
+const (
+	PresenceStateEditing PresenceState = "EDITING"
+	PresenceStateViewing PresenceState = "VIEWING"
+	PresenceStateIdle    PresenceState = "IDLE"
+)
+
+var AllPresenceState = []PresenceState{
+	PresenceStateEditing,
+	PresenceStateViewing,
+	PresenceStateIdle,
+}
+
+func (e PresenceState) IsValid() bool {
+	switch e {
+	case PresenceStateEditing, PresenceStateViewing, PresenceStateIdle:
+		return true
+	}
+	return false
+}
+
+func (e PresenceState) String() string {
+	return string(e)
+}
+
+func (e *PresenceState) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PresenceState(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PresenceState", str)
+	}
+	return nil
+}
+
+func (e PresenceState) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type Status string
 
 const (
@@ -768,3 +1134,89 @@ func (e *TimelineEventFilter) UnmarshalGQL(v interface{}) error {
 func (e TimelineEventFilter) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+type VersionChangeKind string
+
+const (
+	VersionChangeKindInsert VersionChangeKind = "INSERT"
+	VersionChangeKindDelete VersionChangeKind = "DELETE"
+	VersionChangeKindFormat VersionChangeKind = "FORMAT"
+)
+
+var AllVersionChangeKind = []VersionChangeKind{
+	VersionChangeKindInsert,
+	VersionChangeKindDelete,
+	VersionChangeKindFormat,
+}
+
+func (e VersionChangeKind) IsValid() bool {
+	switch e {
+	case VersionChangeKindInsert, VersionChangeKindDelete, VersionChangeKindFormat:
+		return true
+	}
+	return false
+}
+
+func (e VersionChangeKind) String() string {
+	return string(e)
+}
+
+func (e *VersionChangeKind) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = VersionChangeKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid VersionChangeKind", str)
+	}
+	return nil
+}
+
+func (e VersionChangeKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type WebhookEvent string
+
+const (
+	WebhookEventDocumentUpdated WebhookEvent = "DOCUMENT_UPDATED"
+	WebhookEventCommentCreated  WebhookEvent = "COMMENT_CREATED"
+	WebhookEventThreadResolved  WebhookEvent = "THREAD_RESOLVED"
+)
+
+var AllWebhookEvent = []WebhookEvent{
+	WebhookEventDocumentUpdated,
+	WebhookEventCommentCreated,
+	WebhookEventThreadResolved,
+}
+
+func (e WebhookEvent) IsValid() bool {
+	switch e {
+	case WebhookEventDocumentUpdated, WebhookEventCommentCreated, WebhookEventThreadResolved:
+		return true
+	}
+	return false
+}
+
+func (e WebhookEvent) String() string {
+	return string(e)
+}
+
+func (e *WebhookEvent) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookEvent(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookEvent", str)
+	}
+	return nil
+}
+
+func (e WebhookEvent) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -0,0 +1,24 @@
+package graph
+
+import (
+	"github.com/fivetentaylor/pointy/pkg/graph/model"
+	"github.com/fivetentaylor/pointy/pkg/models"
+)
+
+const (
+	defaultNotificationPageSize = 20
+	maxNotificationPageSize     = 100
+)
+
+func commentNotificationType(t models.CommentType) model.CommentNotificationType {
+	switch t {
+	case models.CommentType_Comment:
+		return model.CommentNotificationTypeComment
+	case models.CommentType_Reply:
+		return model.CommentNotificationTypeReply
+	case models.CommentType_Mention:
+		return model.CommentNotificationTypeMention
+	}
+
+	return model.CommentNotificationTypeUnknown
+}
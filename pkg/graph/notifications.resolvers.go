@@ -0,0 +1,248 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.35
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/graph/loaders"
+	"github.com/fivetentaylor/pointy/pkg/graph/model"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/query"
+	"github.com/fivetentaylor/pointy/pkg/service/notifications"
+	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
+	"github.com/fivetentaylor/pointy/pkg/utils"
+)
+
+// MarkNotificationRead is the resolver for the markNotificationRead field.
+func (r *mutationResolver) MarkNotificationRead(ctx context.Context, id string, read *bool) (*dynamo.Notification, error) {
+	log := env.SLog(ctx)
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	markRead := true
+	if read != nil {
+		markRead = *read
+	}
+
+	n, err := notifications.MarkRead(ctx, currentUser.Id, id, markRead)
+	if err != nil {
+		if errors.Is(err, dynamo.ErrNotificationNotFound) {
+			return nil, fmt.Errorf("notification not found")
+		}
+		log.Error("error marking notification", "id", id, "error", err)
+		return nil, fmt.Errorf("sorry, we could not update the notification")
+	}
+
+	return n, nil
+}
+
+// MarkAllNotificationsRead is the resolver for the markAllNotificationsRead field.
+func (r *mutationResolver) MarkAllNotificationsRead(ctx context.Context, documentID *string) (bool, error) {
+	log := env.SLog(ctx)
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return false, fmt.Errorf("please login")
+	}
+
+	err = notifications.MarkAllRead(ctx, currentUser.Id, documentID)
+	if err != nil {
+		log.Error("error marking all notifications read", "error", err)
+		return false, fmt.Errorf("sorry, we could not update your notifications")
+	}
+
+	return true, nil
+}
+
+// MuteNotifications is the resolver for the muteNotifications field.
+func (r *mutationResolver) MuteNotifications(ctx context.Context, documentID string, threadID *string) (*dynamo.NotificationMute, error) {
+	log := env.SLog(ctx)
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	_, err = query.AccessLevelForDocument(env.Query(ctx), documentID, currentUser.Id)
+	if err != nil {
+		log.Error("error getting access level", "documentID", documentID, "error", err)
+		return nil, fmt.Errorf("document not found")
+	}
+
+	m := &dynamo.NotificationMute{
+		UserID: currentUser.Id,
+		DocID:  documentID,
+	}
+	if threadID != nil {
+		m.ThreadID = *threadID
+	}
+
+	err = env.Dynamo(ctx).MuteNotifications(m)
+	if err != nil {
+		log.Error("error muting notifications", "documentID", documentID, "error", err)
+		return nil, fmt.Errorf("sorry, we could not mute notifications")
+	}
+
+	return m, nil
+}
+
+// UnmuteNotifications is the resolver for the unmuteNotifications field.
+func (r *mutationResolver) UnmuteNotifications(ctx context.Context, documentID string, threadID *string) (bool, error) {
+	log := env.SLog(ctx)
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return false, fmt.Errorf("please login")
+	}
+
+	thread := ""
+	if threadID != nil {
+		thread = *threadID
+	}
+
+	err = env.Dynamo(ctx).UnmuteNotifications(currentUser.Id, documentID, thread)
+	if err != nil {
+		log.Error("error unmuting notifications", "documentID", documentID, "error", err)
+		return false, fmt.Errorf("sorry, we could not unmute notifications")
+	}
+
+	return true, nil
+}
+
+// DocumentID is the resolver for the documentId field.
+func (r *notificationMuteResolver) DocumentID(ctx context.Context, obj *dynamo.NotificationMute) (string, error) {
+	return obj.DocID, nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *notificationMuteResolver) CreatedAt(ctx context.Context, obj *dynamo.NotificationMute) (*time.Time, error) {
+	return utils.UnixNanoToTime(obj.CreatedAt), nil
+}
+
+// Notifications is the resolver for the notifications field.
+func (r *queryResolver) Notifications(ctx context.Context, read *bool, documentID *string, first *int, after *string) (*model.NotificationConnection, error) {
+	log := env.SLog(ctx)
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	limit := defaultNotificationPageSize
+	if first != nil {
+		limit = min(max(*first, 1), maxNotificationPageSize)
+	}
+
+	cursor := ""
+	if after != nil {
+		cursor = *after
+	}
+
+	page, next, err := notifications.List(ctx, currentUser.Id, documentID, read != nil && *read, int64(limit), cursor)
+	if err != nil {
+		log.Error("error listing notifications", "error", err)
+		return nil, fmt.Errorf("sorry, we could not get your notifications")
+	}
+
+	pageInfo := &model.PageInfo{HasNextPage: next != ""}
+	if next != "" {
+		pageInfo.EndCursor = &next
+	}
+
+	return &model.NotificationConnection{
+		Edges:    page,
+		PageInfo: pageInfo,
+	}, nil
+}
+
+// UnreadNotificationCount is the resolver for the unreadNotificationCount field.
+func (r *queryResolver) UnreadNotificationCount(ctx context.Context, documentID *string) (int, error) {
+	log := env.SLog(ctx)
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return 0, fmt.Errorf("please login")
+	}
+
+	var count int64
+	if documentID != nil {
+		count, err = env.Dynamo(ctx).GetNotificationCountForUserAndDocument(currentUser.Id, *documentID, false)
+	} else {
+		count, err = env.Dynamo(ctx).GetNotificationCountForUser(currentUser.Id, false)
+	}
+	if err != nil {
+		log.Error("error getting unread notification count", "error", err)
+		return 0, fmt.Errorf("sorry, we could not count your notifications")
+	}
+
+	return int(count), nil
+}
+
+// NotificationMutes is the resolver for the notificationMutes field.
+func (r *queryResolver) NotificationMutes(ctx context.Context) ([]*dynamo.NotificationMute, error) {
+	log := env.SLog(ctx)
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	mutes, err := env.Dynamo(ctx).GetNotificationMutesForUser(currentUser.Id)
+	if err != nil {
+		log.Error("error getting notification mutes", "error", err)
+		return nil, fmt.Errorf("sorry, we could not get your muted notifications")
+	}
+
+	return mutes, nil
+}
+
+// FromUser is the resolver for the fromUser field.
+func (r *shareNotificationPayloadValueResolver) FromUser(ctx context.Context, obj *model.ShareNotificationPayloadValue) (*models.User, error) {
+	return loaders.GetUser(ctx, obj.FromUserID)
+}
+
+// NotificationReceived is the resolver for the notificationReceived field.
+func (r *subscriptionResolver) NotificationReceived(ctx context.Context) (<-chan *dynamo.Notification, error) {
+	log := env.SLog(ctx)
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	ch := make(chan *dynamo.Notification)
+	go notifications.ListenForNotifications(ctx, ch, currentUser.Id)
+	return ch, nil
+}
+
+// Author is the resolver for the author field.
+func (r *timelineCommentNotificationPayloadValueResolver) Author(ctx context.Context, obj *model.TimelineCommentNotificationPayloadValue) (*models.User, error) {
+	return loaders.GetUser(ctx, obj.AuthorID)
+}
+
+// NotificationMute returns NotificationMuteResolver implementation.
+func (r *Resolver) NotificationMute() NotificationMuteResolver { return &notificationMuteResolver{r} }
+
+// ShareNotificationPayloadValue returns ShareNotificationPayloadValueResolver implementation.
+func (r *Resolver) ShareNotificationPayloadValue() ShareNotificationPayloadValueResolver {
+	return &shareNotificationPayloadValueResolver{r}
+}
+
+// TimelineCommentNotificationPayloadValue returns TimelineCommentNotificationPayloadValueResolver implementation.
+func (r *Resolver) TimelineCommentNotificationPayloadValue() TimelineCommentNotificationPayloadValueResolver {
+	return &timelineCommentNotificationPayloadValueResolver{r}
+}
+
+type notificationMuteResolver struct{ *Resolver }
+type shareNotificationPayloadValueResolver struct{ *Resolver }
+type timelineCommentNotificationPayloadValueResolver struct{ *Resolver }
@@ -0,0 +1,55 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/graph/model"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/rogue"
+)
+
+// presenceModels loads the users for presence entries, entries for users
+// that no longer exist are dropped
+func presenceModels(ctx context.Context, presences []rogue.Presence) ([]*model.Presence, error) {
+	userIDs := make([]string, len(presences))
+	for i, presence := range presences {
+		userIDs[i] = presence.UserID
+	}
+
+	userTbl := env.Query(ctx).User
+	users, err := userTbl.Where(userTbl.ID.In(userIDs...)).Find()
+	if err != nil {
+		return nil, fmt.Errorf("error getting users: %w", err)
+	}
+
+	userMap := map[string]*models.User{}
+	for _, user := range users {
+		userMap[user.ID] = user
+	}
+
+	result := []*model.Presence{}
+	for _, presence := range presences {
+		user, ok := userMap[presence.UserID]
+		if !ok {
+			continue
+		}
+
+		p := &model.Presence{
+			User:         user,
+			State:        model.PresenceState(strings.ToUpper(presence.State)),
+			LastActiveAt: presence.LastActiveAt,
+		}
+
+		if presence.BlockID != "" {
+			blockID := presence.BlockID
+			p.BlockID = &blockID
+		}
+
+		result = append(result, p)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,100 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.35
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/graph/model"
+	"github.com/fivetentaylor/pointy/pkg/query"
+	"github.com/fivetentaylor/pointy/pkg/rogue"
+)
+
+// PresenceHeartbeat is the resolver for the presenceHeartbeat field.
+func (r *mutationResolver) PresenceHeartbeat(ctx context.Context, documentID string, idle *bool) (bool, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return false, fmt.Errorf("please login")
+	}
+
+	_, err = query.GetReadableDocumentForUser(env.Query(ctx), documentID, currentUser.Id)
+	if err != nil {
+		return false, err
+	}
+
+	err = rogue.ReadViewHeartbeat(ctx, env.Redis(ctx), documentID, currentUser.Id, idle != nil && *idle)
+	if err != nil {
+		log.Error("error recording presence", "documentID", documentID, "userID", currentUser.Id, "error", err)
+		return false, fmt.Errorf("sorry, we could not update your presence")
+	}
+
+	return true, nil
+}
+
+// DocumentPresence is the resolver for the documentPresence field.
+func (r *queryResolver) DocumentPresence(ctx context.Context, documentID string) ([]*model.Presence, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	_, err = query.GetReadableDocumentForUser(env.Query(ctx), documentID, currentUser.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	presences, err := rogue.CurrentPresence(ctx, env.Redis(ctx), documentID)
+	if err != nil {
+		log.Error("error getting presence", "documentID", documentID, "error", err)
+		return nil, fmt.Errorf("sorry, we could not get who is here")
+	}
+
+	return presenceModels(ctx, presences)
+}
+
+// PresenceChanged is the resolver for the presenceChanged field.
+func (r *subscriptionResolver) PresenceChanged(ctx context.Context, documentID string) (<-chan []*model.Presence, error) {
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("please login")
+	}
+
+	_, err = query.GetReadableDocumentForUser(env.Query(ctx), documentID, currentUser.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	presenceCh := make(chan []rogue.Presence)
+	go rogue.ListenForPresence(ctx, env.Redis(ctx), documentID, presenceCh)
+
+	ch := make(chan []*model.Presence)
+	go func() {
+		defer close(ch)
+
+		for presences := range presenceCh {
+			result, err := presenceModels(ctx, presences)
+			if err != nil {
+				env.SLog(ctx).Error("error loading presence", "documentID", documentID, "error", err)
+				continue
+			}
+
+			select {
+			case ch <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
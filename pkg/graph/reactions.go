@@ -0,0 +1,79 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/graph/model"
+	"github.com/fivetentaylor/pointy/pkg/query"
+	"github.com/fivetentaylor/pointy/pkg/service/reactions"
+)
+
+// react adds or removes the current user's reaction and returns the
+// reactions after the change
+func react(ctx context.Context, input model.ReactionInput, add bool) ([]*model.ReactionGroup, error) {
+	log := env.SLog(ctx)
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	_, err = query.GetReadableDocumentForUser(env.Query(ctx), input.DocumentID, currentUser.Id)
+	if err != nil {
+		log.Error("error getting document", "documentID", input.DocumentID, "error", err)
+		return nil, fmt.Errorf("document not found")
+	}
+
+	if input.ThreadID != nil {
+		// ask ai threads are private to the user that started them
+		thread, err := env.Dynamo(ctx).GetThreadForUser(input.DocumentID, *input.ThreadID, currentUser.Id)
+		if err != nil || thread == nil {
+			log.Error("error getting thread", "threadID", *input.ThreadID, "error", err)
+			return nil, fmt.Errorf("you don't have access to this thread")
+		}
+
+		_, err = reactions.ReactToAiThreadMessage(ctx, currentUser.Id, input.DocumentID, *input.ThreadID, input.MessageID, input.Emoji, add)
+	} else {
+		_, err = reactions.ReactToTimelineEvent(ctx, currentUser.Id, input.DocumentID, input.MessageID, input.Emoji, add)
+	}
+	if err != nil {
+		if errors.Is(err, reactions.ErrInvalidEmoji) || errors.Is(err, reactions.ErrNotReactable) || errors.Is(err, reactions.ErrNotFound) {
+			return nil, err
+		}
+		log.Error("error reacting", "messageID", input.MessageID, "error", err)
+		return nil, fmt.Errorf("sorry, we could not save your reaction")
+	}
+
+	return reactionGroups(ctx, input.MessageID)
+}
+
+// reactionGroups loads the reactions on a timeline comment or message for the
+// current user
+func reactionGroups(ctx context.Context, targetID string) ([]*model.ReactionGroup, error) {
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("please login")
+	}
+
+	rs, err := env.Dynamo(ctx).GetReactions(targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := reactions.GroupReactions(rs)
+	out := make([]*model.ReactionGroup, len(groups))
+	for i, g := range groups {
+		out[i] = &model.ReactionGroup{
+			Emoji:       g.Emoji,
+			Count:       len(g.UserIDs),
+			ReactedByMe: slices.Contains(g.UserIDs, currentUser.Id),
+			UserIds:     g.UserIDs,
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,33 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.35
+
+import (
+	"context"
+
+	"github.com/fivetentaylor/pointy/pkg/graph/loaders"
+	"github.com/fivetentaylor/pointy/pkg/graph/model"
+	"github.com/fivetentaylor/pointy/pkg/models"
+)
+
+// AddReaction is the resolver for the addReaction field.
+func (r *mutationResolver) AddReaction(ctx context.Context, input model.ReactionInput) ([]*model.ReactionGroup, error) {
+	return react(ctx, input, true)
+}
+
+// RemoveReaction is the resolver for the removeReaction field.
+func (r *mutationResolver) RemoveReaction(ctx context.Context, input model.ReactionInput) ([]*model.ReactionGroup, error) {
+	return react(ctx, input, false)
+}
+
+// Users is the resolver for the users field.
+func (r *reactionGroupResolver) Users(ctx context.Context, obj *model.ReactionGroup) ([]*models.User, error) {
+	return loaders.GetUsers(ctx, obj.UserIds)
+}
+
+// ReactionGroup returns ReactionGroupResolver implementation.
+func (r *Resolver) ReactionGroup() ReactionGroupResolver { return &reactionGroupResolver{r} }
+
+type reactionGroupResolver struct{ *Resolver }
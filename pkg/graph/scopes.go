@@ -0,0 +1,105 @@
+package graph
+
+import (
+	"context"
+	"slices"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"github.com/fivetentaylor/pointy/pkg/constants"
+	"github.com/fivetentaylor/pointy/pkg/env"
+)
+
+// sessionOnlyFields can't be used with an api token, a leaked token shouldn't
+// be able to mint more tokens or send documents somewhere else with a webhook
+var sessionOnlyFields = []string{
+	"apiTokens",
+	"createApiToken",
+	"revokeApiToken",
+	"webhooks",
+	"webhookDeliveries",
+	"createWebhook",
+	"deleteWebhook",
+}
+
+// commentMutations only need the comment scope rather than write_docs
+var commentMutations = []string{
+	"createTimelineMessage",
+	"editTimelineMessage",
+	"updateMessageResolution",
+	"editMessageResolutionSummary",
+	"deleteTimelineMessage",
+}
+
+// documentIDFields are root fields whose id argument is a document id
+var documentIDFields = []string{
+	"document",
+	"branches",
+	"updateDocument",
+	"deleteDocument",
+	"softDeleteDocument",
+	"copyDocument",
+	"moveDocument",
+	"updateDocumentPreference",
+}
+
+// APITokenScopes limits requests made with an api token to the token's
+// scopes. Tokens limited to particular documents can only use root fields
+// that take one of those documents.
+func APITokenScopes(ctx context.Context, next graphql.RootResolver) graphql.Marshaler {
+	claims, err := env.UserClaim(ctx)
+	if err != nil || !claims.IsAPIToken() {
+		return next(ctx)
+	}
+
+	rfc := graphql.GetRootFieldContext(ctx)
+	field := rfc.Field.Name
+	if field == "__schema" || field == "__type" || field == "__typename" {
+		return next(ctx)
+	}
+
+	if slices.Contains(sessionOnlyFields, field) {
+		graphql.AddErrorf(ctx, "api tokens can not use %s", field)
+		return graphql.Null
+	}
+
+	scope := constants.APITokenScopeReadDocs
+	if rfc.Object == "Mutation" {
+		scope = constants.APITokenScopeWriteDocs
+		if slices.Contains(commentMutations, field) {
+			scope = constants.APITokenScopeComment
+		}
+	}
+
+	if !claims.HasScope(scope) {
+		graphql.AddErrorf(ctx, "api token is missing the %s scope", scope)
+		return graphql.Null
+	}
+
+	if len(claims.DocumentIDs) > 0 {
+		docID := rootFieldDocumentID(ctx, rfc)
+		if docID == "" || !claims.CanAccessDocument(docID) {
+			graphql.AddErrorf(ctx, "api token can not access this document")
+			return graphql.Null
+		}
+	}
+
+	return next(ctx)
+}
+
+func rootFieldDocumentID(ctx context.Context, rfc *graphql.RootFieldContext) string {
+	args := rfc.Field.ArgumentMap(graphql.GetOperationContext(ctx).Variables)
+
+	keys := []string{"documentId", "documentID", "docId"}
+	if slices.Contains(documentIDFields, rfc.Field.Name) {
+		keys = append(keys, "id")
+	}
+
+	for _, key := range keys {
+		if id, ok := args[key].(string); ok {
+			return id
+		}
+	}
+
+	return ""
+}
@@ -216,7 +216,7 @@ func (r *mutationResolver) JoinShareLink(ctx context.Context, inviteLink string)
 		return nil, fmt.Errorf("sorry, we could not load your share link")
 	}
 
-	doc, err := sharing.JoinDoc(ctx, sl.DocumentID, currentUser.Id, "write")
+	doc, err := sharing.JoinLink(ctx, sl, currentUser.Id)
 	if err != nil {
 		log.Errorf("error joining share link: %s", stackerr.Wrap(err))
 		return nil, fmt.Errorf("sorry, we could not join your share link")
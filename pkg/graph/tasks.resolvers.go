@@ -0,0 +1,141 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.35
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/graph/loaders"
+	"github.com/fivetentaylor/pointy/pkg/graph/model"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/query"
+	"github.com/fivetentaylor/pointy/pkg/service/tasks"
+	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
+)
+
+// Assignee is the resolver for the assignee field.
+func (r *commentTaskResolver) Assignee(ctx context.Context, obj *models.CommentTask) (*models.User, error) {
+	return loaders.GetUser(ctx, obj.AssigneeID)
+}
+
+// AssignedBy is the resolver for the assignedBy field.
+func (r *commentTaskResolver) AssignedBy(ctx context.Context, obj *models.CommentTask) (*models.User, error) {
+	return loaders.GetUser(ctx, obj.AssignedByID)
+}
+
+// Document is the resolver for the document field.
+func (r *commentTaskResolver) Document(ctx context.Context, obj *models.CommentTask) (*models.Document, error) {
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("please login")
+	}
+
+	return query.GetReadableDocumentForUser(env.Query(ctx), obj.DocumentID, currentUser.Id)
+}
+
+// Comment is the resolver for the comment field.
+func (r *commentTaskResolver) Comment(ctx context.Context, obj *models.CommentTask) (*dynamo.TimelineEvent, error) {
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("please login")
+	}
+
+	_, err = query.GetReadableDocumentForUser(env.Query(ctx), obj.DocumentID, currentUser.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return env.Dynamo(ctx).GetTimelineEvent(obj.DocumentID, obj.EventID)
+}
+
+// AssignCommentTask is the resolver for the assignCommentTask field.
+func (r *mutationResolver) AssignCommentTask(ctx context.Context, input model.AssignCommentTaskInput) (*models.CommentTask, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	task, err := tasks.Assign(ctx, currentUser.Id, input.DocumentID, input.EventID, input.AssigneeID, input.DueDate)
+	if errors.Is(err, tasks.ErrNotAssignable) || errors.Is(err, tasks.ErrNotCollaborator) || errors.Is(err, tasks.ErrCommentNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		log.Error("error assigning task", "documentID", input.DocumentID, "eventID", input.EventID, "error", err)
+		return nil, fmt.Errorf("sorry, we could not assign this task")
+	}
+
+	return task, nil
+}
+
+// UnassignCommentTask is the resolver for the unassignCommentTask field.
+func (r *mutationResolver) UnassignCommentTask(ctx context.Context, documentID string, eventID string) (bool, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return false, fmt.Errorf("please login")
+	}
+
+	err = tasks.Unassign(ctx, currentUser.Id, documentID, eventID)
+	if err != nil {
+		log.Error("error unassigning task", "documentID", documentID, "eventID", eventID, "error", err)
+		return false, fmt.Errorf("sorry, we could not unassign this task")
+	}
+
+	return true, nil
+}
+
+// SetCommentTaskDone is the resolver for the setCommentTaskDone field.
+func (r *mutationResolver) SetCommentTaskDone(ctx context.Context, documentID string, eventID string, done bool) (*models.CommentTask, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	task, err := tasks.SetDone(ctx, currentUser.Id, documentID, eventID, done)
+	if errors.Is(err, tasks.ErrTaskNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		log.Error("error updating task", "documentID", documentID, "eventID", eventID, "error", err)
+		return nil, fmt.Errorf("sorry, we could not update this task")
+	}
+
+	return task, nil
+}
+
+// MyOpenTasks is the resolver for the myOpenTasks field.
+func (r *queryResolver) MyOpenTasks(ctx context.Context) ([]*models.CommentTask, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	openTasks, err := tasks.OpenTasksForUser(ctx, currentUser.Id)
+	if err != nil {
+		log.Error("error getting open tasks", "userID", currentUser.Id, "error", err)
+		return nil, fmt.Errorf("sorry, we could not get your tasks")
+	}
+
+	return openTasks, nil
+}
+
+// CommentTask returns CommentTaskResolver implementation.
+func (r *Resolver) CommentTask() CommentTaskResolver { return &commentTaskResolver{r} }
+
+type commentTaskResolver struct{ *Resolver }
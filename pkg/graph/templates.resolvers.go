@@ -0,0 +1,138 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.35
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/graph/loaders"
+	"github.com/fivetentaylor/pointy/pkg/graph/model"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/query"
+	"github.com/fivetentaylor/pointy/pkg/service/doctemplates"
+)
+
+// Placeholders is the resolver for the placeholders field.
+func (r *documentTemplateResolver) Placeholders(ctx context.Context, obj *models.DocumentTemplate) ([]string, error) {
+	return doctemplates.PlaceholdersFor(obj), nil
+}
+
+// Owner is the resolver for the owner field.
+func (r *documentTemplateResolver) Owner(ctx context.Context, obj *models.DocumentTemplate) (*models.User, error) {
+	return loaders.GetUser(ctx, obj.UserID)
+}
+
+// SaveDocumentTemplate is the resolver for the saveDocumentTemplate field.
+func (r *mutationResolver) SaveDocumentTemplate(ctx context.Context, input model.SaveDocumentTemplateInput) (*models.DocumentTemplate, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	saveInput := doctemplates.SaveInput{Address: input.Address}
+	if input.Title != nil {
+		saveInput.Title = *input.Title
+	}
+	if input.Description != nil {
+		saveInput.Description = *input.Description
+	}
+	if input.Shared != nil {
+		saveInput.Shared = *input.Shared
+	}
+
+	tmpl, err := doctemplates.Save(ctx, currentUser.Id, input.DocumentID, saveInput)
+	if errors.Is(err, doctemplates.ErrInvalidAddress) {
+		return nil, fmt.Errorf("sorry, that version of the document could not be found")
+	}
+	var accessErr *query.AccessDeniedError
+	if saveInput.Shared && errors.As(err, &accessErr) {
+		return nil, fmt.Errorf("sorry, you need edit access to the document to share a template")
+	}
+	if err != nil {
+		log.Error("error saving template", "documentID", input.DocumentID, "error", err)
+		return nil, fmt.Errorf("sorry, we could not save your template")
+	}
+
+	return tmpl, nil
+}
+
+// DeleteDocumentTemplate is the resolver for the deleteDocumentTemplate field.
+func (r *mutationResolver) DeleteDocumentTemplate(ctx context.Context, id string) (bool, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return false, fmt.Errorf("please login")
+	}
+
+	err = doctemplates.Delete(ctx, currentUser.Id, id)
+	if errors.Is(err, doctemplates.ErrNotFound) {
+		return false, err
+	}
+	if err != nil {
+		log.Error("error deleting template", "templateID", id, "error", err)
+		return false, fmt.Errorf("sorry, we could not delete your template")
+	}
+
+	return true, nil
+}
+
+// CreateDocumentFromTemplate is the resolver for the createDocumentFromTemplate field.
+func (r *mutationResolver) CreateDocumentFromTemplate(ctx context.Context, templateID string, values []*model.TemplateValueInput) (*models.Document, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	valuesByName := map[string]string{}
+	for _, v := range values {
+		valuesByName[v.Name] = v.Value
+	}
+
+	doc, err := doctemplates.CreateDocument(ctx, currentUser.Id, templateID, valuesByName)
+	if errors.Is(err, doctemplates.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		log.Error("error creating document from template", "templateID", templateID, "error", err)
+		return nil, fmt.Errorf("sorry, we could not create your document")
+	}
+
+	return doc, nil
+}
+
+// DocumentTemplates is the resolver for the documentTemplates field.
+func (r *queryResolver) DocumentTemplates(ctx context.Context) ([]*models.DocumentTemplate, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	tmpls, err := doctemplates.List(ctx, currentUser.Id)
+	if err != nil {
+		log.Error("error listing templates", "userID", currentUser.Id, "error", err)
+		return nil, fmt.Errorf("sorry, we could not get your templates")
+	}
+
+	return tmpls, nil
+}
+
+// DocumentTemplate returns DocumentTemplateResolver implementation.
+func (r *Resolver) DocumentTemplate() DocumentTemplateResolver { return &documentTemplateResolver{r} }
+
+type documentTemplateResolver struct{ *Resolver }
@@ -430,6 +430,16 @@ func (r *tLMessageV1Resolver) Replies(ctx context.Context, obj *model.TLMessageV
 	return env.Dynamo(ctx).GetDocumentTimelineReplies(obj.DocumentID, obj.EventID)
 }
 
+// Reactions is the resolver for the reactions field.
+func (r *tLMessageV1Resolver) Reactions(ctx context.Context, obj *model.TLMessageV1) ([]*model.ReactionGroup, error) {
+	return reactionGroups(ctx, obj.EventID)
+}
+
+// Task is the resolver for the task field.
+func (r *tLMessageV1Resolver) Task(ctx context.Context, obj *model.TLMessageV1) (*models.CommentTask, error) {
+	return loaders.GetCommentTask(ctx, obj.DocumentID, obj.EventID)
+}
+
 // ID is the resolver for the id field.
 func (r *timelineEventResolver) ID(ctx context.Context, obj *dynamo.TimelineEvent) (string, error) {
 	return obj.EventID, nil
@@ -538,6 +548,34 @@ func (r *timelineEventResolver) Event(ctx context.Context, obj *dynamo.TimelineE
 			ContentAddressBefore: v.Paste.ContentAddressBefore,
 			ContentAddressAfter:  v.Paste.ContentAddressAfter,
 		}, nil
+	case *models.TimelineEventPayload_OfflineEdits:
+		return model.TLOfflineEditsV1{
+			ContentAddressBase:   v.OfflineEdits.ContentAddressBase,
+			ContentAddressBefore: v.OfflineEdits.ContentAddressBefore,
+			ContentAddressAfter:  v.OfflineEdits.ContentAddressAfter,
+			ConcurrentSpans:      int(v.OfflineEdits.ConcurrentSpans),
+		}, nil
+	case *models.TimelineEventPayload_BranchMerge:
+		return model.TLBranchMergeV1{
+			BranchID:             v.BranchMerge.BranchId,
+			ParentID:             v.BranchMerge.ParentId,
+			ContentAddressBefore: v.BranchMerge.ContentAddressBefore,
+			ContentAddressAfter:  v.BranchMerge.ContentAddressAfter,
+			ConcurrentSpans:      int(v.BranchMerge.ConcurrentSpans),
+		}, nil
+	case *models.TimelineEventPayload_Restore:
+		var undoOf *string
+		if v.Restore.UndoOfEventId != "" {
+			undoOf = &v.Restore.UndoOfEventId
+		}
+		return model.TLRestoreV1{
+			ContentAddressTarget: v.Restore.ContentAddressTarget,
+			ContentAddressBefore: v.Restore.ContentAddressBefore,
+			ContentAddressAfter:  v.Restore.ContentAddressAfter,
+			StartID:              v.Restore.StartId,
+			EndID:                v.Restore.EndId,
+			UndoOfEventID:        undoOf,
+		}, nil
 	default:
 		log.Error("unknown payload type")
 		return model.TLEmpty{
@@ -546,6 +584,44 @@ func (r *timelineEventResolver) Event(ctx context.Context, obj *dynamo.TimelineE
 	}
 }
 
+// Anchor is the resolver for the anchor field.
+func (r *timelineEventResolver) Anchor(ctx context.Context, obj *dynamo.TimelineEvent) (*model.TLCommentAnchor, error) {
+	log := env.SLog(ctx)
+
+	if !timeline.HasAnchor(obj) {
+		return nil, nil
+	}
+
+	// resolved threads aren't shown in the document
+	replies, err := loaders.GetThreadReplies(ctx, obj.DocID, obj.EventID)
+	if err != nil {
+		log.Error("error getting comment replies", "event_id", obj.EventID, "error", err)
+		return nil, fmt.Errorf("sorry, we couldn't find where this comment is")
+	}
+	if timeline.IsThreadResolved(replies) {
+		return nil, nil
+	}
+
+	msg := obj.Event.GetMessage()
+	anchor, err := loaders.GetCommentAnchor(ctx, loaders.CommentAnchorInput{
+		DocumentID:     obj.DocID,
+		StartID:        msg.SelectionStartId,
+		EndID:          msg.SelectionEndId,
+		ContentAddress: msg.ContentAddress,
+	})
+	if err != nil {
+		log.Error("error resolving comment anchor", "event_id", obj.EventID, "error", err)
+		return nil, fmt.Errorf("sorry, we couldn't find where this comment is")
+	}
+
+	return &model.TLCommentAnchor{
+		StartID:  anchor.StartID.String(),
+		EndID:    anchor.EndID.String(),
+		Orphaned: anchor.Orphaned,
+		Changed:  anchor.Changed,
+	}, nil
+}
+
 // TLMessageV1 returns TLMessageV1Resolver implementation.
 func (r *Resolver) TLMessageV1() TLMessageV1Resolver { return &tLMessageV1Resolver{r} }
 
@@ -9,10 +9,10 @@ import (
 	"fmt"
 
 	"github.com/charmbracelet/log"
-	"github.com/fivetentaylor/pointy/pkg/constants"
 	"github.com/fivetentaylor/pointy/pkg/env"
 	"github.com/fivetentaylor/pointy/pkg/graph/model"
 	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/query"
 	"github.com/fivetentaylor/pointy/pkg/service/images"
 	"github.com/fivetentaylor/pointy/pkg/service/payments"
 )
@@ -62,6 +62,10 @@ func (r *mutationResolver) UpdateMyPreference(ctx context.Context, input model.U
 		dpref.Preference.EnableActivityNotifications = *input.EnableActivityNotifications
 	}
 
+	if input.DigestFrequency != nil {
+		dpref.Preference.DigestFrequency = digestFrequencyProto(*input.DigestFrequency)
+	}
+
 	err = env.Dynamo(ctx).UpsertUserPreference(dpref)
 	if err != nil {
 		log.Errorf("error updating user preference: %s", err)
@@ -102,35 +106,7 @@ func (r *queryResolver) UsersInMyDomain(ctx context.Context, includeSelf *bool)
 		return nil, fmt.Errorf("could not find current user: %w", err)
 	}
 
-	db := env.RawDB(ctx)
-	query := `
-        WITH collaborators AS (
-            SELECT DISTINCT da2.user_id AS collaborator_id
-            FROM document_access da1
-            JOIN document_access da2 ON da1.document_id = da2.document_id AND da1.user_id != da2.user_id
-            WHERE da1.user_id = ?
-        )
-        SELECT u.*
-        FROM collaborators c
-        JOIN users u ON c.collaborator_id = u.id
-        WHERE u.id != ?
-    `
-	args := []interface{}{currentUser.Id, constants.RevisoUserID}
-
-	if includeSelf != nil && *includeSelf {
-		query += `
-            UNION ALL
-            SELECT * FROM users WHERE id = ?
-        `
-		args = append(args, currentUser.Id)
-	}
-
-	var users []*models.User
-	if err := db.Raw(query, args...).Scan(&users).Error; err != nil {
-		return nil, fmt.Errorf("failed to fetch users: %w", err)
-	}
-
-	return users, nil
+	return query.GetUsersInDomain(env.RawDB(ctx), currentUser.Id, includeSelf != nil && *includeSelf)
 }
 
 // MyPreference is the resolver for the myPreference field.
@@ -183,7 +159,16 @@ func (r *userResolver) SubscriptionStatus(ctx context.Context, obj *models.User)
 	return status, nil
 }
 
+// DigestFrequency is the resolver for the digestFrequency field.
+func (r *userPreferenceResolver) DigestFrequency(ctx context.Context, obj *models.UserPreference) (model.EmailDigestFrequency, error) {
+	return digestFrequencyModel(obj.DigestFrequency), nil
+}
+
 // User returns UserResolver implementation.
 func (r *Resolver) User() UserResolver { return &userResolver{r} }
 
+// UserPreference returns UserPreferenceResolver implementation.
+func (r *Resolver) UserPreference() UserPreferenceResolver { return &userPreferenceResolver{r} }
+
 type userResolver struct{ *Resolver }
+type userPreferenceResolver struct{ *Resolver }
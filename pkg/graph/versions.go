@@ -0,0 +1,83 @@
+package graph
+
+import (
+	"context"
+	"strings"
+
+	"github.com/fivetentaylor/pointy/pkg/graph/model"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/service/versions"
+)
+
+func versionRef(ref model.VersionRef) versions.Ref {
+	out := versions.Ref{}
+	if ref.FlaggedVersionID != nil {
+		out.FlaggedVersionID = *ref.FlaggedVersionID
+	}
+	if ref.TimelineEventID != nil {
+		out.TimelineEventID = *ref.TimelineEventID
+	}
+	if ref.Address != nil {
+		out.Address = *ref.Address
+	}
+
+	return out
+}
+
+func versionComparisonModel(ctx context.Context, documentID string, comparison *versions.Comparison) (*model.VersionComparison, error) {
+	authors := []string{}
+	for _, change := range comparison.Changes {
+		authors = append(authors, change.Authors...)
+	}
+
+	userFor, err := authorUsers(ctx, documentID, authors)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.VersionComparison{
+		Changes:  make([]*model.VersionChange, len(comparison.Changes)),
+		HTML:     comparison.Html,
+		Markdown: comparison.Markdown,
+	}
+
+	for i, change := range comparison.Changes {
+		users := []*models.User{}
+		for _, author := range change.Authors {
+			user := userFor(author)
+			if user != nil && !containsUser(users, user.ID) {
+				users = append(users, user)
+			}
+		}
+
+		kind := model.VersionChangeKind(strings.ToUpper(string(change.Kind)))
+		switch kind {
+		case model.VersionChangeKindInsert:
+			result.WordsInserted += change.Words
+		case model.VersionChangeKindDelete:
+			result.WordsDeleted += change.Words
+		}
+
+		result.Changes[i] = &model.VersionChange{
+			Kind:      kind,
+			StartID:   change.StartID.String(),
+			EndID:     change.EndID.String(),
+			AuthorIds: change.Authors,
+			Users:     users,
+			Text:      change.Text,
+			Words:     change.Words,
+		}
+	}
+
+	return result, nil
+}
+
+func containsUser(users []*models.User, id string) bool {
+	for _, user := range users {
+		if user.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
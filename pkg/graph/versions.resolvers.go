@@ -0,0 +1,113 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.35
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/graph/model"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/service/versions"
+)
+
+// CompareVersions is the resolver for the compareVersions field.
+func (r *queryResolver) CompareVersions(ctx context.Context, documentID string, from model.VersionRef, to model.VersionRef) (*model.VersionComparison, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	comparison, err := versions.Compare(ctx, currentUser.Id, documentID, versionRef(from), versionRef(to))
+	if err != nil {
+		log.Error("error comparing versions", "documentID", documentID, "error", err)
+		switch {
+		case errors.Is(err, versions.ErrVersionNotFound):
+			return nil, fmt.Errorf("sorry, we could not find that version")
+		case errors.Is(err, versions.ErrInvalidRef):
+			return nil, fmt.Errorf("only one of flaggedVersionId, timelineEventId or address can be set")
+		}
+		return nil, fmt.Errorf("sorry, we could not compare those versions")
+	}
+
+	result, err := versionComparisonModel(ctx, documentID, comparison)
+	if err != nil {
+		log.Error("error getting version change users", "documentID", documentID, "error", err)
+		return nil, fmt.Errorf("sorry, we could not compare those versions")
+	}
+
+	return result, nil
+}
+
+// DocumentVersions is the resolver for the documentVersions field.
+func (r *queryResolver) DocumentVersions(ctx context.Context, documentID string, includeAuto *bool) ([]*models.DocumentVersion, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	result, err := versions.List(ctx, currentUser.Id, documentID, includeAuto != nil && *includeAuto)
+	if err != nil {
+		log.Error("error listing versions", "documentID", documentID, "error", err)
+		return nil, fmt.Errorf("sorry, we could not load the versions")
+	}
+
+	return result, nil
+}
+
+// RestoreVersion is the resolver for the restoreVersion field.
+func (r *mutationResolver) RestoreVersion(ctx context.Context, documentID string, address string, startID *string, endID *string) (*models.Document, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	doc, err := versions.Restore(ctx, currentUser.Id, documentID, address, startID, endID)
+	if err != nil {
+		log.Error("error restoring version", "documentID", documentID, "error", err)
+		switch {
+		case errors.Is(err, versions.ErrVersionNotFound):
+			return nil, fmt.Errorf("sorry, we could not find that version")
+		case errors.Is(err, versions.ErrInvalidRange):
+			return nil, fmt.Errorf("sorry, that selection is not part of the document")
+		}
+		return nil, fmt.Errorf("sorry, we could not restore that version")
+	}
+
+	return doc, nil
+}
+
+// UndoRestoreVersion is the resolver for the undoRestoreVersion field.
+func (r *mutationResolver) UndoRestoreVersion(ctx context.Context, documentID string, timelineEventID string) (*models.Document, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	doc, err := versions.UndoRestore(ctx, currentUser.Id, documentID, timelineEventID)
+	if err != nil {
+		log.Error("error undoing restore", "documentID", documentID, "timelineEventID", timelineEventID, "error", err)
+		if errors.Is(err, versions.ErrNotRestore) {
+			return nil, fmt.Errorf("sorry, that is not a restore")
+		}
+		return nil, fmt.Errorf("sorry, we could not undo that restore")
+	}
+
+	return doc, nil
+}
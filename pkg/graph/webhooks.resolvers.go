@@ -0,0 +1,152 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.35
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/graph/model"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/service/webhooks"
+)
+
+// CreateWebhook is the resolver for the createWebhook field.
+func (r *mutationResolver) CreateWebhook(ctx context.Context, input model.CreateWebhookInput) (*models.Webhook, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	events := []string{}
+	for _, event := range input.Events {
+		events = append(events, strings.ToLower(strings.ReplaceAll(string(event), "_", ".")))
+	}
+
+	webhook, err := webhooks.Create(ctx, currentUser.Id, webhooks.CreateInput{
+		URL:        input.URL,
+		DocumentID: input.DocumentID,
+		Events:     events,
+	})
+	if err != nil {
+		log.Error("error creating webhook", "userID", currentUser.Id, "error", err)
+		return nil, fmt.Errorf("sorry, we could not create your webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// DeleteWebhook is the resolver for the deleteWebhook field.
+func (r *mutationResolver) DeleteWebhook(ctx context.Context, id string) (bool, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return false, fmt.Errorf("please login")
+	}
+
+	err = webhooks.Delete(ctx, currentUser.Id, id)
+	if errors.Is(err, webhooks.ErrNotFound) {
+		return false, fmt.Errorf("webhook not found")
+	}
+	if err != nil {
+		log.Error("error deleting webhook", "userID", currentUser.Id, "webhookID", id, "error", err)
+		return false, fmt.Errorf("sorry, we could not delete your webhook")
+	}
+
+	return true, nil
+}
+
+// Webhooks is the resolver for the webhooks field.
+func (r *queryResolver) Webhooks(ctx context.Context, documentID *string) ([]*models.Webhook, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	hooks, err := webhooks.List(ctx, currentUser.Id, documentID)
+	if err != nil {
+		log.Error("error listing webhooks", "userID", currentUser.Id, "error", err)
+		return nil, fmt.Errorf("sorry, we could not get your webhooks")
+	}
+
+	return hooks, nil
+}
+
+// WebhookDeliveries is the resolver for the webhookDeliveries field.
+func (r *queryResolver) WebhookDeliveries(ctx context.Context, webhookID string) ([]*models.WebhookDelivery, error) {
+	log := env.SLog(ctx)
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		log.Error("error getting current user", "error", err)
+		return nil, fmt.Errorf("please login")
+	}
+
+	deliveries, err := webhooks.Deliveries(ctx, currentUser.Id, webhookID, 50)
+	if errors.Is(err, webhooks.ErrNotFound) {
+		return nil, fmt.Errorf("webhook not found")
+	}
+	if err != nil {
+		log.Error("error listing webhook deliveries", "userID", currentUser.Id, "webhookID", webhookID, "error", err)
+		return nil, fmt.Errorf("sorry, we could not get your webhook deliveries")
+	}
+
+	return deliveries, nil
+}
+
+// Events is the resolver for the events field.
+func (r *webhookResolver) Events(ctx context.Context, obj *models.Webhook) ([]model.WebhookEvent, error) {
+	events := []model.WebhookEvent{}
+	for _, event := range webhooks.EventsFor(obj) {
+		events = append(events, model.WebhookEvent(strings.ToUpper(strings.ReplaceAll(event, ".", "_"))))
+	}
+
+	return events, nil
+}
+
+// Event is the resolver for the event field.
+func (r *webhookDeliveryResolver) Event(ctx context.Context, obj *models.WebhookDelivery) (model.WebhookEvent, error) {
+	return model.WebhookEvent(strings.ToUpper(strings.ReplaceAll(obj.Event, ".", "_"))), nil
+}
+
+// ResponseStatus is the resolver for the responseStatus field.
+func (r *webhookDeliveryResolver) ResponseStatus(ctx context.Context, obj *models.WebhookDelivery) (*int, error) {
+	if obj.ResponseStatus == 0 {
+		return nil, nil
+	}
+
+	status := int(obj.ResponseStatus)
+	return &status, nil
+}
+
+// NextAttemptAt is the resolver for the nextAttemptAt field.
+func (r *webhookDeliveryResolver) NextAttemptAt(ctx context.Context, obj *models.WebhookDelivery) (*time.Time, error) {
+	if obj.NextAttemptAt.IsZero() {
+		return nil, nil
+	}
+
+	return &obj.NextAttemptAt, nil
+}
+
+// Webhook returns WebhookResolver implementation.
+func (r *Resolver) Webhook() WebhookResolver { return &webhookResolver{r} }
+
+// WebhookDelivery returns WebhookDeliveryResolver implementation.
+func (r *Resolver) WebhookDelivery() WebhookDeliveryResolver { return &webhookDeliveryResolver{r} }
+
+type webhookResolver struct{ *Resolver }
+type webhookDeliveryResolver struct{ *Resolver }
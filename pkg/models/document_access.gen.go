@@ -17,6 +17,7 @@ type DocumentAccess struct {
 	UserID         string    `gorm:"column:user_id;not null" json:"user_id"`
 	AccessLevel    string    `gorm:"column:access_level;not null" json:"access_level"`
 	LastAccessedAt time.Time `gorm:"column:last_accessed_at" json:"last_accessed_at"`
+	SharedLinkID   *int32    `gorm:"column:shared_link_id" json:"shared_link_id"`
 }
 
 // TableName DocumentAccess's table name
@@ -20,6 +20,7 @@ type DocumentVersion struct {
 	UpdatedAt      time.Time `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
 	CreatedBy      string    `gorm:"column:created_by;not null" json:"created_by"`
 	UpdatedBy      string    `gorm:"column:updated_by;not null" json:"updated_by"`
+	IsAuto         bool      `gorm:"column:is_auto;not null" json:"is_auto"`
 }
 
 // TableName DocumentVersion's table name
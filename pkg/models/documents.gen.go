@@ -26,6 +26,7 @@ type Document struct {
 	ParentAddress *string        `gorm:"column:parent_address" json:"parent_address"`
 	IsFolder      bool           `gorm:"column:is_folder" json:"is_folder"`
 	FolderID      *string        `gorm:"column:folder_id" json:"folder_id"`
+	MergedAt      *time.Time     `gorm:"column:merged_at" json:"merged_at"`
 }
 
 // TableName Document's table name
@@ -185,6 +185,8 @@ type MessageMetadata struct {
 	// intent is the intent of the user (if given)
 	Intent MessageIntent `protobuf:"varint,7,opt,name=intent,proto3,enum=models.MessageIntent" json:"intent,omitempty"`
 	Llm    LLM_CHOICE    `protobuf:"varint,8,opt,name=llm,proto3,enum=models.LLM_CHOICE" json:"llm,omitempty"`
+	// promptName is the stored prompt that wrote an AI message
+	PromptName string `protobuf:"bytes,9,opt,name=promptName,proto3" json:"promptName,omitempty"`
 }
 
 func (x *MessageMetadata) Reset() {
@@ -275,6 +277,13 @@ func (x *MessageMetadata) GetLlm() LLM_CHOICE {
 	return LLM_CHOICE_LLM_CHOICE_UNSPECIFIED
 }
 
+func (x *MessageMetadata) GetPromptName() string {
+	if x != nil {
+		return x.PromptName
+	}
+	return ""
+}
+
 var File_pkg_models_messageMetadata_proto protoreflect.FileDescriptor
 
 var file_pkg_models_messageMetadata_proto_rawDesc = []byte{
@@ -282,7 +291,7 @@ var file_pkg_models_messageMetadata_proto_rawDesc = []byte{
 	0x73, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x12, 0x06, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67,
 	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xbe, 0x03, 0x0a, 0x0f,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xde, 0x03, 0x0a, 0x0f,
 	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12,
 	0x28, 0x0a, 0x0f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x44, 0x72, 0x61, 0x66, 0x74, 0x45, 0x64, 0x69,
 	0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x44,
@@ -310,7 +319,9 @@ var file_pkg_models_messageMetadata_proto_rawDesc = []byte{
 	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x06,
 	0x69, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x03, 0x6c, 0x6c, 0x6d, 0x18, 0x08, 0x20,
 	0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x4c, 0x4c, 0x4d,
-	0x5f, 0x43, 0x48, 0x4f, 0x49, 0x43, 0x45, 0x52, 0x03, 0x6c, 0x6c, 0x6d, 0x2a, 0x6d, 0x0a, 0x0e,
+	0x5f, 0x43, 0x48, 0x4f, 0x49, 0x43, 0x45, 0x52, 0x03, 0x6c, 0x6c, 0x6d, 0x12, 0x1e, 0x0a, 0x0a,
+	0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x2a, 0x6d, 0x0a, 0x0e,
 	0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1f,
 	0x0a, 0x1b, 0x52, 0x45, 0x56, 0x49, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55,
 	0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
@@ -328,10 +339,10 @@ var file_pkg_models_messageMetadata_proto_rawDesc = []byte{
 	0x1a, 0x0a, 0x16, 0x4c, 0x4c, 0x4d, 0x5f, 0x43, 0x48, 0x4f, 0x49, 0x43, 0x45, 0x5f, 0x55, 0x4e,
 	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x4c,
 	0x4c, 0x4d, 0x5f, 0x43, 0x4c, 0x41, 0x55, 0x44, 0x45, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x4c,
-	0x4c, 0x4d, 0x5f, 0x47, 0x50, 0x54, 0x34, 0x4f, 0x10, 0x02, 0x42, 0x27, 0x5a, 0x25, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x65, 0x61, 0x6d, 0x72, 0x65, 0x76,
-	0x69, 0x73, 0x6f, 0x2f, 0x63, 0x6f, 0x64, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x6d, 0x6f, 0x64,
-	0x65, 0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x4c, 0x4d, 0x5f, 0x47, 0x50, 0x54, 0x34, 0x4f, 0x10, 0x02, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x69, 0x76, 0x65, 0x74, 0x65, 0x6e,
+	0x74, 0x61, 0x79, 0x6c, 0x6f, 0x72, 0x2f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x79, 0x2f, 0x70, 0x6b,
+	0x67, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -20,6 +20,55 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type DigestFrequency int32
+
+const (
+	DigestFrequency_DigestImmediate DigestFrequency = 0
+	DigestFrequency_DigestHourly    DigestFrequency = 1
+	DigestFrequency_DigestDaily     DigestFrequency = 2
+)
+
+// Enum value maps for DigestFrequency.
+var (
+	DigestFrequency_name = map[int32]string{
+		0: "DigestImmediate",
+		1: "DigestHourly",
+		2: "DigestDaily",
+	}
+	DigestFrequency_value = map[string]int32{
+		"DigestImmediate": 0,
+		"DigestHourly":    1,
+		"DigestDaily":     2,
+	}
+)
+
+func (x DigestFrequency) Enum() *DigestFrequency {
+	p := new(DigestFrequency)
+	*p = x
+	return p
+}
+
+func (x DigestFrequency) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DigestFrequency) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_models_preferences_proto_enumTypes[0].Descriptor()
+}
+
+func (DigestFrequency) Type() protoreflect.EnumType {
+	return &file_pkg_models_preferences_proto_enumTypes[0]
+}
+
+func (x DigestFrequency) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DigestFrequency.Descriptor instead.
+func (DigestFrequency) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_models_preferences_proto_rawDescGZIP(), []int{0}
+}
+
 type NotificationMode int32
 
 const (
@@ -56,11 +105,11 @@ func (x NotificationMode) String() string {
 }
 
 func (NotificationMode) Descriptor() protoreflect.EnumDescriptor {
-	return file_pkg_models_preferences_proto_enumTypes[0].Descriptor()
+	return file_pkg_models_preferences_proto_enumTypes[1].Descriptor()
 }
 
 func (NotificationMode) Type() protoreflect.EnumType {
-	return &file_pkg_models_preferences_proto_enumTypes[0]
+	return &file_pkg_models_preferences_proto_enumTypes[1]
 }
 
 func (x NotificationMode) Number() protoreflect.EnumNumber {
@@ -69,7 +118,7 @@ func (x NotificationMode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use NotificationMode.Descriptor instead.
 func (NotificationMode) EnumDescriptor() ([]byte, []int) {
-	return file_pkg_models_preferences_proto_rawDescGZIP(), []int{0}
+	return file_pkg_models_preferences_proto_rawDescGZIP(), []int{1}
 }
 
 type UserPreference struct {
@@ -77,8 +126,9 @@ type UserPreference struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	EnableActivityNotifications    bool  `protobuf:"varint,1,opt,name=enable_activity_notifications,json=enableActivityNotifications,proto3" json:"enable_activity_notifications,omitempty"`
-	UnreadActivityFrequencyMinutes int32 `protobuf:"varint,2,opt,name=unread_activity_frequency_minutes,json=unreadActivityFrequencyMinutes,proto3" json:"unread_activity_frequency_minutes,omitempty"` // deprecated
+	EnableActivityNotifications    bool            `protobuf:"varint,1,opt,name=enable_activity_notifications,json=enableActivityNotifications,proto3" json:"enable_activity_notifications,omitempty"`
+	UnreadActivityFrequencyMinutes int32           `protobuf:"varint,2,opt,name=unread_activity_frequency_minutes,json=unreadActivityFrequencyMinutes,proto3" json:"unread_activity_frequency_minutes,omitempty"` // deprecated
+	DigestFrequency                DigestFrequency `protobuf:"varint,3,opt,name=digest_frequency,json=digestFrequency,proto3,enum=models.DigestFrequency" json:"digest_frequency,omitempty"`
 }
 
 func (x *UserPreference) Reset() {
@@ -127,6 +177,13 @@ func (x *UserPreference) GetUnreadActivityFrequencyMinutes() int32 {
 	return 0
 }
 
+func (x *UserPreference) GetDigestFrequency() DigestFrequency {
+	if x != nil {
+		return x.DigestFrequency
+	}
+	return DigestFrequency_DigestImmediate
+}
+
 type DocumentPreference struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -211,7 +268,7 @@ var File_pkg_models_preferences_proto protoreflect.FileDescriptor
 var file_pkg_models_preferences_proto_rawDesc = []byte{
 	0x0a, 0x1c, 0x70, 0x6b, 0x67, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2f, 0x70, 0x72, 0x65,
 	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
-	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x22, 0x9f, 0x01, 0x0a, 0x0e, 0x55, 0x73, 0x65, 0x72, 0x50,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x22, 0xe3, 0x01, 0x0a, 0x0e, 0x55, 0x73, 0x65, 0x72, 0x50,
 	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x42, 0x0a, 0x1d, 0x65, 0x6e, 0x61,
 	0x62, 0x6c, 0x65, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x5f, 0x6e, 0x6f, 0x74,
 	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
@@ -221,37 +278,46 @@ var file_pkg_models_preferences_proto_rawDesc = []byte{
 	0x5f, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74,
 	0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x1e, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x64,
 	0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
-	0x79, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x22, 0xcc, 0x02, 0x0a, 0x12, 0x44, 0x6f, 0x63,
-	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12,
-	0x2c, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e,
-	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x45, 0x0a,
-	0x1f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6f, 0x70,
-	0x65, 0x6e, 0x5f, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1c, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x46, 0x69,
-	0x72, 0x73, 0x74, 0x4f, 0x70, 0x65, 0x6e, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x40, 0x0a, 0x1c, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6d,
-	0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1a, 0x65, 0x6e, 0x61, 0x62,
-	0x6c, 0x65, 0x4d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x36, 0x0a, 0x17, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
-	0x5f, 0x64, 0x6d, 0x5f, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x44,
-	0x6d, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x47,
-	0x0a, 0x20, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x63, 0x6f, 0x6d,
-	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1d, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
-	0x41, 0x6c, 0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2a, 0x5d, 0x0a, 0x10, 0x4e, 0x6f, 0x74, 0x69, 0x66,
-	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x13, 0x0a, 0x0f, 0x55,
-	0x6e, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x4d, 0x6f, 0x64, 0x65, 0x10, 0x00,
-	0x12, 0x0b, 0x0a, 0x07, 0x4e, 0x6f, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x10, 0x01, 0x12, 0x0f, 0x0a,
-	0x0b, 0x41, 0x6c, 0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x10, 0x02, 0x12, 0x16,
-	0x0a, 0x12, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x41, 0x6e, 0x64, 0x4d, 0x65, 0x6e, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x10, 0x03, 0x42, 0x27, 0x5a, 0x25, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x65, 0x61, 0x6d, 0x72, 0x65, 0x76, 0x69, 0x73, 0x6f, 0x2f,
-	0x63, 0x6f, 0x64, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x79, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x42, 0x0a, 0x10, 0x64, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x5f, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x17, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x44, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x0f, 0x64, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x22, 0xcc, 0x02, 0x0a,
+	0x12, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x12, 0x2c, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x18, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x4e, 0x6f, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64,
+	0x65, 0x12, 0x45, 0x0a, 0x1f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x66, 0x69, 0x72, 0x73,
+	0x74, 0x5f, 0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1c, 0x65, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x46, 0x69, 0x72, 0x73, 0x74, 0x4f, 0x70, 0x65, 0x6e, 0x4e, 0x6f, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x40, 0x0a, 0x1c, 0x65, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x5f, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x6f, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1a,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x6f, 0x74,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x36, 0x0a, 0x17, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x5f, 0x64, 0x6d, 0x5f, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x65, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x44, 0x6d, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x47, 0x0a, 0x20, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x61, 0x6c, 0x6c,
+	0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1d, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x41, 0x6c, 0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x4e, 0x6f,
+	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2a, 0x49, 0x0a, 0x0f, 0x44,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x13,
+	0x0a, 0x0f, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x49, 0x6d, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74,
+	0x65, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x48, 0x6f, 0x75,
+	0x72, 0x6c, 0x79, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x44,
+	0x61, 0x69, 0x6c, 0x79, 0x10, 0x02, 0x2a, 0x5d, 0x0a, 0x10, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x13, 0x0a, 0x0f, 0x55, 0x6e,
+	0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x4d, 0x6f, 0x64, 0x65, 0x10, 0x00, 0x12,
+	0x0b, 0x0a, 0x07, 0x4e, 0x6f, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b,
+	0x41, 0x6c, 0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x10, 0x02, 0x12, 0x16, 0x0a,
+	0x12, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x41, 0x6e, 0x64, 0x4d, 0x65, 0x6e, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x10, 0x03, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x69, 0x76, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x61, 0x79, 0x6c, 0x6f,
+	0x72, 0x2f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x79, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -266,20 +332,22 @@ func file_pkg_models_preferences_proto_rawDescGZIP() []byte {
 	return file_pkg_models_preferences_proto_rawDescData
 }
 
-var file_pkg_models_preferences_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_pkg_models_preferences_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
 var file_pkg_models_preferences_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_pkg_models_preferences_proto_goTypes = []any{
-	(NotificationMode)(0),      // 0: models.NotificationMode
-	(*UserPreference)(nil),     // 1: models.UserPreference
-	(*DocumentPreference)(nil), // 2: models.DocumentPreference
+	(DigestFrequency)(0),       // 0: models.DigestFrequency
+	(NotificationMode)(0),      // 1: models.NotificationMode
+	(*UserPreference)(nil),     // 2: models.UserPreference
+	(*DocumentPreference)(nil), // 3: models.DocumentPreference
 }
 var file_pkg_models_preferences_proto_depIdxs = []int32{
-	0, // 0: models.DocumentPreference.mode:type_name -> models.NotificationMode
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0, // 0: models.UserPreference.digest_frequency:type_name -> models.DigestFrequency
+	1, // 1: models.DocumentPreference.mode:type_name -> models.NotificationMode
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_pkg_models_preferences_proto_init() }
@@ -318,7 +386,7 @@ func file_pkg_models_preferences_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_models_preferences_proto_rawDesc,
-			NumEnums:      1,
+			NumEnums:      2,
 			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
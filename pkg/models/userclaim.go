@@ -1,6 +1,8 @@
 package models
 
 import (
+	"slices"
+
 	"github.com/golang-jwt/jwt"
 )
 
@@ -9,9 +11,39 @@ type UserClaims struct {
 	Email string `json:"e"`
 	Admin bool   `json:"a,omitempty"`
 
+	// Set when the request was authenticated with an api token rather than a
+	// session, the token limits what the user can do
+	APITokenID  string   `json:"-"`
+	Scopes      []string `json:"-"`
+	DocumentIDs []string `json:"-"`
+
 	jwt.StandardClaims
 }
 
 func (c *UserClaims) Valid() error {
 	return c.StandardClaims.Valid()
 }
+
+// IsAPIToken reports whether the claim came from an api token
+func (c *UserClaims) IsAPIToken() bool {
+	return c.APITokenID != ""
+}
+
+// HasScope reports whether the claim allows scope, sessions have every scope
+func (c *UserClaims) HasScope(scope string) bool {
+	if !c.IsAPIToken() {
+		return true
+	}
+
+	return slices.Contains(c.Scopes, scope)
+}
+
+// CanAccessDocument reports whether the claim isn't limited to other
+// documents, it doesn't check the user's access to the document
+func (c *UserClaims) CanAccessDocument(docID string) bool {
+	if !c.IsAPIToken() || len(c.DocumentIDs) == 0 {
+		return true
+	}
+
+	return slices.Contains(c.DocumentIDs, docID)
+}
@@ -15,7 +15,7 @@ func AccessLevelForDocument(q *Query, docId, userID string) (string, error) {
 		First()
 
 	if err != nil {
-		return constants.AccessLevelNone, fmt.Errorf("error getting document access: %s", err)
+		return constants.AccessLevelNone, fmt.Errorf("error getting document access: %w", err)
 	}
 
 	return access.AccessLevel, err
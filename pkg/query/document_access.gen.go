@@ -32,6 +32,7 @@ func newDocumentAccess(db *gorm.DB, opts ...gen.DOOption) documentAccess {
 	_documentAccess.UserID = field.NewString(tableName, "user_id")
 	_documentAccess.AccessLevel = field.NewString(tableName, "access_level")
 	_documentAccess.LastAccessedAt = field.NewTime(tableName, "last_accessed_at")
+	_documentAccess.SharedLinkID = field.NewInt32(tableName, "shared_link_id")
 
 	_documentAccess.fillFieldMap()
 
@@ -47,6 +48,7 @@ type documentAccess struct {
 	UserID         field.String
 	AccessLevel    field.String
 	LastAccessedAt field.Time
+	SharedLinkID   field.Int32
 
 	fieldMap map[string]field.Expr
 }
@@ -68,6 +70,7 @@ func (d *documentAccess) updateTableName(table string) *documentAccess {
 	d.UserID = field.NewString(table, "user_id")
 	d.AccessLevel = field.NewString(table, "access_level")
 	d.LastAccessedAt = field.NewTime(table, "last_accessed_at")
+	d.SharedLinkID = field.NewInt32(table, "shared_link_id")
 
 	d.fillFieldMap()
 
@@ -84,12 +87,13 @@ func (d *documentAccess) GetFieldByName(fieldName string) (field.OrderExpr, bool
 }
 
 func (d *documentAccess) fillFieldMap() {
-	d.fieldMap = make(map[string]field.Expr, 5)
+	d.fieldMap = make(map[string]field.Expr, 6)
 	d.fieldMap["id"] = d.ID
 	d.fieldMap["document_id"] = d.DocumentID
 	d.fieldMap["user_id"] = d.UserID
 	d.fieldMap["access_level"] = d.AccessLevel
 	d.fieldMap["last_accessed_at"] = d.LastAccessedAt
+	d.fieldMap["shared_link_id"] = d.SharedLinkID
 }
 
 func (d documentAccess) clone(db *gorm.DB) documentAccess {
@@ -35,6 +35,7 @@ func newDocumentVersion(db *gorm.DB, opts ...gen.DOOption) documentVersion {
 	_documentVersion.UpdatedAt = field.NewTime(tableName, "updated_at")
 	_documentVersion.CreatedBy = field.NewString(tableName, "created_by")
 	_documentVersion.UpdatedBy = field.NewString(tableName, "updated_by")
+	_documentVersion.IsAuto = field.NewBool(tableName, "is_auto")
 
 	_documentVersion.fillFieldMap()
 
@@ -53,6 +54,7 @@ type documentVersion struct {
 	UpdatedAt      field.Time
 	CreatedBy      field.String
 	UpdatedBy      field.String
+	IsAuto         field.Bool
 
 	fieldMap map[string]field.Expr
 }
@@ -77,6 +79,7 @@ func (d *documentVersion) updateTableName(table string) *documentVersion {
 	d.UpdatedAt = field.NewTime(table, "updated_at")
 	d.CreatedBy = field.NewString(table, "created_by")
 	d.UpdatedBy = field.NewString(table, "updated_by")
+	d.IsAuto = field.NewBool(table, "is_auto")
 
 	d.fillFieldMap()
 
@@ -93,7 +96,7 @@ func (d *documentVersion) GetFieldByName(fieldName string) (field.OrderExpr, boo
 }
 
 func (d *documentVersion) fillFieldMap() {
-	d.fieldMap = make(map[string]field.Expr, 8)
+	d.fieldMap = make(map[string]field.Expr, 9)
 	d.fieldMap["id"] = d.ID
 	d.fieldMap["document_id"] = d.DocumentID
 	d.fieldMap["name"] = d.Name
@@ -102,6 +105,7 @@ func (d *documentVersion) fillFieldMap() {
 	d.fieldMap["updated_at"] = d.UpdatedAt
 	d.fieldMap["created_by"] = d.CreatedBy
 	d.fieldMap["updated_by"] = d.UpdatedBy
+	d.fieldMap["is_auto"] = d.IsAuto
 }
 
 func (d documentVersion) clone(db *gorm.DB) documentVersion {
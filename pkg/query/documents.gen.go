@@ -39,6 +39,7 @@ func newDocument(db *gorm.DB, opts ...gen.DOOption) document {
 	_document.ParentAddress = field.NewString(tableName, "parent_address")
 	_document.IsFolder = field.NewBool(tableName, "is_folder")
 	_document.FolderID = field.NewString(tableName, "folder_id")
+	_document.MergedAt = field.NewTime(tableName, "merged_at")
 
 	_document.fillFieldMap()
 
@@ -61,6 +62,7 @@ type document struct {
 	ParentAddress field.String
 	IsFolder      field.Bool
 	FolderID      field.String
+	MergedAt      field.Time
 
 	fieldMap map[string]field.Expr
 }
@@ -89,6 +91,7 @@ func (d *document) updateTableName(table string) *document {
 	d.ParentAddress = field.NewString(table, "parent_address")
 	d.IsFolder = field.NewBool(table, "is_folder")
 	d.FolderID = field.NewString(table, "folder_id")
+	d.MergedAt = field.NewTime(table, "merged_at")
 
 	d.fillFieldMap()
 
@@ -105,7 +108,7 @@ func (d *document) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (d *document) fillFieldMap() {
-	d.fieldMap = make(map[string]field.Expr, 12)
+	d.fieldMap = make(map[string]field.Expr, 13)
 	d.fieldMap["id"] = d.ID
 	d.fieldMap["title"] = d.Title
 	d.fieldMap["created_at"] = d.CreatedAt
@@ -118,6 +121,7 @@ func (d *document) fillFieldMap() {
 	d.fieldMap["parent_address"] = d.ParentAddress
 	d.fieldMap["is_folder"] = d.IsFolder
 	d.fieldMap["folder_id"] = d.FolderID
+	d.fieldMap["merged_at"] = d.MergedAt
 }
 
 func (d document) clone(db *gorm.DB) document {
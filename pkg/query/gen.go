@@ -17,12 +17,15 @@ import (
 
 var (
 	Q                  = new(Query)
+	APIToken           *aPIToken
 	AuthorID           *authorID
 	Comment            *comment
+	CommentTask        *commentTask
 	DefaultDocument    *defaultDocument
 	Document           *document
 	DocumentAccess     *documentAccess
 	DocumentAttachment *documentAttachment
+	DocumentTemplate   *documentTemplate
 	DocumentVersion    *documentVersion
 	OneTimeAccessToken *oneTimeAccessToken
 	PaymentHistory     *paymentHistory
@@ -34,16 +37,21 @@ var (
 	User               *user
 	UserSubscription   *userSubscription
 	WaitlistUser       *waitlistUser
+	Webhook            *webhook
+	WebhookDelivery    *webhookDelivery
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	*Q = *Use(db, opts...)
+	APIToken = &Q.APIToken
 	AuthorID = &Q.AuthorID
 	Comment = &Q.Comment
+	CommentTask = &Q.CommentTask
 	DefaultDocument = &Q.DefaultDocument
 	Document = &Q.Document
 	DocumentAccess = &Q.DocumentAccess
 	DocumentAttachment = &Q.DocumentAttachment
+	DocumentTemplate = &Q.DocumentTemplate
 	DocumentVersion = &Q.DocumentVersion
 	OneTimeAccessToken = &Q.OneTimeAccessToken
 	PaymentHistory = &Q.PaymentHistory
@@ -55,17 +63,22 @@ func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	User = &Q.User
 	UserSubscription = &Q.UserSubscription
 	WaitlistUser = &Q.WaitlistUser
+	Webhook = &Q.Webhook
+	WebhookDelivery = &Q.WebhookDelivery
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
 		db:                 db,
+		APIToken:           newAPIToken(db, opts...),
 		AuthorID:           newAuthorID(db, opts...),
 		Comment:            newComment(db, opts...),
+		CommentTask:        newCommentTask(db, opts...),
 		DefaultDocument:    newDefaultDocument(db, opts...),
 		Document:           newDocument(db, opts...),
 		DocumentAccess:     newDocumentAccess(db, opts...),
 		DocumentAttachment: newDocumentAttachment(db, opts...),
+		DocumentTemplate:   newDocumentTemplate(db, opts...),
 		DocumentVersion:    newDocumentVersion(db, opts...),
 		OneTimeAccessToken: newOneTimeAccessToken(db, opts...),
 		PaymentHistory:     newPaymentHistory(db, opts...),
@@ -77,18 +90,23 @@ func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 		User:               newUser(db, opts...),
 		UserSubscription:   newUserSubscription(db, opts...),
 		WaitlistUser:       newWaitlistUser(db, opts...),
+		Webhook:            newWebhook(db, opts...),
+		WebhookDelivery:    newWebhookDelivery(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
+	APIToken           aPIToken
 	AuthorID           authorID
 	Comment            comment
+	CommentTask        commentTask
 	DefaultDocument    defaultDocument
 	Document           document
 	DocumentAccess     documentAccess
 	DocumentAttachment documentAttachment
+	DocumentTemplate   documentTemplate
 	DocumentVersion    documentVersion
 	OneTimeAccessToken oneTimeAccessToken
 	PaymentHistory     paymentHistory
@@ -100,6 +118,8 @@ type Query struct {
 	User               user
 	UserSubscription   userSubscription
 	WaitlistUser       waitlistUser
+	Webhook            webhook
+	WebhookDelivery    webhookDelivery
 }
 
 func (q *Query) Available() bool { return q.db != nil }
@@ -107,12 +127,15 @@ func (q *Query) Available() bool { return q.db != nil }
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
 		db:                 db,
+		APIToken:           q.APIToken.clone(db),
 		AuthorID:           q.AuthorID.clone(db),
 		Comment:            q.Comment.clone(db),
+		CommentTask:        q.CommentTask.clone(db),
 		DefaultDocument:    q.DefaultDocument.clone(db),
 		Document:           q.Document.clone(db),
 		DocumentAccess:     q.DocumentAccess.clone(db),
 		DocumentAttachment: q.DocumentAttachment.clone(db),
+		DocumentTemplate:   q.DocumentTemplate.clone(db),
 		DocumentVersion:    q.DocumentVersion.clone(db),
 		OneTimeAccessToken: q.OneTimeAccessToken.clone(db),
 		PaymentHistory:     q.PaymentHistory.clone(db),
@@ -124,6 +147,8 @@ func (q *Query) clone(db *gorm.DB) *Query {
 		User:               q.User.clone(db),
 		UserSubscription:   q.UserSubscription.clone(db),
 		WaitlistUser:       q.WaitlistUser.clone(db),
+		Webhook:            q.Webhook.clone(db),
+		WebhookDelivery:    q.WebhookDelivery.clone(db),
 	}
 }
 
@@ -138,12 +163,15 @@ func (q *Query) WriteDB() *Query {
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
 		db:                 db,
+		APIToken:           q.APIToken.replaceDB(db),
 		AuthorID:           q.AuthorID.replaceDB(db),
 		Comment:            q.Comment.replaceDB(db),
+		CommentTask:        q.CommentTask.replaceDB(db),
 		DefaultDocument:    q.DefaultDocument.replaceDB(db),
 		Document:           q.Document.replaceDB(db),
 		DocumentAccess:     q.DocumentAccess.replaceDB(db),
 		DocumentAttachment: q.DocumentAttachment.replaceDB(db),
+		DocumentTemplate:   q.DocumentTemplate.replaceDB(db),
 		DocumentVersion:    q.DocumentVersion.replaceDB(db),
 		OneTimeAccessToken: q.OneTimeAccessToken.replaceDB(db),
 		PaymentHistory:     q.PaymentHistory.replaceDB(db),
@@ -155,16 +183,21 @@ func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 		User:               q.User.replaceDB(db),
 		UserSubscription:   q.UserSubscription.replaceDB(db),
 		WaitlistUser:       q.WaitlistUser.replaceDB(db),
+		Webhook:            q.Webhook.replaceDB(db),
+		WebhookDelivery:    q.WebhookDelivery.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
+	APIToken           IAPITokenDo
 	AuthorID           IAuthorIDDo
 	Comment            ICommentDo
+	CommentTask        ICommentTaskDo
 	DefaultDocument    IDefaultDocumentDo
 	Document           IDocumentDo
 	DocumentAccess     IDocumentAccessDo
 	DocumentAttachment IDocumentAttachmentDo
+	DocumentTemplate   IDocumentTemplateDo
 	DocumentVersion    IDocumentVersionDo
 	OneTimeAccessToken IOneTimeAccessTokenDo
 	PaymentHistory     IPaymentHistoryDo
@@ -176,16 +209,21 @@ type queryCtx struct {
 	User               IUserDo
 	UserSubscription   IUserSubscriptionDo
 	WaitlistUser       IWaitlistUserDo
+	Webhook            IWebhookDo
+	WebhookDelivery    IWebhookDeliveryDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
+		APIToken:           q.APIToken.WithContext(ctx),
 		AuthorID:           q.AuthorID.WithContext(ctx),
 		Comment:            q.Comment.WithContext(ctx),
+		CommentTask:        q.CommentTask.WithContext(ctx),
 		DefaultDocument:    q.DefaultDocument.WithContext(ctx),
 		Document:           q.Document.WithContext(ctx),
 		DocumentAccess:     q.DocumentAccess.WithContext(ctx),
 		DocumentAttachment: q.DocumentAttachment.WithContext(ctx),
+		DocumentTemplate:   q.DocumentTemplate.WithContext(ctx),
 		DocumentVersion:    q.DocumentVersion.WithContext(ctx),
 		OneTimeAccessToken: q.OneTimeAccessToken.WithContext(ctx),
 		PaymentHistory:     q.PaymentHistory.WithContext(ctx),
@@ -197,6 +235,8 @@ func (q *Query) WithContext(ctx context.Context) *queryCtx {
 		User:               q.User.WithContext(ctx),
 		UserSubscription:   q.UserSubscription.WithContext(ctx),
 		WaitlistUser:       q.WaitlistUser.WithContext(ctx),
+		Webhook:            q.Webhook.WithContext(ctx),
+		WebhookDelivery:    q.WebhookDelivery.WithContext(ctx),
 	}
 }
 
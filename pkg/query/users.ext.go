@@ -1,11 +1,49 @@
 package query
 
 import (
+	"fmt"
 	"math/rand"
 
+	"gorm.io/gorm"
+
+	"github.com/fivetentaylor/pointy/pkg/constants"
 	"github.com/fivetentaylor/pointy/pkg/models"
 )
 
+// usersInDomainIDsSQL selects the ids of everyone who shares a document with
+// a user, other than the user themselves and Reviso
+const usersInDomainIDsSQL = `
+    SELECT DISTINCT da2.user_id
+    FROM document_access da1
+    JOIN document_access da2 ON da1.document_id = da2.document_id AND da1.user_id != da2.user_id
+    WHERE da1.user_id = ? AND da2.user_id != ?
+`
+
+// UsersInDomainIDs is a subquery of the ids of the users in a user's domain,
+// for use in an IN clause
+func UsersInDomainIDs(db *gorm.DB, userID string) *gorm.DB {
+	return db.Raw(usersInDomainIDsSQL, userID, constants.RevisoUserID)
+}
+
+// GetUsersInDomain returns everyone who shares a document with the user,
+// and the user too if includeSelf is set
+func GetUsersInDomain(db *gorm.DB, userID string, includeSelf bool) ([]*models.User, error) {
+	tbl := db.Model(&models.User{})
+	if includeSelf {
+		tbl = tbl.Where("id IN (?) OR id = ?", UsersInDomainIDs(db, userID), userID)
+	} else {
+		tbl = tbl.Where("id IN (?)", UsersInDomainIDs(db, userID))
+	}
+
+	var users []*models.User
+	err := tbl.Find(&users).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch users: %w", err)
+	}
+
+	return users, nil
+}
+
 func GetRandomUser(db *Query) (*models.User, error) {
 	tbl := db.User
 
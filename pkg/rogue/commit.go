@@ -0,0 +1,59 @@
+package rogue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/fivetentaylor/pointy/pkg/background/wire"
+	"github.com/fivetentaylor/pointy/pkg/env"
+	rogueV3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+// CommitOp persists an op made on the server, e.g. by merging a branch, and
+// publishes it to the sessions editing the document
+func CommitOp(ctx context.Context, docID string, op rogueV3.Op) error {
+	log := env.SLog(ctx)
+	q := env.Query(ctx)
+
+	opBytes, err := json.Marshal(op)
+	if err != nil {
+		return fmt.Errorf("error marshalling op: %w", err)
+	}
+
+	docStore := NewDocStore(env.S3(ctx), q, env.Redis(ctx))
+	seq, err := docStore.AddDeltaLog(ctx, docID, string(opBytes))
+	if err != nil {
+		return fmt.Errorf("docStore.AddDeltaLog(ctx, %s): %w", docID, err)
+	}
+
+	if seq%checkpointInterval == 0 {
+		_, err = env.Background(ctx).Enqueue(ctx, &wire.SnapshotRogue{
+			DocId: docID,
+		})
+		if err != nil {
+			log.Error("error enqueueing snapshot", "error", err)
+		}
+	}
+
+	err = EnqueueIndex(ctx, docID)
+	if err != nil {
+		log.Error("error enqueueing index", "error", err)
+	}
+
+	realtime := NewRealtime(env.Redis(ctx), q, docID, "", "", "")
+	err = realtime.PublishOp(docID, opBytes)
+	if err != nil {
+		return fmt.Errorf("error publishing op: %w", err)
+	}
+
+	docTbl := q.Document
+	_, err = docTbl.
+		Where(docTbl.ID.Eq(docID)).
+		Updates(map[string]interface{}{
+			"updated_at": time.Now(),
+		})
+
+	return err
+}
@@ -0,0 +1,49 @@
+package rogue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jpoz/conveyor"
+
+	"github.com/fivetentaylor/pointy/pkg/background/wire"
+	"github.com/fivetentaylor/pointy/pkg/constants"
+	"github.com/fivetentaylor/pointy/pkg/env"
+)
+
+// IndexDelay is how long after an edit a document's search index is
+// refreshed, the edits made in the meantime are indexed together
+const IndexDelay = 30 * time.Second
+
+// EnqueueIndex schedules the document's search index to be refreshed unless
+// it already is. The pending key outlives the delay in case the job is slow
+// to start, the job clears it before reading the document so no edit is
+// missed.
+func EnqueueIndex(ctx context.Context, docID string) error {
+	key := fmt.Sprintf(constants.DocIndexPendingKey, docID)
+
+	ok, err := env.Redis(ctx).SetNX(ctx, key, "1", 2*IndexDelay).Result()
+	if err != nil {
+		return fmt.Errorf("error setting index pending key: %w", err)
+	}
+	if !ok {
+		return nil
+	}
+
+	_, err = env.Background(ctx).Enqueue(ctx, &wire.IndexDocument{
+		DocId: docID,
+	}, conveyor.Delay(IndexDelay))
+	if err != nil {
+		// let the next edit try again
+		env.Redis(ctx).Del(ctx, key)
+		return fmt.Errorf("error enqueueing index job: %w", err)
+	}
+
+	return nil
+}
+
+// ClearIndexPending lets the next edit to the document schedule an index
+func ClearIndexPending(ctx context.Context, docID string) error {
+	return env.Redis(ctx).Del(ctx, fmt.Sprintf(constants.DocIndexPendingKey, docID)).Err()
+}
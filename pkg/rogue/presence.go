@@ -0,0 +1,249 @@
+package rogue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/fivetentaylor/pointy/pkg/constants"
+	"github.com/fivetentaylor/pointy/pkg/env"
+)
+
+const (
+	PresenceEditing = "editing"
+	PresenceViewing = "viewing"
+	PresenceIdle    = "idle"
+)
+
+const (
+	// PresenceTTL is how long an entry lasts without a heartbeat, sessions
+	// heartbeat on every keepalive ping
+	PresenceTTL = 30 * time.Second
+
+	// PresenceIdleAfter is how long without any activity before a user is idle
+	PresenceIdleAfter = 2 * time.Minute
+
+	// PresenceEditingWindow is how long after their last op a user is still
+	// editing rather than viewing
+	PresenceEditingWindow = 30 * time.Second
+)
+
+// ReadViewAuthorID stands in for the author of presence heartbeats from
+// clients without a websocket session, e.g. the read view
+const ReadViewAuthorID = "read"
+
+// Presence is one connection's presence on a document, a user with the doc
+// open in several tabs has one per tab
+type Presence struct {
+	UserID   string `json:"userID"`
+	AuthorID string `json:"authorID"`
+	State    string `json:"state"`
+
+	// BlockID is the start of the block the user's selection is in
+	BlockID string `json:"blockID,omitempty"`
+
+	LastActiveAt time.Time `json:"lastActiveAt"`
+	LastSeenAt   time.Time `json:"lastSeenAt"`
+}
+
+// PresenceState works out the state for a connection from its activity
+func PresenceState(now, lastActive, lastEdit time.Time) string {
+	if now.Sub(lastActive) > PresenceIdleAfter {
+		return PresenceIdle
+	}
+
+	if now.Sub(lastEdit) <= PresenceEditingWindow {
+		return PresenceEditing
+	}
+
+	return PresenceViewing
+}
+
+// SetPresence records a connection's presence, the whole key expires when
+// nobody on the doc has sent a heartbeat for PresenceTTL
+func SetPresence(ctx context.Context, client redis.UniversalClient, docID string, presence Presence) error {
+	bts, err := json.Marshal(presence)
+	if err != nil {
+		return fmt.Errorf("error marshalling presence: %w", err)
+	}
+
+	key := fmt.Sprintf(constants.DocPresenceKey, docID)
+	_, err = client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, presenceField(presence.UserID, presence.AuthorID), bts)
+		pipe.Expire(ctx, key, PresenceTTL)
+		return nil
+	})
+
+	return err
+}
+
+func RemovePresence(ctx context.Context, client redis.UniversalClient, docID, userID, authorID string) error {
+	return client.HDel(ctx, fmt.Sprintf(constants.DocPresenceKey, docID), presenceField(userID, authorID)).Err()
+}
+
+func PublishPresenceChanged(ctx context.Context, client redis.UniversalClient, docID string) error {
+	return client.Publish(ctx, fmt.Sprintf(constants.DocPresenceChanFormat, docID), docID).Err()
+}
+
+// CurrentPresence returns the presence of each user on the document. Entries
+// that missed their heartbeats are removed, and users with several
+// connections get the most active one's state.
+func CurrentPresence(ctx context.Context, client redis.UniversalClient, docID string) ([]Presence, error) {
+	log := env.SLog(ctx)
+	key := fmt.Sprintf(constants.DocPresenceKey, docID)
+
+	entries, err := client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error getting presence: %w", err)
+	}
+
+	now := time.Now()
+	users := map[string]Presence{}
+	stale := []string{}
+	for field, value := range entries {
+		var presence Presence
+		err := json.Unmarshal([]byte(value), &presence)
+		if err != nil || now.Sub(presence.LastSeenAt) > PresenceTTL {
+			stale = append(stale, field)
+			continue
+		}
+
+		current, ok := users[presence.UserID]
+		if !ok || morePresent(presence, current) {
+			users[presence.UserID] = presence
+		}
+	}
+
+	if len(stale) > 0 {
+		err = client.HDel(ctx, key, stale...).Err()
+		if err != nil {
+			log.Error("error removing stale presence", "docID", docID, "error", err)
+		}
+	}
+
+	presences := make([]Presence, 0, len(users))
+	for _, presence := range users {
+		presences = append(presences, presence)
+	}
+
+	slices.SortFunc(presences, func(a, b Presence) int {
+		return strings.Compare(a.UserID, b.UserID)
+	})
+
+	return presences, nil
+}
+
+// ListenForPresence sends the document's presence on ch to start with and
+// again whenever it changes, including when entries expire
+func ListenForPresence(ctx context.Context, client redis.UniversalClient, docID string, ch chan []Presence) {
+	log := env.SLog(ctx)
+
+	pubsub := client.Subscribe(ctx, fmt.Sprintf(constants.DocPresenceChanFormat, docID))
+	incoming := pubsub.Channel()
+
+	// nothing is published when an entry expires so check for that too
+	ticker := time.NewTicker(PresenceTTL / 2)
+
+	defer func() {
+		log.Info("closing presence listener", "docID", docID)
+		ticker.Stop()
+		pubsub.Unsubscribe(ctx)
+		pubsub.Close()
+		close(ch)
+	}()
+
+	var last string
+	for {
+		presences, err := CurrentPresence(ctx, client, docID)
+		if err != nil {
+			log.Error("error getting presence", "docID", docID, "error", err)
+			return
+		}
+
+		if summary := presenceSummary(presences); summary != last {
+			last = summary
+
+			select {
+			case ch <- presences:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-incoming:
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ReadViewHeartbeat records presence for a client without a websocket
+// session, they're viewing unless they say they're idle
+func ReadViewHeartbeat(ctx context.Context, client redis.UniversalClient, docID, userID string, idle bool) error {
+	key := fmt.Sprintf(constants.DocPresenceKey, docID)
+	now := time.Now()
+
+	presence := Presence{
+		UserID:       userID,
+		AuthorID:     ReadViewAuthorID,
+		State:        PresenceViewing,
+		LastActiveAt: now,
+		LastSeenAt:   now,
+	}
+
+	if idle {
+		presence.State = PresenceIdle
+
+		// keep when they were last active if they were already here
+		bts, err := client.HGet(ctx, key, presenceField(userID, ReadViewAuthorID)).Bytes()
+		if err == nil {
+			var previous Presence
+			if json.Unmarshal(bts, &previous) == nil {
+				presence.LastActiveAt = previous.LastActiveAt
+			}
+		}
+	}
+
+	err := SetPresence(ctx, client, docID, presence)
+	if err != nil {
+		return err
+	}
+
+	return PublishPresenceChanged(ctx, client, docID)
+}
+
+func presenceField(userID, authorID string) string {
+	return fmt.Sprintf("%s:%s", userID, authorID)
+}
+
+var presenceRank = map[string]int{
+	PresenceEditing: 2,
+	PresenceViewing: 1,
+	PresenceIdle:    0,
+}
+
+func morePresent(a, b Presence) bool {
+	if presenceRank[a.State] != presenceRank[b.State] {
+		return presenceRank[a.State] > presenceRank[b.State]
+	}
+
+	return a.LastActiveAt.After(b.LastActiveAt)
+}
+
+// presenceSummary is what subscribers care about changing, heartbeats alone
+// don't change it
+func presenceSummary(presences []Presence) string {
+	parts := make([]string, len(presences))
+	for i, presence := range presences {
+		parts[i] = fmt.Sprintf("%s:%s:%s", presence.UserID, presence.State, presence.BlockID)
+	}
+
+	return strings.Join(parts, ",")
+}
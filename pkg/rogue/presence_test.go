@@ -0,0 +1,66 @@
+package rogue_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/rogue"
+	"github.com/fivetentaylor/pointy/pkg/testutils"
+)
+
+func TestPresenceState(t *testing.T) {
+	now := time.Now()
+
+	assert.Equal(t, rogue.PresenceEditing, rogue.PresenceState(now, now, now.Add(-10*time.Second)))
+	assert.Equal(t, rogue.PresenceViewing, rogue.PresenceState(now, now, now.Add(-time.Minute)))
+	assert.Equal(t, rogue.PresenceViewing, rogue.PresenceState(now, now, time.Time{}))
+	assert.Equal(t, rogue.PresenceIdle, rogue.PresenceState(now, now.Add(-3*time.Minute), now.Add(-3*time.Minute)))
+}
+
+func TestCurrentPresence(t *testing.T) {
+	testutils.EnsureStorage()
+	ctx := testutils.TestContext()
+	rds := env.Redis(ctx)
+
+	docID := uuid.NewString()
+	alice := uuid.NewString()
+	bob := uuid.NewString()
+	now := time.Now()
+
+	// alice has the doc open in two tabs, she's editing in one of them
+	for _, presence := range []rogue.Presence{
+		{UserID: alice, AuthorID: "a1", State: rogue.PresenceIdle, LastActiveAt: now.Add(-5 * time.Minute), LastSeenAt: now},
+		{UserID: alice, AuthorID: "a2", State: rogue.PresenceEditing, BlockID: "a2_10", LastActiveAt: now, LastSeenAt: now},
+		{UserID: bob, AuthorID: "b1", State: rogue.PresenceViewing, LastActiveAt: now, LastSeenAt: now.Add(-time.Minute)},
+	} {
+		require.NoError(t, rogue.SetPresence(ctx, rds, docID, presence))
+	}
+
+	presences, err := rogue.CurrentPresence(ctx, rds, docID)
+	require.NoError(t, err)
+	require.Len(t, presences, 1)
+	assert.Equal(t, alice, presences[0].UserID)
+	assert.Equal(t, rogue.PresenceEditing, presences[0].State)
+	assert.Equal(t, "a2_10", presences[0].BlockID)
+
+	// bob's entry missed its heartbeats and was removed
+	require.NoError(t, rogue.ReadViewHeartbeat(ctx, rds, docID, bob, false))
+
+	presences, err = rogue.CurrentPresence(ctx, rds, docID)
+	require.NoError(t, err)
+	require.Len(t, presences, 2)
+
+	require.NoError(t, rogue.RemovePresence(ctx, rds, docID, alice, "a1"))
+	require.NoError(t, rogue.RemovePresence(ctx, rds, docID, alice, "a2"))
+
+	presences, err = rogue.CurrentPresence(ctx, rds, docID)
+	require.NoError(t, err)
+	require.Len(t, presences, 1)
+	assert.Equal(t, bob, presences[0].UserID)
+	assert.Equal(t, rogue.PresenceViewing, presences[0].State)
+}
@@ -3,6 +3,7 @@ package rogue
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -13,6 +14,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/jpoz/conveyor"
+	"gorm.io/gorm"
 
 	"github.com/fivetentaylor/pointy/pkg/background/wire"
 	"github.com/fivetentaylor/pointy/pkg/constants"
@@ -22,15 +24,22 @@ import (
 	"github.com/fivetentaylor/pointy/pkg/service/document"
 	"github.com/fivetentaylor/pointy/pkg/service/timeline"
 	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
+	"github.com/fivetentaylor/pointy/pkg/utils"
 	rogueV3 "github.com/fivetentaylor/pointy/rogue/v3"
 )
 
-const loadedEvent = "{\"type\":\"event\", \"event\":\"loaded\"}"
 const pingEvent = "{\"type\":\"event\", \"event\":\"ping\"}"
 
+const keepaliveInterval = 10 * time.Second
+
 type AuthEvent struct {
-	Type     string `json:"type"`
-	AuthorID string `json:"authorID"`
+	Type        string `json:"type"`
+	AuthorID    string `json:"authorID"`
+	AccessLevel string `json:"accessLevel,omitempty"`
+
+	// CatchUp is set when the server is sending the ops the client is
+	// missing rather than a snapshot, so the client should keep its doc
+	CatchUp bool `json:"catchUp,omitempty"`
 }
 
 type Event struct {
@@ -39,6 +48,24 @@ type Event struct {
 	Data  map[string]interface{} `json:"data"`
 }
 
+const (
+	OpErrorInvalid        = "invalidOp"
+	OpErrorAuthorMismatch = "authorMismatch"
+	OpErrorParentNotFound = "parentNotFound"
+	OpErrorInvalidFormat  = "invalidFormat"
+	OpErrorMergeFailed    = "mergeFailed"
+	OpErrorReadOnly       = "readOnly"
+)
+
+// OpErrorEvent is sent back to the client that sent a rejected op, it is
+// never published to the other sessions on the document.
+type OpErrorEvent struct {
+	Type    string      `json:"type"`
+	Code    string      `json:"code"`
+	OpID    *rogueV3.ID `json:"opID,omitempty"`
+	Message string      `json:"message"`
+}
+
 type Session struct {
 	ID       string
 	docID    string
@@ -55,6 +82,20 @@ type Session struct {
 	lastPong time.Time
 	authorID string
 
+	// accessLevel is the user's document_access level, it's refreshed live
+	// when sharing changes are published for the doc
+	accessLevel string
+
+	// readOnly is set when the user connected with an api token that can't
+	// write docs, it holds whatever their access level is
+	readOnly bool
+
+	// presence is what was last recorded for this connection, lastEdit is
+	// when it last sent an op
+	presence      Presence
+	lastEdit      time.Time
+	presenceMutex sync.Mutex
+
 	pongMutex sync.Mutex
 	connMutex sync.Mutex
 	docMutex  sync.Mutex
@@ -69,11 +110,16 @@ func NewSession(
 ) (*Session, error) {
 	q := env.Query(ctx)
 
-	doc, err := query.GetEditableDocumentForUser(q, docID, user.ID)
+	doc, err := query.GetReadableDocumentForUser(q, docID, user.ID)
 	if err != nil {
 		return nil, err
 	}
 
+	accessLevel, err := query.AccessLevelForDocument(q, docID, user.ID)
+	if err != nil {
+		return nil, &query.AccessDeniedError{Message: "access denied: insufficient permissions"}
+	}
+
 	sessionId := uuid.NewString()
 
 	session := &Session{
@@ -86,6 +132,13 @@ func NewSession(
 		cancel:   nil,
 		query:    q,
 		lastPong: time.Now(),
+
+		accessLevel: accessLevel,
+	}
+
+	claims, err := env.UserClaim(ctx)
+	if err == nil && !claims.HasScope(constants.APITokenScopeWriteDocs) {
+		session.readOnly = true
 	}
 
 	session.realtime = NewRealtime(
@@ -115,6 +168,31 @@ type Subscribe struct {
 	Type     string `json:"type"`
 	DocID    string `json:"docID"`
 	AuthorID string `json:"authorID,omitempty"`
+
+	// A reconnecting client can send what it already has so that only the
+	// missing ops are sent instead of a full snapshot
+	ContentAddress string `json:"contentAddress,omitempty"`
+	LastSeq        *int64 `json:"lastSeq,omitempty"`
+}
+
+// OfflineBatch is a MultiOp of edits made while the client was offline,
+// ContentAddress is the address of the client's doc the edits were made on
+type OfflineBatch struct {
+	Type           string `json:"type"`
+	Op             string `json:"op"`
+	ContentAddress string `json:"contentAddress"`
+}
+
+// OfflineBatchSpan is a span of the doc an offline batch changed, Html is the
+// span after the merge and DiffHtml shows just the batch's changes
+type OfflineBatchSpan struct {
+	FirstBlockID rogueV3.ID `json:"firstBlockID"`
+	LastBlockID  rogueV3.ID `json:"lastBlockID"`
+	StartID      rogueV3.ID `json:"startID"`
+	EndID        rogueV3.ID `json:"endID"`
+	Html         string     `json:"html"`
+	DiffHtml     string     `json:"diffHtml"`
+	Concurrent   bool       `json:"concurrent"`
 }
 
 // {"id":["cr8vxds2",153],"char":"!","parentId":["cr8vxds2",152],"side":1}
@@ -183,24 +261,140 @@ func (s *Session) HighlightColor() string {
 	return s.user.HighlightColor()
 }
 
+// CanEdit reports whether the session's user is allowed to send ops, readers
+// and commenters still receive the snapshot, live ops and cursors
+func (s *Session) CanEdit() bool {
+	return !s.readOnly && utils.Contains(constants.AccessLevelsWithEdit, s.accessLevel)
+}
+
 func (s *Session) DeactivateDocLogger() {
 	s.docLog.Deactivate()
 }
 
 func (s *Session) Keepalive() {
-	ticker := time.NewTicker(10 * time.Second)
+	ticker := time.NewTicker(keepaliveInterval)
 	for {
 		select {
 		case <-ticker.C:
-			s.log.Info("ping. last pong", "duration", time.Now().Sub(s.lastPong).Seconds())
+			s.pongMutex.Lock()
+			lastPong := s.lastPong
+			s.pongMutex.Unlock()
+
+			s.log.Info("ping. last pong", "duration", time.Now().Sub(lastPong).Seconds())
 			err := s.writeMessage([]byte(pingEvent))
 			if err != nil {
 				return
 			}
+
+			// the presence heartbeat rides on the ping, a client that stopped
+			// answering is left to expire
+			if time.Since(lastPong) < 2*keepaliveInterval {
+				s.recordPresence(context.Background(), nil)
+			}
+		}
+	}
+}
+
+// touchPresence records activity on the connection, blockID is the block the
+// user's selection moved to if it moved
+func (s *Session) touchPresence(ctx context.Context, edited bool, blockID *string) {
+	s.presenceMutex.Lock()
+	now := time.Now()
+	s.presence.LastActiveAt = now
+	if edited {
+		s.lastEdit = now
+	}
+	s.presenceMutex.Unlock()
+
+	s.recordPresence(ctx, blockID)
+}
+
+// recordPresence writes the connection's presence and lets subscribers know
+// when its state or block changed
+func (s *Session) recordPresence(ctx context.Context, blockID *string) {
+	s.presenceMutex.Lock()
+	defer s.presenceMutex.Unlock()
+
+	// presence starts once the client has subscribed and has an author
+	if s.authorID == "" {
+		return
+	}
+
+	now := time.Now()
+	previous := s.presence
+
+	s.presence.UserID = s.UserID()
+	s.presence.AuthorID = s.authorID
+	s.presence.State = PresenceState(now, s.presence.LastActiveAt, s.lastEdit)
+	s.presence.LastSeenAt = now
+	if blockID != nil {
+		s.presence.BlockID = *blockID
+	}
+
+	rds := s.store.Redis
+	err := SetPresence(ctx, rds, s.docID, s.presence)
+	if err != nil {
+		s.log.Error("error setting presence", "error", err)
+		return
+	}
+
+	if previous.State != s.presence.State || previous.BlockID != s.presence.BlockID {
+		err = PublishPresenceChanged(ctx, rds, s.docID)
+		if err != nil {
+			s.log.Error("error publishing presence", "error", err)
 		}
 	}
 }
 
+// removePresence clears the connection's presence when it closes
+func (s *Session) removePresence(ctx context.Context) {
+	s.presenceMutex.Lock()
+	defer s.presenceMutex.Unlock()
+
+	if s.presence.AuthorID == "" {
+		return
+	}
+
+	rds := s.store.Redis
+	err := RemovePresence(ctx, rds, s.docID, s.presence.UserID, s.presence.AuthorID)
+	if err != nil {
+		s.log.Error("error removing presence", "error", err)
+		return
+	}
+
+	err = PublishPresenceChanged(ctx, rds, s.docID)
+	if err != nil {
+		s.log.Error("error publishing presence", "error", err)
+	}
+
+	s.presence = Presence{}
+}
+
+// selectionBlock returns the start of the block a cursor update's selection
+// starts in, or nil if it can't be found in the session's doc
+func (s *Session) selectionBlock(msg []byte) *string {
+	cursor := DocCursorOperation{}
+	err := json.Unmarshal(msg, &cursor)
+	if err != nil || len(cursor.Range) == 0 {
+		return nil
+	}
+
+	s.docMutex.Lock()
+	defer s.docMutex.Unlock()
+
+	if s.doc == nil {
+		return nil
+	}
+
+	startID, _, err := s.doc.GetBlockAt(cursor.Range[0], nil)
+	if err != nil {
+		return nil
+	}
+
+	blockID := startID.String()
+	return &blockID
+}
+
 func (s *Session) handleSubscribe(ctx context.Context, msg []byte) error {
 	s.docMutex.Lock()
 	defer s.docMutex.Unlock()
@@ -226,6 +420,9 @@ func (s *Session) handleSubscribe(ctx context.Context, msg []byte) error {
 		}
 	}
 
+	// the client's doc is only reusable if it keeps its authorID
+	canCatchUp := sub.AuthorID != ""
+
 	if sub.AuthorID == "" {
 		sub.AuthorID, err = document.NewAuthorID(ctx, sub.DocID, s.user.ID)
 		if err != nil {
@@ -235,7 +432,7 @@ func (s *Session) handleSubscribe(ctx context.Context, msg []byte) error {
 
 	start := time.Now()
 
-	_, doc, err := s.store.GetCurrentDoc(ctx, sub.DocID)
+	seq, doc, err := s.store.GetCurrentDoc(ctx, sub.DocID)
 	if err != nil {
 		s.log.Error("error getting doc", "error", err)
 		return err
@@ -244,10 +441,17 @@ func (s *Session) handleSubscribe(ctx context.Context, msg []byte) error {
 	s.doc = doc
 	s.authorID = sub.AuthorID
 
+	var catchUpOps []rogueV3.Op
+	if canCatchUp {
+		catchUpOps, canCatchUp = s.catchUpOps(ctx, sub, seq)
+	}
+
 	// Give the client a unique author id
 	authBytes, err := json.Marshal(AuthEvent{
-		Type:     "auth",
-		AuthorID: sub.AuthorID,
+		Type:        "auth",
+		AuthorID:    sub.AuthorID,
+		AccessLevel: s.accessLevel,
+		CatchUp:     canCatchUp,
 	})
 	if err != nil {
 		s.log.Error("error marshalling auth event", "error", err)
@@ -259,32 +463,60 @@ func (s *Session) handleSubscribe(ctx context.Context, msg []byte) error {
 		return err
 	}
 
-	// Send the snapshot
-	snapshot, err := doc.NewSnapshotOp()
-	if err != nil {
-		s.log.Error("error creating snapshot operation", "error", err)
-		return err
-	}
-	opBytes, err := json.Marshal(snapshot)
-	if err != nil {
-		s.log.Error("error marshalling snapshot", "error", err)
-		return err
-	}
-	err = s.writeMessage(opBytes)
-	if err != nil {
-		s.log.Error("error marshalling snapshot", "error", err)
-		return err
+	if canCatchUp {
+		// Send only the ops the client is missing
+		for _, op := range catchUpOps {
+			opBytes, err := json.Marshal(op)
+			if err != nil {
+				s.log.Error("error marshalling catch up op", "error", err)
+				return err
+			}
+			err = s.writeMessage(opBytes)
+			if err != nil {
+				s.log.Error("error writing catch up op", "error", err)
+				return err
+			}
+		}
+
+		s.log.Info("caught up client", "ops", len(catchUpOps), "seq", seq)
+	} else {
+		// Send the snapshot
+		snapshot, err := doc.NewSnapshotOp()
+		if err != nil {
+			s.log.Error("error creating snapshot operation", "error", err)
+			return err
+		}
+		opBytes, err := json.Marshal(snapshot)
+		if err != nil {
+			s.log.Error("error marshalling snapshot", "error", err)
+			return err
+		}
+		err = s.writeMessage(opBytes)
+		if err != nil {
+			s.log.Error("error marshalling snapshot", "error", err)
+			return err
+		}
 	}
 
-	// Send the loaded event
-	err = s.writeMessage([]byte(loadedEvent))
+	// Send the loaded event, the seq lets the client catch up from here
+	// if it reconnects
+	err = s.writeEvent("loaded", map[string]interface{}{"seq": seq})
 	if err != nil {
 		s.log.Error("error writing loaded event", "error", err)
 		return err
 	}
 
 	// Connect to the realtime channel
-	s.cancel = s.realtime.Subscribe(ctx, sub.AuthorID, s.onRealtimeMessage)
+	rtCancel := s.realtime.Subscribe(ctx, sub.AuthorID, s.onRealtimeMessage)
+
+	// Listen for sharing changes to this doc
+	accessCtx, accessCancel := context.WithCancel(ctx)
+	go s.listenForAccessChanges(accessCtx)
+
+	s.cancel = func() {
+		rtCancel()
+		accessCancel()
+	}
 
 	// Send the active cursors
 	cursors, err := s.realtime.CurrentRealtimeOperations(ctx)
@@ -305,16 +537,65 @@ func (s *Session) handleSubscribe(ctx context.Context, msg []byte) error {
 		}
 	}
 
+	s.touchPresence(ctx, false, nil)
+
 	elapsed := time.Since(start)
 	s.log.Info("subscribed for docID", "docID", sub.DocID, "elapsed", elapsed)
 
 	return nil
 }
 
+// catchUpOps returns the ops a reconnecting client is missing. It prefers the
+// delta log after the client's last seen seq and falls back to diffing the doc
+// against the client's content address. ok is false when neither can be used
+// and the client needs a full snapshot.
+func (s *Session) catchUpOps(ctx context.Context, sub Subscribe, seq int64) (ops []rogueV3.Op, ok bool) {
+	// a lastSeq ahead of the doc isn't from this delta log (e.g. redis was reset)
+	if sub.LastSeq != nil && *sub.LastSeq <= seq {
+		ops, _, err := s.store.GetOpsSince(ctx, s.docID, *sub.LastSeq)
+		if err == nil {
+			return ops, true
+		}
+
+		if !errors.As(err, &ErrorDeltaLogCompacted{}) {
+			s.log.Error("error getting ops since", "lastSeq", *sub.LastSeq, "error", err)
+		}
+	}
+
+	if sub.ContentAddress != "" {
+		address, err := rogueV3.ParseContentAddress(sub.ContentAddress)
+		if err != nil {
+			s.log.Warn("invalid content address", "contentAddress", sub.ContentAddress, "error", err)
+			return nil, false
+		}
+
+		ops, err := s.doc.OpsSince(address)
+		if err != nil {
+			s.log.Error("error getting ops since address", "error", err)
+			return nil, false
+		}
+
+		return ops, true
+	}
+
+	return nil, false
+}
+
 func (s *Session) onRealtimeMessage(msg []byte) {
 	s.docMutex.Lock()
 	defer s.docMutex.Unlock()
 
+	// ops are serialized as arrays, everything else (cursors, events) is an object
+	if s.doc != nil && len(msg) > 0 && msg[0] == '[' {
+		var rmsg rogueV3.Message
+		err := json.Unmarshal(msg, &rmsg)
+		if err != nil {
+			s.log.Error("error unmarshalling realtime op", "error", err)
+		} else if _, err = s.doc.MergeOp(rmsg.Op); err != nil {
+			s.log.Error("error merging realtime op", "error", err)
+		}
+	}
+
 	err := s.writeMessage(msg)
 	if err != nil {
 		s.log.Error("failed to write message", "err", err)
@@ -333,9 +614,62 @@ func (s *Session) handleOp(ctx context.Context, msg []byte) error {
 		return fmt.Errorf("error unmarshalling op: %s", err)
 	}
 
-	seq, err := s.store.AddDeltaLog(ctx, s.docID, op.Op)
+	if s.doc == nil {
+		return fmt.Errorf("op received before subscribe for docID: %s", s.docID)
+	}
+
+	var rmsg rogueV3.Message
+	err = json.Unmarshal([]byte(op.Op), &rmsg)
+	if err != nil || rmsg.Op == nil {
+		return s.rejectOp(nil, OpErrorInvalid, fmt.Sprintf("invalid op: %s", op.Op))
+	}
+
+	opID := rmsg.Op.GetID()
+	if !s.CanEdit() {
+		return s.rejectOp(&opID, OpErrorReadOnly, fmt.Sprintf("access level %q can not edit this document", s.accessLevel))
+	}
+
+	code, err := s.validateOp(rmsg.Op)
+	if err != nil {
+		return s.rejectOp(&opID, code, err.Error())
+	}
+
+	_, err = s.doc.MergeOp(rmsg.Op)
+	if err != nil {
+		s.log.Error("error merging op", "error", err, "op", op.Op)
+
+		rerr := s.reloadDoc(ctx)
+		if rerr != nil {
+			return rerr
+		}
+
+		return s.rejectOp(&opID, OpErrorMergeFailed, err.Error())
+	}
+
+	log.Debugf("[%s] merged op: %s", s.docID, string(msg))
+
+	return s.commitOp(ctx, op.Op)
+}
+
+// reloadDoc replaces the session's doc with the one in storage. A multi op may
+// have been partially applied before failing to merge, reloading makes sure
+// the rejected op doesn't linger in memory.
+func (s *Session) reloadDoc(ctx context.Context) error {
+	_, doc, err := s.store.GetCurrentDoc(ctx, s.docID)
+	if err != nil {
+		return fmt.Errorf("s.store.GetCurrentDoc(ctx, %s): %w", s.docID, err)
+	}
+	s.doc = doc
+
+	return nil
+}
+
+// commitOp persists an op that's been merged into the session's doc and
+// publishes it to the other sessions on the document
+func (s *Session) commitOp(ctx context.Context, opStr string) error {
+	seq, err := s.store.AddDeltaLog(ctx, s.docID, opStr)
 	if err != nil {
-		return fmt.Errorf("s.store.AddDeltaLog(ctx, %s, %s): %w", s.docID, op.Op, err)
+		return fmt.Errorf("s.store.AddDeltaLog(ctx, %s, %s): %w", s.docID, opStr, err)
 	}
 
 	if seq%1000 == 0 {
@@ -347,9 +681,12 @@ func (s *Session) handleOp(ctx context.Context, msg []byte) error {
 		}
 	}
 
-	log.Debugf("[%s] merged op: %s", s.docID, string(msg))
+	err = EnqueueIndex(ctx, s.docID)
+	if err != nil {
+		log.Errorf("error enqueueing index: %s", err)
+	}
 
-	err = s.realtime.PublishOp(s.docID, []byte(op.Op))
+	err = s.realtime.PublishOp(s.docID, []byte(opStr))
 	if err != nil {
 		log.Errorf("error publishing op: %s", err)
 		return fmt.Errorf("error publishing op: %s", err)
@@ -371,7 +708,285 @@ func (s *Session) handleOp(ctx context.Context, msg []byte) error {
 	return nil
 }
 
+// handleOfflineBatch merges a batch of offline edits as a single op, it's
+// either merged and published whole or rejected. The client gets back an
+// offlineBatchMerged event describing where its edits landed.
+func (s *Session) handleOfflineBatch(ctx context.Context, msg []byte) error {
+	s.docMutex.Lock()
+	defer s.docMutex.Unlock()
+
+	var batch OfflineBatch
+	err := json.Unmarshal(msg, &batch)
+	if err != nil {
+		return fmt.Errorf("error unmarshalling offline batch: %s", err)
+	}
+
+	if s.doc == nil {
+		return fmt.Errorf("offline batch received before subscribe for docID: %s", s.docID)
+	}
+
+	var rmsg rogueV3.Message
+	err = json.Unmarshal([]byte(batch.Op), &rmsg)
+	if err != nil {
+		return s.rejectOp(nil, OpErrorInvalid, fmt.Sprintf("invalid op: %s", batch.Op))
+	}
+
+	mop, ok := rmsg.Op.(rogueV3.MultiOp)
+	if !ok || len(mop.Mops) == 0 {
+		return s.rejectOp(nil, OpErrorInvalid, "offline batch must be a non-empty multi op")
+	}
+
+	opID := mop.GetID()
+	if !s.CanEdit() {
+		return s.rejectOp(&opID, OpErrorReadOnly, fmt.Sprintf("access level %q can not edit this document", s.accessLevel))
+	}
+
+	baseAddress, err := rogueV3.ParseContentAddress(batch.ContentAddress)
+	if err != nil {
+		return s.rejectOp(&opID, OpErrorInvalid, fmt.Sprintf("invalid content address: %s", batch.ContentAddress))
+	}
+
+	code, err := s.validateOp(mop)
+	if err != nil {
+		return s.rejectOp(&opID, code, err.Error())
+	}
+
+	_, err = s.doc.MergeOp(mop)
+	if err != nil {
+		s.log.Error("error merging offline batch", "error", err, "opID", opID)
+
+		rerr := s.reloadDoc(ctx)
+		if rerr != nil {
+			return rerr
+		}
+
+		return s.rejectOp(&opID, OpErrorMergeFailed, err.Error())
+	}
+
+	err = s.commitOp(ctx, batch.Op)
+	if err != nil {
+		return err
+	}
+
+	// the batch is committed at this point, a failed summary shouldn't fail it
+	spans := []OfflineBatchSpan{}
+	mergeSpans, err := s.doc.SummarizeMerge(mop, baseAddress)
+	if err != nil {
+		s.log.Error("error summarizing offline batch", "error", err, "opID", opID)
+	}
+
+	concurrentSpans := 0
+	for _, span := range mergeSpans {
+		spans = append(spans, OfflineBatchSpan{
+			FirstBlockID: span.FirstBlockID,
+			LastBlockID:  span.LastBlockID,
+			StartID:      span.ToStartID,
+			EndID:        span.ToEndID,
+			Html:         span.Html,
+			DiffHtml:     span.DiffHtml,
+			Concurrent:   span.Concurrent,
+		})
+
+		if span.Concurrent {
+			concurrentSpans++
+		}
+	}
+
+	before, after, err := s.addressesAroundOp(mop)
+	if err != nil {
+		s.log.Error("error getting offline batch addresses", "error", err, "opID", opID)
+	}
+
+	timeline.CreateTimelineEvent(ctx, &dynamo.TimelineEvent{
+		UserID:   s.UserID(),
+		AuthorID: s.authorID,
+		DocID:    s.docID,
+		Event: &models.TimelineEventPayload{
+			Payload: &models.TimelineEventPayload_OfflineEdits{
+				OfflineEdits: &models.TimelineOfflineEdits{
+					ContentAddressBase:   batch.ContentAddress,
+					ContentAddressBefore: before,
+					ContentAddressAfter:  after,
+					ConcurrentSpans:      int32(concurrentSpans),
+				},
+			},
+		},
+	})
+
+	return s.writeEvent("offlineBatchMerged", map[string]interface{}{
+		"opID":                 opID,
+		"contentAddressBefore": before,
+		"contentAddressAfter":  after,
+		"spans":                spans,
+	})
+}
+
+// addressesAroundOp returns the serialized content addresses of the doc
+// right before and after op was merged
+func (s *Session) addressesAroundOp(op rogueV3.Op) (before, after string, err error) {
+	beforeAddress, err := s.doc.AddressBeforeOp(op)
+	if err != nil {
+		return "", "", err
+	}
+
+	afterAddress, err := s.doc.AddressAfterOp(op)
+	if err != nil {
+		return "", "", err
+	}
+
+	beforeBytes, err := json.Marshal(beforeAddress)
+	if err != nil {
+		return "", "", err
+	}
+
+	afterBytes, err := json.Marshal(afterAddress)
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(beforeBytes), string(afterBytes), nil
+}
+
+// validateOp checks an incoming op against the session's doc, returning the
+// OpError code to report to the client when the op is rejected
+func (s *Session) validateOp(op rogueV3.Op) (string, error) {
+	ops := []rogueV3.Op{op}
+	if mop, ok := op.(rogueV3.MultiOp); ok {
+		ops = mop.Mops
+	}
+
+	for _, o := range ops {
+		if o.GetID().Author != s.authorID {
+			return OpErrorAuthorMismatch, fmt.Errorf("op author %q does not match session author %q", o.GetID().Author, s.authorID)
+		}
+	}
+
+	err := s.doc.ValidateOp(op)
+	if err == nil {
+		return "", nil
+	}
+
+	if errors.As(err, &rogueV3.ErrorParentNotFound{}) {
+		return OpErrorParentNotFound, err
+	}
+
+	if errors.As(err, &rogueV3.ErrorInvalidFormat{}) {
+		return OpErrorInvalidFormat, err
+	}
+
+	return OpErrorInvalid, err
+}
+
+// rejectOp tells the client its op was not accepted. The connection is kept
+// open so the client can resync.
+func (s *Session) rejectOp(opID *rogueV3.ID, code, message string) error {
+	s.log.Warn("rejecting op", "code", code, "opID", opID, "message", message)
+
+	bts, err := json.Marshal(OpErrorEvent{
+		Type:    "opError",
+		Code:    code,
+		OpID:    opID,
+		Message: message,
+	})
+	if err != nil {
+		return fmt.Errorf("error marshalling op error: %w", err)
+	}
+
+	return s.writeMessage(bts)
+}
+
+// listenForAccessChanges re-checks the user's access level whenever sharing
+// changes are published for the doc
+func (s *Session) listenForAccessChanges(ctx context.Context) {
+	pubsub := s.store.Redis.Subscribe(ctx, fmt.Sprintf(constants.DocAccessChanFormat, s.docID))
+	incoming := pubsub.Channel()
+
+	defer func() {
+		pubsub.Unsubscribe(context.Background())
+		pubsub.Close()
+	}()
+
+	for {
+		select {
+		case msg, ok := <-incoming:
+			if !ok {
+				return
+			}
+
+			if msg.Payload != s.UserID() {
+				continue
+			}
+
+			err := s.refreshAccessLevel()
+			if err != nil {
+				s.log.Error("error refreshing access level", "error", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Session) refreshAccessLevel() error {
+	s.docMutex.Lock()
+	defer s.docMutex.Unlock()
+
+	accessLevel, err := query.AccessLevelForDocument(s.query, s.docID, s.UserID())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.log.Info("access revoked, closing session", "userID", s.UserID())
+		s.accessLevel = constants.AccessLevelNone
+
+		err = s.writeEvent("accessRevoked", nil)
+		if err != nil {
+			s.log.Error("error writing access revoked event", "error", err)
+		}
+
+		s.connMutex.Lock()
+		defer s.connMutex.Unlock()
+		err = s.conn.WriteControl(
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "access revoked"),
+			time.Now().Add(time.Second),
+		)
+		if err != nil {
+			s.log.Error("error writing close message", "error", err)
+		}
+
+		// closing the conn ends the read loop which closes the session
+		return s.conn.Close()
+	}
+	if err != nil {
+		return err
+	}
+
+	if accessLevel == s.accessLevel {
+		return nil
+	}
+
+	s.log.Info("access level changed", "userID", s.UserID(), "from", s.accessLevel, "to", accessLevel)
+	s.accessLevel = accessLevel
+
+	return s.writeEvent("accessChanged", map[string]interface{}{
+		"accessLevel": accessLevel,
+	})
+}
+
+func (s *Session) writeEvent(event string, data map[string]interface{}) error {
+	bts, err := json.Marshal(Event{
+		Type:  "event",
+		Event: event,
+		Data:  data,
+	})
+	if err != nil {
+		return fmt.Errorf("error marshalling event: %w", err)
+	}
+
+	return s.writeMessage(bts)
+}
+
 func (s *Session) handleCursorUpdate(ctx context.Context, msg []byte) error {
+	s.touchPresence(ctx, false, s.selectionBlock(msg))
+
 	return s.realtime.PublishCursorUpdate(ctx, msg)
 }
 
@@ -384,11 +999,13 @@ func (s *Session) handleEvent(ctx context.Context, msg []byte) error {
 
 	if e.Event == "pong" {
 		s.pongMutex.Lock()
-		defer s.pongMutex.Unlock()
 		s.lastPong = time.Now()
+		s.pongMutex.Unlock()
+	} else {
+		s.touchPresence(ctx, false, nil)
 	}
 
-	if e.Event == "paste" {
+	if e.Event == "paste" && s.CanEdit() {
 		var ok bool
 		var val interface{}
 		var before string
@@ -447,7 +1064,12 @@ func (s *Session) Message(ctx context.Context, msg []byte) error {
 		return s.handleSubscribe(ctx, msg)
 	} else if t.Type == "op" {
 		s.docLog.Info(fmt.Sprintf("-> %s", msg))
+		s.touchPresence(ctx, s.CanEdit(), nil)
 		return s.handleOp(ctx, msg)
+	} else if t.Type == "offlineBatch" {
+		s.docLog.Info(fmt.Sprintf("-> %s", msg))
+		s.touchPresence(ctx, s.CanEdit(), nil)
+		return s.handleOfflineBatch(ctx, msg)
 	} else if t.Type == "cursor" {
 		return s.handleCursorUpdate(ctx, msg)
 	} else if t.Type == "event" {
@@ -479,6 +1101,8 @@ func (s *Session) Close(ctx context.Context) error {
 		s.cancel = nil
 	}
 
+	s.removePresence(ctx)
+
 	if s.doc == nil {
 		return nil
 	}
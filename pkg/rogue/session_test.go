@@ -13,8 +13,10 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/fivetentaylor/pointy/pkg/constants"
 	"github.com/fivetentaylor/pointy/pkg/env"
 	"github.com/fivetentaylor/pointy/pkg/rogue"
+	"github.com/fivetentaylor/pointy/pkg/service/pubsub"
 	"github.com/fivetentaylor/pointy/pkg/testutils"
 	v3 "github.com/fivetentaylor/pointy/rogue/v3"
 )
@@ -161,6 +163,370 @@ func TestSession_Subscribe_to_doc_you_dont_own(t *testing.T) {
 	defer server.Close()
 }
 
+func TestSession_Op_rejected(t *testing.T) {
+	testutils.EnsureStorage()
+	ctx := testutils.TestContext()
+	docID := uuid.NewString()
+	user := testutils.CreateUser(t, ctx)
+
+	testutils.CreateTestDocument(t, ctx, docID, "test")
+	testutils.AddOwnerToDocument(t, ctx, docID, user.ID)
+
+	_, ws, msgs, _, cleanup := testutils.CreateTestRogueSessionServer(t, ctx, user, docID)
+	defer cleanup()
+
+	sendMessage(t, ws, &rogue.Subscribe{
+		Type:  "subscribe",
+		DocID: docID,
+	})
+
+	authMsg := popType(t, msgs, "auth")
+	authorID := authMsg["authorID"].(string)
+	popType(t, msgs, "event")
+
+	testCases := []struct {
+		name string
+		op   v3.Op
+		code string
+	}{
+		{
+			name: "author mismatch",
+			op:   v3.InsertOp{ID: v3.ID{Author: "not" + authorID, Seq: 100}, Text: "x", ParentID: v3.ID{Author: "0", Seq: 3}, Side: v3.Right},
+			code: rogue.OpErrorAuthorMismatch,
+		},
+		{
+			name: "unknown parent",
+			op:   v3.InsertOp{ID: v3.ID{Author: authorID, Seq: 100}, Text: "x", ParentID: v3.ID{Author: "nobody", Seq: 3}, Side: v3.Right},
+			code: rogue.OpErrorParentNotFound,
+		},
+		{
+			name: "invalid line format",
+			op:   v3.FormatOp{ID: v3.ID{Author: authorID, Seq: 100}, StartID: v3.ID{Author: "0", Seq: 3}, EndID: v3.ID{Author: "0", Seq: 4}, Format: v3.FormatV3Header(1)},
+			code: rogue.OpErrorInvalidFormat,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opBytes, err := json.Marshal(tc.op)
+			require.NoError(t, err)
+
+			sendMessage(t, ws, &rogue.Operation{
+				Type: "op",
+				Op:   string(opBytes),
+			})
+
+			errMsg := popType(t, msgs, "opError")
+			assert.Equal(t, tc.code, errMsg["code"])
+		})
+	}
+
+	ds := rogue.NewDocStore(env.S3(ctx), env.Query(ctx), env.Redis(ctx))
+	size, err := ds.DeltaLogSize(ctx, docID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), size)
+}
+
+func TestSession_Op_read_only(t *testing.T) {
+	testutils.EnsureStorage()
+	ctx := testutils.TestContext()
+	docID := uuid.NewString()
+	owner := testutils.CreateUser(t, ctx)
+	reader := testutils.CreateUser(t, ctx)
+
+	testutils.CreateTestDocument(t, ctx, docID, "test")
+	testutils.AddOwnerToDocument(t, ctx, docID, owner.ID)
+	testutils.AddUserToDocument(t, ctx, docID, reader.ID, constants.AccessLevelRead)
+
+	_, ws, msgs, _, cleanup := testutils.CreateTestRogueSessionServer(t, ctx, reader, docID)
+	defer cleanup()
+
+	sendMessage(t, ws, &rogue.Subscribe{
+		Type:  "subscribe",
+		DocID: docID,
+	})
+
+	authMsg := popType(t, msgs, "auth")
+	assert.Equal(t, constants.AccessLevelRead, authMsg["accessLevel"])
+	authorID := authMsg["authorID"].(string)
+	popType(t, msgs, "event")
+
+	opBytes, err := json.Marshal(v3.InsertOp{
+		ID:       v3.ID{Author: authorID, Seq: 100},
+		Text:     "x",
+		ParentID: v3.ID{Author: "0", Seq: 3},
+		Side:     v3.Right,
+	})
+	require.NoError(t, err)
+
+	sendMessage(t, ws, &rogue.Operation{
+		Type: "op",
+		Op:   string(opBytes),
+	})
+
+	errMsg := popType(t, msgs, "opError")
+	assert.Equal(t, rogue.OpErrorReadOnly, errMsg["code"])
+}
+
+func TestSession_access_revoked(t *testing.T) {
+	testutils.EnsureStorage()
+	ctx := testutils.TestContext()
+	docID := uuid.NewString()
+	owner := testutils.CreateUser(t, ctx)
+	writer := testutils.CreateUser(t, ctx)
+
+	testutils.CreateTestDocument(t, ctx, docID, "test")
+	testutils.AddOwnerToDocument(t, ctx, docID, owner.ID)
+	testutils.AddUserToDocument(t, ctx, docID, writer.ID, constants.AccessLevelWrite)
+
+	_, ws, msgs, _, cleanup := testutils.CreateTestRogueSessionServer(t, ctx, writer, docID)
+	defer cleanup()
+
+	sendMessage(t, ws, &rogue.Subscribe{
+		Type:  "subscribe",
+		DocID: docID,
+	})
+
+	authMsg := popType(t, msgs, "auth")
+	assert.Equal(t, constants.AccessLevelWrite, authMsg["accessLevel"])
+
+	docAccessTbl := env.Query(ctx).DocumentAccess
+	_, err := docAccessTbl.
+		Where(docAccessTbl.DocumentID.Eq(docID)).
+		Where(docAccessTbl.UserID.Eq(writer.ID)).
+		Update(docAccessTbl.AccessLevel, constants.AccessLevelComment)
+	require.NoError(t, err)
+
+	// give the session a moment to subscribe to access changes
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, pubsub.PublishDocumentAccess(ctx, docID, writer.ID))
+
+	for {
+		evt := popType(t, msgs, "event")
+		if evt["event"] == "accessChanged" {
+			data := evt["data"].(map[string]interface{})
+			assert.Equal(t, constants.AccessLevelComment, data["accessLevel"])
+			break
+		}
+	}
+
+	_, err = docAccessTbl.
+		Where(docAccessTbl.DocumentID.Eq(docID)).
+		Where(docAccessTbl.UserID.Eq(writer.ID)).
+		Delete()
+	require.NoError(t, err)
+	require.NoError(t, pubsub.PublishDocumentAccess(ctx, docID, writer.ID))
+
+	for {
+		evt := popType(t, msgs, "event")
+		if evt["event"] == "accessRevoked" {
+			break
+		}
+	}
+}
+
+// popType pops messages until one with the given type arrives, ops (which
+// are arrays) and other message types are skipped
+func TestSession_Subscribe_catch_up(t *testing.T) {
+	testutils.EnsureStorage()
+	ctx := testutils.TestContext()
+	docID := uuid.NewString()
+	user := testutils.CreateUser(t, ctx)
+
+	testutils.CreateTestDocument(t, ctx, docID, "test")
+	testutils.AddOwnerToDocument(t, ctx, docID, user.ID)
+
+	ds := rogue.NewDocStore(env.S3(ctx), env.Query(ctx), env.Redis(ctx))
+
+	_, ws, msgs, _, cleanup := testutils.CreateTestRogueSessionServer(t, ctx, user, docID)
+	defer cleanup()
+
+	sendMessage(t, ws, &rogue.Subscribe{
+		Type:  "subscribe",
+		DocID: docID,
+	})
+
+	authMsg := popType(t, msgs, "auth")
+	authorID := authMsg["authorID"].(string)
+	loadedMsg := popType(t, msgs, "event")
+	lastSeq := int64(loadedMsg["data"].(map[string]interface{})["seq"].(float64))
+
+	op := v3.InsertOp{ID: v3.ID{Author: authorID, Seq: 100}, Text: "x", ParentID: v3.ID{Author: "0", Seq: 3}, Side: v3.Right}
+	opBytes, err := json.Marshal(op)
+	require.NoError(t, err)
+
+	sendMessage(t, ws, &rogue.Operation{
+		Type: "op",
+		Op:   string(opBytes),
+	})
+
+	require.Eventually(t, func() bool {
+		size, err := ds.DeltaLogSize(ctx, docID)
+		return err == nil && size == 1
+	}, time.Second, 10*time.Millisecond)
+
+	reconnect := func(sub *rogue.Subscribe) (map[string]interface{}, []string) {
+		_, ws, msgs, _, cleanup := testutils.CreateTestRogueSessionServer(t, ctx, user, docID)
+		defer cleanup()
+
+		sendMessage(t, ws, sub)
+
+		authMsg := popType(t, msgs, "auth")
+
+		var out []string
+		for {
+			msg, err := pop(msgs, 1000*time.Millisecond)
+			require.NoError(t, err)
+			if strings.Contains(msg, `"loaded"`) {
+				return authMsg, out
+			}
+			out = append(out, msg)
+		}
+	}
+
+	t.Run("last seq", func(t *testing.T) {
+		authMsg, out := reconnect(&rogue.Subscribe{
+			Type:     "subscribe",
+			DocID:    docID,
+			AuthorID: authorID,
+			LastSeq:  &lastSeq,
+		})
+		assert.Equal(t, true, authMsg["catchUp"])
+		require.Len(t, out, 1)
+		assert.JSONEq(t, string(opBytes), out[0])
+	})
+
+	t.Run("content address", func(t *testing.T) {
+		_, doc, err := ds.GetCurrentDoc(ctx, docID)
+		require.NoError(t, err)
+		address, err := doc.GetFullAddress()
+		require.NoError(t, err)
+		address.MaxIDs[authorID] = 0
+		addressBytes, err := json.Marshal(address)
+		require.NoError(t, err)
+
+		authMsg, out := reconnect(&rogue.Subscribe{
+			Type:           "subscribe",
+			DocID:          docID,
+			AuthorID:       authorID,
+			ContentAddress: string(addressBytes),
+		})
+		assert.Equal(t, true, authMsg["catchUp"])
+		require.Len(t, out, 1)
+		assert.JSONEq(t, string(opBytes), out[0])
+	})
+
+	t.Run("compacted", func(t *testing.T) {
+		require.NoError(t, ds.SnapshotDoc(ctx, docID))
+
+		authMsg, out := reconnect(&rogue.Subscribe{
+			Type:     "subscribe",
+			DocID:    docID,
+			AuthorID: authorID,
+			LastSeq:  &lastSeq,
+		})
+		assert.Nil(t, authMsg["catchUp"])
+		require.Len(t, out, 1)
+
+		var outMsg v3.Message
+		require.NoError(t, json.Unmarshal([]byte(out[0]), &outMsg))
+		assert.IsType(t, v3.SnapshotOp{}, outMsg.Op)
+	})
+}
+
+func TestSession_OfflineBatch(t *testing.T) {
+	testutils.EnsureStorage()
+	ctx := testutils.TestContext()
+	docID := uuid.NewString()
+	user := testutils.CreateUser(t, ctx)
+
+	testutils.CreateTestDocument(t, ctx, docID, "test")
+	testutils.AddOwnerToDocument(t, ctx, docID, user.ID)
+
+	ds := rogue.NewDocStore(env.S3(ctx), env.Query(ctx), env.Redis(ctx))
+	_, doc, err := ds.GetCurrentDoc(ctx, docID)
+	require.NoError(t, err)
+	base, err := doc.GetFullAddress()
+	require.NoError(t, err)
+	baseBytes, err := json.Marshal(base)
+	require.NoError(t, err)
+
+	_, ws, msgs, _, cleanup := testutils.CreateTestRogueSessionServer(t, ctx, user, docID)
+	defer cleanup()
+
+	sendMessage(t, ws, &rogue.Subscribe{
+		Type:  "subscribe",
+		DocID: docID,
+	})
+
+	authMsg := popType(t, msgs, "auth")
+	authorID := authMsg["authorID"].(string)
+	popType(t, msgs, "event")
+
+	sendBatch := func(mops ...v3.Op) {
+		opBytes, err := json.Marshal(v3.MultiOp{Mops: mops})
+		require.NoError(t, err)
+
+		sendMessage(t, ws, &rogue.OfflineBatch{
+			Type:           "offlineBatch",
+			Op:             string(opBytes),
+			ContentAddress: string(baseBytes),
+		})
+	}
+
+	t.Run("rejected whole", func(t *testing.T) {
+		sendBatch(
+			v3.InsertOp{ID: v3.ID{Author: authorID, Seq: 100}, Text: "x", ParentID: v3.ID{Author: "0", Seq: 3}, Side: v3.Right},
+			v3.InsertOp{ID: v3.ID{Author: authorID, Seq: 101}, Text: "y", ParentID: v3.ID{Author: "nobody", Seq: 3}, Side: v3.Right},
+		)
+
+		errMsg := popType(t, msgs, "opError")
+		assert.Equal(t, rogue.OpErrorParentNotFound, errMsg["code"])
+
+		size, err := ds.DeltaLogSize(ctx, docID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), size)
+	})
+
+	t.Run("merged", func(t *testing.T) {
+		sendBatch(
+			v3.InsertOp{ID: v3.ID{Author: authorID, Seq: 100}, Text: "x", ParentID: v3.ID{Author: "0", Seq: 3}, Side: v3.Right},
+			v3.InsertOp{ID: v3.ID{Author: authorID, Seq: 101}, Text: "y", ParentID: v3.ID{Author: authorID, Seq: 100}, Side: v3.Right},
+		)
+
+		eventMsg := popType(t, msgs, "event")
+		assert.Equal(t, "offlineBatchMerged", eventMsg["event"])
+
+		data := eventMsg["data"].(map[string]interface{})
+		spans := data["spans"].([]interface{})
+		require.Len(t, spans, 1)
+		assert.Contains(t, spans[0].(map[string]interface{})["html"], "txyest")
+		assert.Equal(t, false, spans[0].(map[string]interface{})["concurrent"])
+
+		size, err := ds.DeltaLogSize(ctx, docID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), size)
+	})
+}
+
+func popType(t *testing.T, msgs <-chan string, tp string) map[string]interface{} {
+	for {
+		receivedMessage, err := pop(msgs, 1000*time.Millisecond)
+		if err != nil {
+			t.Fatalf("Failed to receive %q message: %v", tp, err)
+		}
+
+		var msg map[string]interface{}
+		if json.Unmarshal([]byte(receivedMessage), &msg) != nil {
+			continue
+		}
+
+		if msg["type"] == tp {
+			return msg
+		}
+	}
+}
+
 func sendMessage(t *testing.T, ws *websocket.Conn, msg interface{}) {
 	if err := ws.WriteJSON(msg); err != nil {
 		t.Fatalf("Failed to write message: %v", err)
@@ -194,6 +194,58 @@ func (ds *DocStore) GetDeltaLog(ctx context.Context, docID string, lowScore, hig
 	return events, nil
 }
 
+type ErrorDeltaLogCompacted struct {
+	DocID       string
+	Seq         int64
+	SnapshotSeq int64
+}
+
+func (e ErrorDeltaLogCompacted) Error() string {
+	return fmt.Sprintf("delta log for doc %s compacted past seq %d (snapshot seq %d)", e.DocID, e.Seq, e.SnapshotSeq)
+}
+
+// GetOpsSince returns the ops in the delta log after seq and the highest seq
+// returned. If some of those ops have already been folded into an s3 snapshot
+// and removed from the delta log it returns ErrorDeltaLogCompacted.
+func (ds *DocStore) GetOpsSince(ctx context.Context, docID string, seq int64) ([]rogueV3.Op, int64, error) {
+	// read the delta log before the snapshot seq, if a snapshot lands between
+	// the two calls we'll still have every entry we read
+	events, err := ds.GetDeltaLog(ctx, docID, seq+1, -1)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ds.GetDeltaLog(ctx, %s, %d, -1): %w", docID, seq+1, err)
+	}
+
+	s3Seq, err := ds.GetLastS3Seq(docID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ds.GetLastS3Seq(%s): %w", docID, err)
+	}
+
+	if seq < s3Seq && (len(events) == 0 || int64(events[0].Score) != seq+1) {
+		return nil, 0, ErrorDeltaLogCompacted{DocID: docID, Seq: seq, SnapshotSeq: s3Seq}
+	}
+
+	maxSeq := seq
+	ops := make([]rogueV3.Op, 0, len(events))
+	for _, z := range events {
+		maxSeq = int64(z.Score)
+
+		op := z.Member.(string)
+		if strings.HasPrefix(op, "deleted") {
+			continue
+		}
+
+		var msg rogueV3.Message
+		err = json.Unmarshal([]byte(op), &msg)
+		if err != nil {
+			return nil, 0, fmt.Errorf("[doc %s] error unmarshalling op at seq %d: %w", docID, maxSeq, err)
+		}
+
+		ops = append(ops, msg.Op)
+	}
+
+	return ops, maxSeq, nil
+}
+
 func (ds *DocStore) DeleteDeltaLogItem(ctx context.Context, docID string, score int64) error {
 	eventsZSetKey := fmt.Sprintf(constants.DocEventsKeyFormat, docID)
 
@@ -0,0 +1,732 @@
+package rogueclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/fivetentaylor/pointy/pkg/rogue"
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+const (
+	DefaultReconnectDelay    = 500 * time.Millisecond
+	DefaultMaxReconnectDelay = 30 * time.Second
+	DefaultHandshakeTimeout  = 30 * time.Second
+)
+
+var (
+	ErrNotConnected = errors.New("rogueclient: not connected")
+	ErrClosed       = errors.New("rogueclient: closed")
+)
+
+type EventType string
+
+const (
+	// EventConnected is emitted once the doc has loaded, on the first
+	// connection and on every reconnect
+	EventConnected EventType = "connected"
+
+	// EventDisconnected is emitted when the connection drops, edits made
+	// while disconnected are sent when the client reconnects
+	EventDisconnected EventType = "disconnected"
+
+	// EventChange is emitted when an op from another author is merged
+	EventChange EventType = "change"
+
+	// EventOpError is emitted when the server rejects one of our ops
+	EventOpError EventType = "opError"
+
+	// EventServer is any other event the server sends, e.g. accessChanged
+	// or offlineBatchMerged
+	EventServer EventType = "server"
+
+	// EventError is emitted for errors that don't stop the client, like a
+	// failed reconnect attempt
+	EventError EventType = "error"
+)
+
+// Event is passed to Options.OnEvent, which fields are set depends on Type
+type Event struct {
+	Type EventType
+
+	// EventChange
+	Op      v3.Op
+	Actions v3.Actions
+
+	// EventOpError
+	Code string
+	OpID *v3.ID
+
+	// EventServer
+	Name string
+	Data map[string]interface{}
+
+	Err error
+}
+
+type Options struct {
+	// BaseURL is the app's url e.g. https://app.example.com
+	BaseURL string
+	DocID   string
+
+	// Token is sent as a bearer token, Header can carry any other auth
+	Token  string
+	Header http.Header
+
+	// OnEvent is called from the client's goroutine, it shouldn't block
+	OnEvent func(Event)
+
+	ReconnectDelay    time.Duration
+	MaxReconnectDelay time.Duration
+	HandshakeTimeout  time.Duration
+
+	Dialer *websocket.Dialer
+	Logger *slog.Logger
+}
+
+// Client keeps a local replica of a rogue document in sync with the server
+// over the same websocket protocol the editor uses. Edits are applied to the
+// replica and broadcast, remote ops are merged as they arrive.
+type Client struct {
+	opts Options
+	log  *slog.Logger
+
+	// mu guards the replica and connection state
+	mu          sync.Mutex
+	doc         *v3.Rogue
+	authorID    string
+	accessLevel string
+	conn        *websocket.Conn
+	connected   bool
+	closed      bool
+	cancel      context.CancelFunc
+
+	// pending are the ops made since the last successful send, they're sent
+	// as an offline batch made on pendingBase after reconnecting
+	pending     []v3.Op
+	pendingBase *v3.ContentAddress
+
+	// remerge is set when a reconnect replaced the replica with a snapshot,
+	// the queued edits are merged back on top of it once it's loaded
+	remerge bool
+	started bool
+
+	writeMu sync.Mutex
+	done    chan struct{}
+}
+
+func New(opts Options) *Client {
+	if opts.ReconnectDelay == 0 {
+		opts.ReconnectDelay = DefaultReconnectDelay
+	}
+	if opts.MaxReconnectDelay == 0 {
+		opts.MaxReconnectDelay = DefaultMaxReconnectDelay
+	}
+	if opts.HandshakeTimeout == 0 {
+		opts.HandshakeTimeout = DefaultHandshakeTimeout
+	}
+	if opts.Dialer == nil {
+		opts.Dialer = websocket.DefaultDialer
+	}
+
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.New(slog.NewJSONHandler(io.Discard, nil))
+	}
+
+	return &Client{
+		opts: opts,
+		log:  logger.With("docID", opts.DocID),
+		done: make(chan struct{}),
+	}
+}
+
+// WebsocketURL is the rogue websocket endpoint of a document
+func WebsocketURL(baseURL, docID string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("error parsing base url: %w", err)
+	}
+
+	switch u.Scheme {
+	case "http", "ws":
+		u.Scheme = "ws"
+	case "https", "wss":
+		u.Scheme = "wss"
+	default:
+		return "", fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+
+	u.Path = strings.TrimSuffix(u.Path, "/") + fmt.Sprintf("/api/v1/documents/%s/rogue/ws", docID)
+	return u.String(), nil
+}
+
+// Connect loads the document and keeps it in sync until ctx is done or the
+// client is closed, reconnecting whenever the connection drops
+func (c *Client) Connect(ctx context.Context) error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return ErrClosed
+	}
+	if c.started {
+		c.mu.Unlock()
+		return fmt.Errorf("rogueclient: already connected")
+	}
+	ctx, c.cancel = context.WithCancel(ctx)
+	c.mu.Unlock()
+
+	conn, err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.started = true
+	c.mu.Unlock()
+
+	go c.run(ctx, conn)
+	return nil
+}
+
+// Close disconnects and stops reconnecting
+func (c *Client) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	cancel, conn, started := c.cancel, c.conn, c.started
+	c.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+
+	if conn != nil {
+		c.writeMu.Lock()
+		conn.WriteControl(
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+			time.Now().Add(time.Second),
+		)
+		c.writeMu.Unlock()
+		conn.Close()
+	}
+
+	if started {
+		<-c.done
+	}
+
+	return nil
+}
+
+func (c *Client) AuthorID() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.authorID
+}
+
+func (c *Client) AccessLevel() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.accessLevel
+}
+
+func (c *Client) Connected() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.connected
+}
+
+// View calls fn with the local replica, the doc must not be modified or
+// kept after fn returns
+func (c *Client) View(fn func(doc *v3.Rogue) error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.doc == nil {
+		return ErrNotConnected
+	}
+
+	return fn(c.doc)
+}
+
+func (c *Client) GetText() (string, error) {
+	var text string
+	err := c.View(func(doc *v3.Rogue) error {
+		text = doc.GetText()
+		return nil
+	})
+	return text, err
+}
+
+func (c *Client) GetMarkdown() (string, error) {
+	var md string
+	err := c.View(func(doc *v3.Rogue) (err error) {
+		md, err = doc.GetFullMarkdown()
+		return err
+	})
+	return md, err
+}
+
+func (c *Client) Insert(visIx int, text string) (v3.Op, error) {
+	return c.edit(func(doc *v3.Rogue) (v3.Op, error) {
+		return doc.Insert(visIx, text)
+	})
+}
+
+func (c *Client) Delete(visIx, length int) (v3.Op, error) {
+	return c.edit(func(doc *v3.Rogue) (v3.Op, error) {
+		return doc.Delete(visIx, length)
+	})
+}
+
+func (c *Client) Format(visIx, length int, format v3.FormatV3) (v3.Op, error) {
+	return c.edit(func(doc *v3.Rogue) (v3.Op, error) {
+		return doc.Format(visIx, length, format)
+	})
+}
+
+func (c *Client) InsertMarkdown(visIx int, md string) (v3.Op, error) {
+	return c.edit(func(doc *v3.Rogue) (v3.Op, error) {
+		mop, _, err := doc.InsertMarkdown(visIx, md)
+		return mop, err
+	})
+}
+
+// edit applies fn to the replica and broadcasts the op it makes. Edits made
+// while disconnected are queued and sent once the client reconnects.
+func (c *Client) edit(fn func(doc *v3.Rogue) (v3.Op, error)) (v3.Op, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil, ErrClosed
+	}
+
+	if c.doc == nil {
+		return nil, ErrNotConnected
+	}
+
+	if len(c.pending) == 0 {
+		base, err := c.doc.GetFullAddress()
+		if err != nil {
+			return nil, fmt.Errorf("error getting content address: %w", err)
+		}
+		c.pendingBase = base
+	}
+
+	op, err := fn(c.doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.connected && len(c.pending) == 0 {
+		err = c.sendOp(c.conn, op)
+		if err == nil {
+			return op, nil
+		}
+
+		c.log.Warn("error sending op, queueing it", "error", err)
+	}
+
+	c.pending = append(c.pending, op)
+	return op, nil
+}
+
+func (c *Client) sendOp(conn *websocket.Conn, op v3.Op) error {
+	opBytes, err := json.Marshal(op)
+	if err != nil {
+		return fmt.Errorf("error marshalling op: %w", err)
+	}
+
+	return c.writeJSON(conn, rogue.Operation{Type: "op", Op: string(opBytes)})
+}
+
+// flushPending sends the queued edits as a single offline batch, c.mu must be
+// held
+func (c *Client) flushPending(conn *websocket.Conn) error {
+	if len(c.pending) == 0 {
+		return nil
+	}
+
+	mop := v3.MultiOp{}
+	for _, op := range c.pending {
+		mop = mop.Append(op)
+	}
+
+	opBytes, err := json.Marshal(mop)
+	if err != nil {
+		return fmt.Errorf("error marshalling offline batch: %w", err)
+	}
+
+	baseBytes, err := json.Marshal(c.pendingBase)
+	if err != nil {
+		return fmt.Errorf("error marshalling content address: %w", err)
+	}
+
+	err = c.writeJSON(conn, rogue.OfflineBatch{
+		Type:           "offlineBatch",
+		Op:             string(opBytes),
+		ContentAddress: string(baseBytes),
+	})
+	if err != nil {
+		return err
+	}
+
+	c.pending = nil
+	c.pendingBase = nil
+	return nil
+}
+
+func (c *Client) writeJSON(conn *websocket.Conn, msg any) error {
+	if conn == nil {
+		return ErrNotConnected
+	}
+
+	bts, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("error marshalling message: %w", err)
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return conn.WriteMessage(websocket.TextMessage, bts)
+}
+
+// connect dials the server and subscribes, it returns once the doc has loaded
+// and any queued edits have been sent
+func (c *Client) connect(ctx context.Context) (*websocket.Conn, error) {
+	wsURL, err := WebsocketURL(c.opts.BaseURL, c.opts.DocID)
+	if err != nil {
+		return nil, err
+	}
+
+	header := http.Header{}
+	for k, v := range c.opts.Header {
+		header[k] = v
+	}
+	if c.opts.Token != "" {
+		header.Set("Authorization", "Bearer "+c.opts.Token)
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, c.opts.HandshakeTimeout)
+	defer cancel()
+
+	conn, _, err := c.opts.Dialer.DialContext(dialCtx, wsURL, header)
+	if err != nil {
+		return nil, fmt.Errorf("error dialing %s: %w", wsURL, err)
+	}
+
+	err = c.subscribe(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	c.emit(Event{Type: EventConnected})
+	return conn, nil
+}
+
+func (c *Client) subscribe(conn *websocket.Conn) error {
+	c.mu.Lock()
+	sub := rogue.Subscribe{
+		Type:     "subscribe",
+		DocID:    c.opts.DocID,
+		AuthorID: c.authorID,
+	}
+
+	// a reconnecting client only needs the ops it's missing
+	if c.doc != nil {
+		address, err := c.doc.GetFullAddress()
+		if err != nil {
+			c.mu.Unlock()
+			return fmt.Errorf("error getting content address: %w", err)
+		}
+
+		addressBytes, err := json.Marshal(address)
+		if err != nil {
+			c.mu.Unlock()
+			return fmt.Errorf("error marshalling content address: %w", err)
+		}
+		sub.ContentAddress = string(addressBytes)
+	}
+	c.mu.Unlock()
+
+	err := c.writeJSON(conn, sub)
+	if err != nil {
+		return fmt.Errorf("error sending subscribe: %w", err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(c.opts.HandshakeTimeout))
+	defer conn.SetReadDeadline(time.Time{})
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return fmt.Errorf("error reading handshake: %w", err)
+		}
+
+		loaded, err := c.handleHandshakeMessage(conn, msg)
+		if err != nil {
+			return err
+		}
+
+		if loaded {
+			return nil
+		}
+	}
+}
+
+func (c *Client) handleHandshakeMessage(conn *websocket.Conn, msg []byte) (loaded bool, err error) {
+	if len(msg) > 0 && msg[0] == '[' {
+		return false, c.handleOp(msg)
+	}
+
+	var t rogue.MsgType
+	err = json.Unmarshal(msg, &t)
+	if err != nil {
+		return false, fmt.Errorf("error unmarshalling message: %w", err)
+	}
+
+	switch t.Type {
+	case "auth":
+		var auth rogue.AuthEvent
+		err = json.Unmarshal(msg, &auth)
+		if err != nil {
+			return false, fmt.Errorf("error unmarshalling auth: %w", err)
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if auth.AuthorID != c.authorID && len(c.pending) > 0 {
+			// the server won't take ops from our old author anymore
+			c.log.Warn("author changed, dropping queued edits", "pending", len(c.pending))
+			c.pending = nil
+			c.pendingBase = nil
+		}
+
+		c.authorID = auth.AuthorID
+		c.accessLevel = auth.AccessLevel
+
+		if !auth.CatchUp {
+			// a snapshot follows
+			c.doc = v3.NewRogueForQuill(auth.AuthorID)
+			c.remerge = len(c.pending) > 0
+		}
+
+		return false, nil
+	case "event":
+		var e rogue.Event
+		err = json.Unmarshal(msg, &e)
+		if err != nil {
+			return false, fmt.Errorf("error unmarshalling event: %w", err)
+		}
+
+		if e.Event != "loaded" {
+			c.handleEvent(conn, e)
+			return false, nil
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if c.remerge {
+			for _, op := range c.pending {
+				_, err = c.doc.MergeOp(op)
+				if err != nil {
+					c.log.Warn("error merging queued edit", "error", err)
+				}
+			}
+			c.remerge = false
+		}
+
+		err = c.flushPending(conn)
+		if err != nil {
+			return false, fmt.Errorf("error sending queued edits: %w", err)
+		}
+
+		c.conn = conn
+		c.connected = true
+		return true, nil
+	default:
+		c.handleMessage(conn, msg)
+		return false, nil
+	}
+}
+
+// run reads from the connection until it drops and then reconnects, it
+// returns once the client is closed
+func (c *Client) run(ctx context.Context, conn *websocket.Conn) {
+	defer close(c.done)
+
+	for {
+		c.read(conn)
+
+		c.mu.Lock()
+		c.connected = false
+		c.conn = nil
+		closed := c.closed
+		c.mu.Unlock()
+
+		conn.Close()
+
+		if closed || ctx.Err() != nil {
+			return
+		}
+
+		c.emit(Event{Type: EventDisconnected})
+
+		conn = c.reconnect(ctx)
+		if conn == nil {
+			return
+		}
+	}
+}
+
+func (c *Client) reconnect(ctx context.Context) *websocket.Conn {
+	delay := c.opts.ReconnectDelay
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+
+		conn, err := c.connect(ctx)
+		if err == nil {
+			return conn
+		}
+
+		c.log.Warn("error reconnecting", "error", err, "delay", delay)
+		c.emit(Event{Type: EventError, Err: err})
+
+		delay = min(delay*2, c.opts.MaxReconnectDelay)
+	}
+}
+
+func (c *Client) read(conn *websocket.Conn) {
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			c.log.Info("connection closed", "error", err)
+			return
+		}
+
+		c.handleMessage(conn, msg)
+	}
+}
+
+func (c *Client) handleMessage(conn *websocket.Conn, msg []byte) {
+	// ops are serialized as arrays, everything else is an object
+	if len(msg) > 0 && msg[0] == '[' {
+		err := c.handleOp(msg)
+		if err != nil {
+			c.log.Error("error handling op", "error", err)
+			c.emit(Event{Type: EventError, Err: err})
+		}
+		return
+	}
+
+	var t rogue.MsgType
+	err := json.Unmarshal(msg, &t)
+	if err != nil {
+		c.log.Error("error unmarshalling message", "error", err)
+		return
+	}
+
+	switch t.Type {
+	case "event":
+		var e rogue.Event
+		err = json.Unmarshal(msg, &e)
+		if err != nil {
+			c.log.Error("error unmarshalling event", "error", err)
+			return
+		}
+		c.handleEvent(conn, e)
+	case "opError":
+		var e rogue.OpErrorEvent
+		err = json.Unmarshal(msg, &e)
+		if err != nil {
+			c.log.Error("error unmarshalling op error", "error", err)
+			return
+		}
+		c.emit(Event{Type: EventOpError, Code: e.Code, OpID: e.OpID, Err: errors.New(e.Message)})
+	default:
+		// cursors and presence aren't tracked by the client
+	}
+}
+
+func (c *Client) handleEvent(conn *websocket.Conn, e rogue.Event) {
+	switch e.Event {
+	case "ping":
+		err := c.writeJSON(conn, rogue.Event{Type: "event", Event: "pong"})
+		if err != nil {
+			c.log.Warn("error sending pong", "error", err)
+		}
+		return
+	case "accessChanged":
+		if level, ok := e.Data["accessLevel"].(string); ok {
+			c.mu.Lock()
+			c.accessLevel = level
+			c.mu.Unlock()
+		}
+	}
+
+	c.emit(Event{Type: EventServer, Name: e.Event, Data: e.Data})
+}
+
+func (c *Client) handleOp(msg []byte) error {
+	var rmsg v3.Message
+	err := json.Unmarshal(msg, &rmsg)
+	if err != nil {
+		return fmt.Errorf("error unmarshalling op: %w", err)
+	}
+
+	c.mu.Lock()
+	if c.doc == nil {
+		c.mu.Unlock()
+		return fmt.Errorf("op received before auth")
+	}
+
+	actions, err := c.doc.MergeOp(rmsg.Op)
+	authorID := c.authorID
+	c.mu.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("error merging op: %w", err)
+	}
+
+	if _, ok := rmsg.Op.(v3.SnapshotOp); ok {
+		return nil
+	}
+
+	// our own ops are echoed back by the server
+	if rmsg.Op.GetID().Author == authorID {
+		return nil
+	}
+
+	c.emit(Event{Type: EventChange, Op: rmsg.Op, Actions: actions})
+	return nil
+}
+
+func (c *Client) emit(e Event) {
+	if c.opts.OnEvent != nil {
+		c.opts.OnEvent(e)
+	}
+}
@@ -0,0 +1,152 @@
+package rogueclient_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fivetentaylor/pointy/pkg/rogueclient"
+	"github.com/fivetentaylor/pointy/pkg/rogueclient/rogueclienttest"
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+type recorder struct {
+	mu     sync.Mutex
+	events []rogueclient.Event
+}
+
+func (r *recorder) OnEvent(e rogueclient.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+func (r *recorder) count(t rogueclient.EventType) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := 0
+	for _, e := range r.events {
+		if e.Type == t {
+			n++
+		}
+	}
+	return n
+}
+
+func newClient(t *testing.T, server *rogueclienttest.Server, rec *recorder) *rogueclient.Client {
+	client := rogueclient.New(rogueclient.Options{
+		BaseURL:        server.BaseURL(),
+		DocID:          server.DocID,
+		OnEvent:        rec.OnEvent,
+		ReconnectDelay: 10 * time.Millisecond,
+	})
+
+	err := client.Connect(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	return client
+}
+
+func requireText(t *testing.T, client *rogueclient.Client, expected string) {
+	require.Eventually(t, func() bool {
+		text, err := client.GetText()
+		return err == nil && text == expected
+	}, 2*time.Second, 5*time.Millisecond)
+}
+
+func TestClient(t *testing.T) {
+	server := rogueclienttest.NewServer(t, "hello world")
+
+	rec := &recorder{}
+	client := newClient(t, server, rec)
+
+	require.NotEmpty(t, client.AuthorID())
+	require.Equal(t, "owner", client.AccessLevel())
+	requireText(t, client, "hello world\n")
+	require.Equal(t, 1, rec.count(rogueclient.EventConnected))
+
+	_, err := client.Insert(5, " big")
+	require.NoError(t, err)
+	_, err = client.Format(0, 5, v3.FormatV3Span{"b": "true"})
+	require.NoError(t, err)
+	_, err = client.InsertMarkdown(0, "# Title\n")
+	require.NoError(t, err)
+	_, err = client.Delete(len("Title\nhello big "), 5)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		text, err := server.Text()
+		return err == nil && text == "Title\nhello big \n"
+	}, 2*time.Second, 5*time.Millisecond)
+
+	md, err := client.GetMarkdown()
+	require.NoError(t, err)
+	require.Contains(t, md, "# Title")
+	require.Contains(t, md, "**hello**")
+
+	// our own ops echoed back don't count as changes
+	require.Equal(t, 0, rec.count(rogueclient.EventChange))
+
+	err = server.Edit(func(doc *v3.Rogue) (v3.Op, error) {
+		return doc.Insert(doc.VisSize-1, "there")
+	})
+	require.NoError(t, err)
+
+	requireText(t, client, "Title\nhello big there\n")
+	require.Equal(t, 1, rec.count(rogueclient.EventChange))
+}
+
+func TestClientConverges(t *testing.T) {
+	server := rogueclienttest.NewServer(t, "abc")
+
+	a := newClient(t, server, &recorder{})
+	b := newClient(t, server, &recorder{})
+	require.NotEqual(t, a.AuthorID(), b.AuthorID())
+
+	_, err := a.Insert(0, "a")
+	require.NoError(t, err)
+	_, err = b.Insert(3, "b")
+	require.NoError(t, err)
+
+	requireText(t, a, "aabcb\n")
+	requireText(t, b, "aabcb\n")
+}
+
+func TestClientReconnects(t *testing.T) {
+	server := rogueclienttest.NewServer(t, "hello")
+
+	rec := &recorder{}
+	client := newClient(t, server, rec)
+	authorID := client.AuthorID()
+
+	server.DropConnections()
+	require.Eventually(t, func() bool {
+		return rec.count(rogueclient.EventDisconnected) == 1
+	}, 2*time.Second, 5*time.Millisecond)
+
+	// both sides edit while the client is away
+	err := server.Edit(func(doc *v3.Rogue) (v3.Op, error) {
+		return doc.Insert(0, ">> ")
+	})
+	require.NoError(t, err)
+
+	_, err = client.Insert(5, " world")
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return rec.count(rogueclient.EventConnected) == 2
+	}, 2*time.Second, 5*time.Millisecond)
+
+	// the client caught up instead of reloading and kept its author
+	require.Equal(t, authorID, client.AuthorID())
+	requireText(t, client, ">> hello world\n")
+	require.Eventually(t, func() bool {
+		text, err := server.Text()
+		return err == nil && text == ">> hello world\n"
+	}, 2*time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,167 @@
+// Package rogueclienttest runs real rogue sessions behind an in-process
+// websocket server so bots and integrations can be tested against the same
+// protocol the editor uses. Redis is replaced by miniredis, postgres, s3 and
+// dynamo come from the usual test storage.
+package rogueclienttest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/rogue"
+	"github.com/fivetentaylor/pointy/pkg/testutils"
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+type Server struct {
+	*httptest.Server
+
+	DocID string
+	User  *models.User
+	Redis *miniredis.Miniredis
+
+	ctx   context.Context
+	store *rogue.DocStore
+
+	mu    sync.Mutex
+	conns map[*websocket.Conn]bool
+}
+
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// NewServer creates a document with text as its contents, owned by a new
+// user, and serves it to that user. The server's BaseURL can be passed
+// straight to rogueclient.Options, it's closed when the test finishes.
+func NewServer(t *testing.T, text string) *Server {
+	testutils.EnsureStorage()
+
+	mr := miniredis.RunT(t)
+	rc := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rc.Close() })
+
+	ctx := env.RedisCtx(testutils.TestContext(), rc)
+
+	docID := uuid.NewString()
+	user := testutils.CreateUser(t, ctx)
+	_, store := testutils.CreateTestDocument(t, ctx, docID, text)
+	testutils.AddOwnerToDocument(t, ctx, docID, user.ID)
+
+	s := &Server{
+		DocID: docID,
+		User:  user,
+		Redis: mr,
+		ctx:   ctx,
+		store: store,
+		conns: map[*websocket.Conn]bool{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("/api/v1/documents/%s/rogue/ws", docID), s.handleWebsocket)
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *Server) BaseURL() string {
+	return s.Server.URL
+}
+
+// Text is the stored copy of the doc, including every committed op
+func (s *Server) Text() (string, error) {
+	_, doc, err := s.store.GetCurrentDoc(s.ctx, s.DocID)
+	if err != nil {
+		return "", err
+	}
+
+	return doc.GetText(), nil
+}
+
+// Edit makes a change to the stored doc as author "0" and commits it, like a
+// collaborator editing in the browser
+func (s *Server) Edit(fn func(doc *v3.Rogue) (v3.Op, error)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, doc, err := s.store.GetCurrentDoc(s.ctx, s.DocID)
+	if err != nil {
+		return err
+	}
+
+	op, err := fn(doc)
+	if err != nil {
+		return err
+	}
+
+	return rogue.CommitOp(s.ctx, s.DocID, op)
+}
+
+// DropConnections closes every client connection without a close message,
+// like a network failure
+func (s *Server) DropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for conn := range s.conns {
+		conn.Close()
+	}
+}
+
+func (s *Server) handleWebsocket(w http.ResponseWriter, r *http.Request) {
+	log := env.SLog(s.ctx)
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Error("error upgrading to websocket", "error", err)
+		return
+	}
+	defer conn.Close()
+
+	session, err := rogue.NewSession(s.ctx, s.User, conn, s.store, s.DocID)
+	if err != nil {
+		log.Error("error creating session", "error", err)
+		return
+	}
+	session.DeactivateDocLogger() // don't log to S3 in tests
+
+	s.mu.Lock()
+	s.conns[conn] = true
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		session.Close(s.ctx)
+	}()
+
+	for {
+		messageType, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		if messageType != websocket.TextMessage {
+			continue
+		}
+
+		err = session.Message(s.ctx, msg)
+		if err != nil {
+			log.Error("error handling message", "error", err)
+			return
+		}
+	}
+}
@@ -82,7 +82,7 @@ func (m *Manager) JoinInvite(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	doc, err := sharing.JoinDoc(ctx, sl.DocumentID, currentUser.Id, "write")
+	doc, err := sharing.JoinLink(ctx, sl, currentUser.Id)
 	if err != nil {
 		log.Errorf("[invite] error joining share link: %s", err)
 		auth.InviteFailed(os.Getenv("SEGMENT_KEY")).Render(r.Context(), w)
@@ -14,6 +14,7 @@ import (
 	"github.com/fivetentaylor/pointy/pkg/constants"
 	"github.com/fivetentaylor/pointy/pkg/env"
 	"github.com/fivetentaylor/pointy/pkg/query"
+	"github.com/fivetentaylor/pointy/pkg/service/apitokens"
 )
 
 type Manager struct {
@@ -37,6 +38,7 @@ func NewManager(cfg config.Server) *Manager {
 
 func (m *Manager) Routes(r chi.Router) {
 	r.Use(m.JWTMiddleware)
+	r.Use(RejectAPITokens)
 	r.Get("/auth/signout", m.Signout)
 	r.Get("/auth/google", m.GoogleRedirect)
 	r.Get("/auth/google/callback", m.GoogleCallback)
@@ -70,6 +72,21 @@ func (m *Manager) AttachUserClaimIfExists(next http.Handler) http.Handler {
 		authHeader := r.Header.Get("Authorization")
 		if authHeader != "" {
 			token := strings.TrimPrefix(authHeader, "Bearer ")
+			if apitokens.IsAPIToken(token) {
+				claims, err := apitokens.Authenticate(ctx, token)
+				if err != nil {
+					log.Error(fmt.Errorf("error authenticating api token: %w", err))
+					http.Error(w, "invalid api token", http.StatusUnauthorized)
+					return
+				}
+
+				// only routes registered with a scope treat the token as the
+				// user, see AllowAPIToken
+				ctx = env.APITokenClaimCtx(r.Context(), claims)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
 			claims, err := m.jwt.ParseUserToken(token)
 			if err == nil {
 				ctx = env.UserClaimCtx(r.Context(), claims)
@@ -85,6 +102,49 @@ func (m *Manager) AttachUserClaimIfExists(next http.Handler) http.Handler {
 	})
 }
 
+// AllowAPIToken lets requests made with an api token that has scope use the
+// route. Any {docID} in the route has to be one the token is limited to. An
+// empty scope leaves checking the scope to the handler, like graphql does per
+// field. Routes without it treat api token requests as logged out.
+func AllowAPIToken(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := env.APITokenClaim(r.Context())
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if scope != "" && !claims.HasScope(scope) {
+				http.Error(w, fmt.Sprintf("api token is missing the %s scope", scope), http.StatusForbidden)
+				return
+			}
+
+			docID := chi.URLParam(r, "docID")
+			if docID != "" && !claims.CanAccessDocument(docID) {
+				http.Error(w, "api token can not access this document", http.StatusForbidden)
+				return
+			}
+
+			ctx := env.UserClaimCtx(r.Context(), claims)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RejectAPITokens stops api tokens being used on routes that sign in or out,
+// a token must never be exchanged for a session
+func RejectAPITokens(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := env.APITokenClaim(r.Context()); ok {
+			http.Error(w, "api tokens can not be used here", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 func (m *Manager) RequireAdmin(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		user, err := env.UserClaim(r.Context())
@@ -0,0 +1,93 @@
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fivetentaylor/pointy/pkg/config"
+	"github.com/fivetentaylor/pointy/pkg/constants"
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/server/auth"
+)
+
+// withAPIToken stands in for AttachUserClaimIfExists authenticating a bearer
+// api token
+func withAPIToken(claims *models.UserClaims) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(env.APITokenClaimCtx(r.Context(), claims)))
+		})
+	}
+}
+
+func apiTokenClaims() *models.UserClaims {
+	return &models.UserClaims{
+		Id:          "user-id",
+		Email:       "user@example.com",
+		APITokenID:  "token-id",
+		Scopes:      []string{constants.APITokenScopeReadDocs},
+		DocumentIDs: []string{"doc-a"},
+	}
+}
+
+func TestRefreshTokenRejectsAPITokens(t *testing.T) {
+	m := auth.NewManager(config.Server{
+		JWTSecret:   "testtesttesttest",
+		GoogleOauth: &config.GoogleOauth{},
+	})
+
+	r := chi.NewRouter()
+	r.Use(withAPIToken(apiTokenClaims()))
+	r.Group(m.Routes)
+
+	for _, path := range []string{"/auth/refresh_token", "/auth/token"} {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.Header.Set("Authorization", "Bearer pty_test")
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code, path)
+		assert.Empty(t, w.Result().Cookies(), path)
+	}
+}
+
+func TestAllowAPIToken(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(withAPIToken(apiTokenClaims()))
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		claims, err := env.UserClaim(r.Context())
+		if err != nil {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(claims.Id))
+	}
+
+	r.With(auth.AllowAPIToken(constants.APITokenScopeReadDocs)).Get("/read/{docID}", handler)
+	r.With(auth.AllowAPIToken(constants.APITokenScopeWriteDocs)).Post("/write/{docID}", handler)
+	r.Put("/unscoped", handler)
+
+	tests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{http.MethodGet, "/read/doc-a", http.StatusOK},
+		{http.MethodGet, "/read/doc-b", http.StatusForbidden},
+		{http.MethodPost, "/write/doc-a", http.StatusForbidden},
+		{http.MethodPut, "/unscoped", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+		assert.Equal(t, tt.want, w.Code, "%s %s", tt.method, tt.path)
+	}
+}
@@ -1,13 +1,19 @@
 package server
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/fivetentaylor/pointy/pkg/env"
 	"github.com/fivetentaylor/pointy/pkg/query"
 	"github.com/fivetentaylor/pointy/pkg/rogue"
+	"github.com/fivetentaylor/pointy/pkg/service/images"
 	"github.com/fivetentaylor/pointy/pkg/views/editor"
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+	"gorm.io/gorm"
 )
 
 func (s *Server) HtmlDocument(w http.ResponseWriter, r *http.Request) {
@@ -61,6 +67,93 @@ func (s *Server) HtmlDocument(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(html))
 }
 
+func (s *Server) DocxDocument(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := env.Log(ctx)
+	q := env.Query(ctx)
+
+	docID := chi.URLParam(r, "docID")
+
+	var userID string
+	currentUser, err := env.UserClaim(ctx)
+	if err == nil {
+		userID = currentUser.Id
+	}
+
+	doc, err := query.GetReadableDocumentForUser(q, docID, userID)
+	if doc == nil || err != nil {
+		if err != nil {
+			switch e := err.(type) {
+			case *query.AccessDeniedError:
+				log.Errorf("error getting document (access denied): %s", e.Error())
+				http.Error(w, err.Error(), http.StatusForbidden)
+			default:
+				log.Errorf("error getting document: %s", e.Error())
+			}
+		}
+		http.NotFound(w, r)
+		return
+	}
+
+	address, err := requestAddress(r, q, docID)
+	if err != nil {
+		log.Errorf("error getting content address: %s", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	docStore := rogue.NewDocStore(s.S3, s.Query, s.Redis)
+	_, rog, err := docStore.GetCurrentDoc(ctx, docID)
+	if err != nil {
+		log.Errorf("error getting document from doc store: %s", err)
+		http.NotFound(w, r)
+		return
+	}
+
+	docx, err := rog.GetFullDocx(address, images.DocumentImageLoader(ctx, docID))
+	if err != nil {
+		log.Errorf("error getting docx: %s", err)
+		http.NotFound(w, r)
+		return
+	}
+
+	filename := strings.NewReplacer(`"`, "", "\\", "", "/", "-").Replace(doc.Title)
+	if filename == "" {
+		filename = "Untitled"
+	}
+
+	w.Header().Set("X-Document-Title", doc.Title)
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.wordprocessingml.document")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.docx"`, filename))
+	w.Write(docx)
+}
+
+// requestAddress returns the content address to read the document at, taken
+// from either a flagged version (?versionID=) or a raw address (?address=). A
+// nil address means the current state of the document.
+func requestAddress(r *http.Request, q *query.Query, docID string) (*v3.ContentAddress, error) {
+	if versionID := r.URL.Query().Get("versionID"); versionID != "" {
+		version, err := q.DocumentVersion.
+			Where(q.DocumentVersion.ID.Eq(versionID)).
+			Where(q.DocumentVersion.DocumentID.Eq(docID)).
+			First()
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("version %q not found", versionID)
+			}
+			return nil, err
+		}
+
+		return v3.ParseContentAddress(version.ContentAddress)
+	}
+
+	if address := r.URL.Query().Get("address"); address != "" {
+		return v3.ParseContentAddress(address)
+	}
+
+	return nil, nil
+}
+
 func (s *Server) DocumentEditor(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	log := env.Log(ctx)
@@ -0,0 +1,374 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/fivetentaylor/pointy/pkg/constants"
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/query"
+	"github.com/fivetentaylor/pointy/pkg/rogue"
+	"github.com/fivetentaylor/pointy/pkg/service/document"
+	"github.com/fivetentaylor/pointy/pkg/service/versions"
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+const (
+	ContentFormatMarkdown  = "markdown"
+	ContentFormatHtml      = "html"
+	ContentFormatPlaintext = "plaintext"
+
+	// ContentFormatBlame is html with each author's text wrapped in a span
+	ContentFormatBlame = "blame"
+)
+
+// editLockTimeout frees the author if a request dies while holding it
+const editLockTimeout = 30 * time.Second
+
+var errInvalidSelection = errors.New("invalid selection")
+
+type DocumentContent struct {
+	ID      string             `json:"id"`
+	Title   string             `json:"title"`
+	Format  string             `json:"format"`
+	Content string             `json:"content"`
+	Address *v3.ContentAddress `json:"address"`
+}
+
+type UpdateDocumentRequest struct {
+	// BeforeID and AfterID bound the text being replaced, e.g. "1a_42", the
+	// whole document is replaced when they're left out
+	BeforeID string `json:"beforeId"`
+	AfterID  string `json:"afterId"`
+	Markdown string `json:"markdown"`
+}
+
+type AppendDocumentRequest struct {
+	Markdown string `json:"markdown"`
+}
+
+// GetDocumentContent returns the document as markdown (the default), html,
+// plaintext or blame html, optionally at a content address or flagged version
+func (s *Server) GetDocumentContent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := env.SLog(ctx)
+	q := env.Query(ctx)
+
+	docID := chi.URLParam(r, "docID")
+
+	var userID string
+	currentUser, err := env.UserClaim(ctx)
+	if err == nil {
+		userID = currentUser.Id
+	}
+
+	doc, err := query.GetReadableDocumentForUser(q, docID, userID)
+	if !documentAllowed(w, r, doc, err) {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = ContentFormatMarkdown
+	}
+
+	address, err := requestAddress(r, q, docID)
+	if err != nil {
+		log.Error("error getting content address", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	docStore := rogue.NewDocStore(s.S3, s.Query, s.Redis)
+	_, rog, err := docStore.GetCurrentDoc(ctx, docID)
+	if err != nil {
+		log.Error("error getting document from doc store", "error", err)
+		http.NotFound(w, r)
+		return
+	}
+
+	if address == nil {
+		address, err = rog.GetFullAddress()
+		if err != nil {
+			log.Error("error getting full address", "error", err)
+			http.Error(w, "error getting document", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	var content string
+	switch format {
+	case ContentFormatMarkdown:
+		content, err = rog.GetMarkdownAt(address.StartID, address.EndID, *address)
+	case ContentFormatHtml:
+		content, err = rog.GetHtmlAt(address.StartID, address.EndID, address, r.URL.Query().Get("ids") == "true", false)
+	case ContentFormatPlaintext:
+		content, err = rog.GetPlaintext(address.StartID, address.EndID, address)
+	case ContentFormatBlame:
+		content, err = rog.GetHtmlBlame(address.StartID, address.EndID, address, r.URL.Query().Get("ids") == "true", false)
+	default:
+		http.Error(w, fmt.Sprintf("unknown format %q", format), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		log.Error("error getting document content", "format", format, "error", err)
+		http.Error(w, "error getting document content", http.StatusInternalServerError)
+		return
+	}
+
+	render.JSON(w, r, DocumentContent{
+		ID:      doc.ID,
+		Title:   doc.Title,
+		Format:  format,
+		Content: content,
+		Address: address,
+	})
+}
+
+// CompareDocumentVersions returns a unified diff of the document as markdown
+// between two versions. The from and to versions are picked with the
+// fromVersionID, fromEventID or fromAddress params (and the same for to),
+// leaving them out compares against the current version.
+func (s *Server) CompareDocumentVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := env.SLog(ctx)
+
+	docID := chi.URLParam(r, "docID")
+
+	var userID string
+	currentUser, err := env.UserClaim(ctx)
+	if err == nil {
+		userID = currentUser.Id
+	}
+
+	doc, err := query.GetReadableDocumentForUser(env.Query(ctx), docID, userID)
+	if !documentAllowed(w, r, doc, err) {
+		return
+	}
+
+	comparison, err := versions.Compare(ctx, userID, docID, requestVersionRef(r, "from"), requestVersionRef(r, "to"))
+	if err != nil {
+		log.Error("error comparing versions", "error", err)
+		if errors.Is(err, versions.ErrVersionNotFound) || errors.Is(err, versions.ErrInvalidRef) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "error comparing versions", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/x-diff; charset=utf-8")
+	w.Write([]byte(comparison.Markdown))
+}
+
+func requestVersionRef(r *http.Request, prefix string) versions.Ref {
+	params := r.URL.Query()
+	return versions.Ref{
+		FlaggedVersionID: params.Get(prefix + "VersionID"),
+		TimelineEventID:  params.Get(prefix + "EventID"),
+		Address:          params.Get(prefix + "Address"),
+	}
+}
+
+// UpdateDocumentContent applies a markdown diff between two ids, it's the same
+// edit the ai makes when rewriting a selection
+func (s *Server) UpdateDocumentContent(w http.ResponseWriter, r *http.Request) {
+	var req UpdateDocumentRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var beforeID, afterID *v3.ID
+	if req.BeforeID != "" || req.AfterID != "" {
+		before, err := v3.ParseID(req.BeforeID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid beforeId %q", req.BeforeID), http.StatusBadRequest)
+			return
+		}
+
+		after, err := v3.ParseID(req.AfterID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid afterId %q", req.AfterID), http.StatusBadRequest)
+			return
+		}
+
+		beforeID, afterID = &before, &after
+	}
+
+	s.editDocument(w, r, func(authorID string, rog *v3.Rogue) (v3.Op, error) {
+		if beforeID == nil {
+			before, after, err := rog.GetWrappingTotIDs()
+			if err != nil {
+				return nil, err
+			}
+			beforeID, afterID = &before, &after
+		} else {
+			err := checkSelection(rog, *beforeID, *afterID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		mop, _, err := rog.ApplyMarkdownDiff(authorID, req.Markdown, *beforeID, *afterID)
+		return mop, err
+	})
+}
+
+// AppendDocumentContent adds markdown to the end of the document
+func (s *Server) AppendDocumentContent(w http.ResponseWriter, r *http.Request) {
+	var req AppendDocumentRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil || req.Markdown == "" {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	s.editDocument(w, r, func(authorID string, rog *v3.Rogue) (v3.Op, error) {
+		mop, _, err := rog.InsertMarkdown(rog.VisSize, req.Markdown)
+		return mop, err
+	})
+}
+
+// editDocument checks the user can edit the document, applies fn to the
+// current doc as the user's api author and persists and publishes the op so open
+// editors see the change live. The updated document is returned as markdown.
+func (s *Server) editDocument(w http.ResponseWriter, r *http.Request, fn func(authorID string, rog *v3.Rogue) (v3.Op, error)) {
+	ctx := r.Context()
+	log := env.SLog(ctx)
+	q := env.Query(ctx)
+
+	docID := chi.URLParam(r, "docID")
+
+	currentUser, err := env.UserClaim(ctx)
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	doc, err := query.GetEditableDocumentForUser(q, docID, currentUser.Id)
+	if !documentAllowed(w, r, doc, err) {
+		return
+	}
+
+	authorID, err := document.APIAuthorID(ctx, docID, currentUser.Id)
+	if err != nil {
+		log.Error("error getting author id", "error", err)
+		http.Error(w, "error editing document", http.StatusInternalServerError)
+		return
+	}
+
+	// two edits as the same author at once would generate the same ids
+	lockKey := fmt.Sprintf(constants.DocAuthorEditLockKey, docID, authorID)
+	locked, err := s.Redis.SetNX(ctx, lockKey, "1", editLockTimeout).Result()
+	if err != nil {
+		log.Error("error locking author", "error", err)
+		http.Error(w, "error editing document", http.StatusInternalServerError)
+		return
+	}
+	if !locked {
+		http.Error(w, "another edit to this document is in progress", http.StatusConflict)
+		return
+	}
+	defer s.Redis.Del(ctx, lockKey)
+
+	docStore := rogue.NewDocStore(s.S3, s.Query, s.Redis)
+	_, rog, err := docStore.GetCurrentDoc(ctx, docID)
+	if err != nil {
+		log.Error("error getting document from doc store", "error", err)
+		http.NotFound(w, r)
+		return
+	}
+	rog.Author = authorID
+
+	op, err := fn(authorID, rog)
+	if err != nil {
+		log.Error("error editing document", "docID", docID, "error", err)
+		if errors.Is(err, errInvalidSelection) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "error editing document", http.StatusInternalServerError)
+		return
+	}
+
+	err = rogue.CommitOp(ctx, docID, op)
+	if err != nil {
+		log.Error("error committing op", "docID", docID, "error", err)
+		http.Error(w, "error editing document", http.StatusInternalServerError)
+		return
+	}
+
+	address, err := rog.GetFullAddress()
+	if err != nil {
+		log.Error("error getting full address", "error", err)
+		http.Error(w, "error getting document", http.StatusInternalServerError)
+		return
+	}
+
+	content, err := rog.GetFullMarkdown()
+	if err != nil {
+		log.Error("error getting markdown", "error", err)
+		http.Error(w, "error getting document", http.StatusInternalServerError)
+		return
+	}
+
+	render.JSON(w, r, DocumentContent{
+		ID:      doc.ID,
+		Title:   doc.Title,
+		Format:  ContentFormatMarkdown,
+		Content: content,
+		Address: address,
+	})
+}
+
+// checkSelection makes sure the ids bounding an edit are in the document and
+// in order, anything else that goes wrong applying the edit isn't the
+// caller's fault
+func checkSelection(rog *v3.Rogue, beforeID, afterID v3.ID) error {
+	_, beforeIx, err := rog.Rope.GetIndex(beforeID)
+	if err != nil {
+		return fmt.Errorf("%w: beforeId %s isn't in the document", errInvalidSelection, beforeID)
+	}
+
+	_, afterIx, err := rog.Rope.GetIndex(afterID)
+	if err != nil {
+		return fmt.Errorf("%w: afterId %s isn't in the document", errInvalidSelection, afterID)
+	}
+
+	if beforeIx >= afterIx {
+		return fmt.Errorf("%w: beforeId must come before afterId", errInvalidSelection)
+	}
+
+	return nil
+}
+
+// documentAllowed writes the error response for a failed document lookup and
+// reports whether the handler can carry on
+func documentAllowed(w http.ResponseWriter, r *http.Request, doc *models.Document, err error) bool {
+	if err != nil {
+		var accessErr *query.AccessDeniedError
+		if errors.As(err, &accessErr) {
+			env.SLog(r.Context()).Error("error getting document (access denied)", "error", err)
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return false
+		}
+
+		env.SLog(r.Context()).Error("error getting document", "error", err)
+	}
+
+	if doc == nil || err != nil {
+		http.NotFound(w, r)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,166 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fivetentaylor/pointy/pkg/constants"
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/server"
+	"github.com/fivetentaylor/pointy/pkg/testutils"
+)
+
+func documentsRouter(ctx context.Context) http.Handler {
+	s := &server.Server{
+		Query: env.Query(ctx),
+		Redis: env.Redis(ctx),
+		S3:    env.S3(ctx),
+	}
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/documents/{docID}", s.GetDocumentContent)
+	r.Patch("/api/v1/documents/{docID}", s.UpdateDocumentContent)
+	r.Post("/api/v1/documents/{docID}", s.AppendDocumentContent)
+	return r
+}
+
+func doDocumentRequest(t *testing.T, ctx context.Context, user *models.User, method, path string, body any) (*httptest.ResponseRecorder, server.DocumentContent) {
+	var buf bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&buf).Encode(body))
+	}
+
+	req := httptest.NewRequest(method, path, &buf)
+	req = req.WithContext(testutils.WithUserClaimForUser(user)(ctx))
+	w := httptest.NewRecorder()
+
+	documentsRouter(ctx).ServeHTTP(w, req)
+
+	var content server.DocumentContent
+	if w.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &content))
+	}
+
+	return w, content
+}
+
+func TestGetDocumentContent(t *testing.T) {
+	testutils.EnsureStorage()
+	ctx := testutils.TestContext()
+
+	docID := uuid.NewString()
+	owner := testutils.CreateUser(t, ctx)
+	stranger := testutils.CreateUser(t, ctx)
+	testutils.CreateTestDocument(t, ctx, docID, "hello world")
+	testutils.AddOwnerToDocument(t, ctx, docID, owner.ID)
+
+	path := fmt.Sprintf("/api/v1/documents/%s", docID)
+
+	w, content := doDocumentRequest(t, ctx, owner, http.MethodGet, path, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, "markdown", content.Format)
+	require.Equal(t, "hello world\n", content.Content)
+	require.NotNil(t, content.Address)
+
+	w, content = doDocumentRequest(t, ctx, owner, http.MethodGet, path+"?format=plaintext", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, "hello world\n", content.Content)
+
+	w, _ = doDocumentRequest(t, ctx, owner, http.MethodGet, path+"?format=pdf", nil)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w, _ = doDocumentRequest(t, ctx, stranger, http.MethodGet, path, nil)
+	require.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestUpdateDocumentContent(t *testing.T) {
+	testutils.EnsureStorage()
+	ctx := testutils.TestContext()
+
+	docID := uuid.NewString()
+	owner := testutils.CreateUser(t, ctx)
+	reader := testutils.CreateUser(t, ctx)
+	rd, _ := testutils.CreateTestDocument(t, ctx, docID, "hello world")
+	testutils.AddOwnerToDocument(t, ctx, docID, owner.ID)
+	testutils.AddUserToDocument(t, ctx, docID, reader.ID, constants.AccessLevelRead)
+
+	path := fmt.Sprintf("/api/v1/documents/%s", docID)
+
+	w, content := doDocumentRequest(t, ctx, owner, http.MethodPatch, path, map[string]string{
+		"markdown": "hello there world",
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, "hello there world\n", content.Content)
+
+	w, content = doDocumentRequest(t, ctx, owner, http.MethodPatch, path, map[string]string{
+		"markdown": "# hello there world",
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, "# hello there world\n", content.Content)
+
+	// both edits were made as the same author
+	q := env.Query(ctx)
+	authors, err := q.AuthorID.
+		Where(q.AuthorID.DocumentID.Eq(docID), q.AuthorID.UserID.Eq(owner.ID)).
+		Count()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), authors)
+
+	before, after, err := rd.GetWrappingTotIDs()
+	require.NoError(t, err)
+
+	w, _ = doDocumentRequest(t, ctx, owner, http.MethodPatch, path, map[string]string{
+		"beforeId": "ff_9999",
+		"afterId":  after.String(),
+		"markdown": "nope",
+	})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w, _ = doDocumentRequest(t, ctx, owner, http.MethodPatch, path, map[string]string{
+		"beforeId": after.String(),
+		"afterId":  before.String(),
+		"markdown": "nope",
+	})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w, _ = doDocumentRequest(t, ctx, reader, http.MethodPatch, path, map[string]string{
+		"markdown": "nope",
+	})
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	w, content = doDocumentRequest(t, ctx, owner, http.MethodGet, path, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, "# hello there world\n", content.Content)
+}
+
+func TestAppendDocumentContent(t *testing.T) {
+	testutils.EnsureStorage()
+	ctx := testutils.TestContext()
+
+	docID := uuid.NewString()
+	owner := testutils.CreateUser(t, ctx)
+	testutils.CreateTestDocument(t, ctx, docID, "hello world")
+	testutils.AddOwnerToDocument(t, ctx, docID, owner.ID)
+
+	path := fmt.Sprintf("/api/v1/documents/%s", docID)
+
+	w, content := doDocumentRequest(t, ctx, owner, http.MethodPost, path, map[string]string{
+		"markdown": "**goodbye**",
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Contains(t, content.Content, "hello world")
+	require.Contains(t, content.Content, "**goodbye**")
+
+	w, _ = doDocumentRequest(t, ctx, owner, http.MethodPost, path, map[string]string{})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
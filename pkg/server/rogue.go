@@ -53,8 +53,9 @@ func (s *Server) RogueWebSocket(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "error checking access", http.StatusInternalServerError)
 		return
 	}
-	hasWriteAccess := count > 0
-	if !hasWriteAccess {
+	// the session enforces the access level, read only users can still connect
+	hasAccess := count > 0
+	if !hasAccess {
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
 		return
 	}
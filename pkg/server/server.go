@@ -29,6 +29,7 @@ import (
 	"github.com/fivetentaylor/pointy/pkg/assets"
 	"github.com/fivetentaylor/pointy/pkg/client"
 	"github.com/fivetentaylor/pointy/pkg/config"
+	"github.com/fivetentaylor/pointy/pkg/constants"
 	"github.com/fivetentaylor/pointy/pkg/env"
 	"github.com/fivetentaylor/pointy/pkg/graph"
 	"github.com/fivetentaylor/pointy/pkg/query"
@@ -171,7 +172,7 @@ func (s *Server) Router() *chi.Mux {
 
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   s.Config.AllowedOrigins,
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "Cookie", "sentry-trace", "baggage"},
 		ExposedHeaders:   []string{"Link", "SetCookie"},
 		AllowCredentials: true,
@@ -190,7 +191,10 @@ func (s *Server) Router() *chi.Mux {
 	r.Get("/src/*", assets.SrcHandler("/src"))
 
 	// UI
-	s.UI(r)
+	r.Group(func(r chi.Router) {
+		r.Use(auth.RejectAPITokens)
+		s.UI(r)
+	})
 
 	// Auth
 	r.Group(s.Auth.Routes)
@@ -199,26 +203,35 @@ func (s *Server) Router() *chi.Mux {
 		r.Post("/", waitlist.AddToWaitlist)
 	})
 
-	// Routes
+	// Routes, api tokens can only be used on the ones registered with
+	// auth.AllowAPIToken. graphql checks each field's scope itself.
 	r.Route("/graphql", func(r chi.Router) {
 		r.Get("/", playground.Handler("GraphQL playground", "/graphql/query"))
-		r.Mount("/query", graph.NewHandler())
+		r.With(auth.AllowAPIToken("")).Mount("/query", graph.NewHandler())
 	})
 
 	// Api
+	readDocs := auth.AllowAPIToken(constants.APITokenScopeReadDocs)
+	writeDocs := auth.AllowAPIToken(constants.APITokenScopeWriteDocs)
 	r.Route("/api/v1", func(r chi.Router) {
-		r.HandleFunc("/documents/{docID}/rogue/ws", s.RogueWebSocket)
+		r.With(readDocs).Get("/documents/{docID}", s.GetDocumentContent)
+		r.With(writeDocs).Patch("/documents/{docID}", s.UpdateDocumentContent)
+		r.With(writeDocs).Post("/documents/{docID}", s.AppendDocumentContent)
+		r.With(readDocs).Get("/documents/{docID}/compare.diff", s.CompareDocumentVersions)
+		r.With(readDocs).HandleFunc("/documents/{docID}/rogue/ws", s.RogueWebSocket)
 		r.HandleFunc("/documents/{docID}/threads/{threadID}/authors/{authorID}/stream", s.StreamingVoice)
-		r.Get("/documents/{docID}/doc.html", s.HtmlDocument)
-		r.Get("/documents/{docID}/editor.html", s.DocumentEditor)
-		r.Post("/documents/{docID}/images/", s.CreateDocumentImage)
-		r.Get("/documents/{docID}/images/{imageID}", s.GetDocumentImage)
+		r.With(readDocs).Get("/documents/{docID}/doc.html", s.HtmlDocument)
+		r.With(readDocs).Get("/documents/{docID}/doc.docx", s.DocxDocument)
+		r.With(readDocs).Get("/documents/{docID}/editor.html", s.DocumentEditor)
+		r.With(writeDocs).Post("/documents/{docID}/images/", s.CreateDocumentImage)
+		r.With(readDocs).Get("/documents/{docID}/images/{imageID}", s.GetDocumentImage)
 		r.Get("/users/{userID}/avatar", s.GetUserAvatar)
 		r.Put("/avatar", s.UpdateUserAvatar)
 	})
 
 	// Admin
 	r.Route("/admin", func(r chi.Router) {
+		r.Use(auth.AllowAPIToken(constants.APITokenScopeAdmin))
 		r.Use(s.Auth.RequireAdmin)
 		r.Use(func(next http.Handler) http.Handler {
 			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -259,6 +272,7 @@ func (s *Server) Router() *chi.Mux {
 	if os.Getenv("ENV") == "test" || true {
 		r.Route("/test", func(r chi.Router) {
 			r.Use(s.Auth.JWTMiddleware)
+			r.Use(auth.RejectAPITokens)
 			r.Post("/documents", s.CreateTestDocument)
 			r.Get("/documents/{docID}.txt", s.TestDocumentText)
 			r.Get("/documents/{docID}.html", s.TestDocumentHTML)
@@ -0,0 +1,239 @@
+package apitokens
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/fivetentaylor/pointy/pkg/constants"
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+)
+
+// lastUsedInterval limits how often a token's last_used_at is written, a
+// script making many requests shouldn't write on every one of them
+const lastUsedInterval = time.Minute
+
+// displayPrefixLen is how much of a token is kept to tell tokens apart in
+// listings, e.g. "pty_3f9a1c2e"
+const displayPrefixLen = len(constants.APITokenPrefix) + 8
+
+var (
+	ErrInvalidToken = errors.New("invalid api token")
+	ErrExpiredToken = errors.New("api token has expired")
+	ErrNotFound     = errors.New("api token not found")
+)
+
+type CreateInput struct {
+	Name        string
+	Scopes      []string
+	DocumentIDs []string
+	ExpiresAt   *time.Time
+}
+
+// IsAPIToken reports whether a bearer token is an api token rather than a jwt
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, constants.APITokenPrefix)
+}
+
+// Create makes a new token for the user, the token itself is only returned
+// here, just its hash is stored
+func Create(ctx context.Context, user *models.User, input CreateInput) (string, *models.APIToken, error) {
+	q := env.Query(ctx)
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return "", nil, fmt.Errorf("token name is required")
+	}
+
+	if len(input.Scopes) == 0 {
+		return "", nil, fmt.Errorf("at least one scope is required")
+	}
+
+	for _, scope := range input.Scopes {
+		if !slices.Contains(constants.APITokenScopes, scope) {
+			return "", nil, fmt.Errorf("unknown scope %q", scope)
+		}
+
+		if scope == constants.APITokenScopeAdmin && !user.Admin {
+			return "", nil, fmt.Errorf("only admins can create tokens with the admin scope")
+		}
+	}
+
+	if input.ExpiresAt != nil && input.ExpiresAt.Before(time.Now()) {
+		return "", nil, fmt.Errorf("expiry must be in the future")
+	}
+
+	if len(input.DocumentIDs) > 0 {
+		docAccessTbl := q.DocumentAccess
+		count, err := docAccessTbl.
+			Where(docAccessTbl.UserID.Eq(user.ID)).
+			Where(docAccessTbl.DocumentID.In(input.DocumentIDs...)).
+			Count()
+		if err != nil {
+			return "", nil, fmt.Errorf("error checking document access: %w", err)
+		}
+
+		if int(count) != len(input.DocumentIDs) {
+			return "", nil, fmt.Errorf("tokens can only be limited to documents you have access to")
+		}
+	}
+
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", nil, fmt.Errorf("error generating token: %w", err)
+	}
+	token := constants.APITokenPrefix + hex.EncodeToString(secret)
+
+	apiToken := &models.APIToken{
+		UserID:      user.ID,
+		Name:        name,
+		TokenHash:   Hash(token),
+		TokenPrefix: token[:displayPrefixLen],
+		Scopes:      strings.Join(input.Scopes, ","),
+	}
+
+	if len(input.DocumentIDs) > 0 {
+		documentIDs := strings.Join(input.DocumentIDs, ",")
+		apiToken.DocumentIds = &documentIDs
+	}
+
+	tokenTbl := q.APIToken
+	omit := tokenTbl.Omit(tokenTbl.LastUsedAt)
+	if input.ExpiresAt != nil {
+		apiToken.ExpiresAt = *input.ExpiresAt
+	} else {
+		omit = tokenTbl.Omit(tokenTbl.LastUsedAt, tokenTbl.ExpiresAt)
+	}
+
+	err = omit.Create(apiToken)
+	if err != nil {
+		return "", nil, fmt.Errorf("error creating token: %w", err)
+	}
+
+	return token, apiToken, nil
+}
+
+// List returns the user's tokens, newest first
+func List(ctx context.Context, userID string) ([]*models.APIToken, error) {
+	tokenTbl := env.Query(ctx).APIToken
+
+	return tokenTbl.
+		Where(tokenTbl.UserID.Eq(userID)).
+		Order(tokenTbl.CreatedAt.Desc()).
+		Find()
+}
+
+// Revoke deletes one of the user's tokens, it stops working immediately
+func Revoke(ctx context.Context, userID, tokenID string) error {
+	tokenTbl := env.Query(ctx).APIToken
+
+	result, err := tokenTbl.
+		Where(tokenTbl.ID.Eq(tokenID)).
+		Where(tokenTbl.UserID.Eq(userID)).
+		Delete()
+	if err != nil {
+		return fmt.Errorf("error revoking token: %w", err)
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Authenticate looks up a token and returns a claim for its user limited to
+// the token's scopes and documents
+func Authenticate(ctx context.Context, token string) (*models.UserClaims, error) {
+	q := env.Query(ctx)
+
+	if !IsAPIToken(token) {
+		return nil, ErrInvalidToken
+	}
+
+	tokenTbl := q.APIToken
+	apiToken, err := tokenTbl.Where(tokenTbl.TokenHash.Eq(Hash(token))).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, fmt.Errorf("error getting token: %w", err)
+	}
+
+	if IsExpired(apiToken) {
+		return nil, ErrExpiredToken
+	}
+
+	userTbl := q.User
+	user, err := userTbl.Where(userTbl.ID.Eq(apiToken.UserID)).First()
+	if err != nil {
+		return nil, fmt.Errorf("error getting token user: %w", err)
+	}
+
+	if time.Since(apiToken.LastUsedAt) > lastUsedInterval {
+		_, err = tokenTbl.
+			Where(tokenTbl.ID.Eq(apiToken.ID)).
+			UpdateColumn(tokenTbl.LastUsedAt, time.Now())
+		if err != nil {
+			env.SLog(ctx).Error("error updating token last used", "tokenID", apiToken.ID, "error", err)
+		}
+	}
+
+	scopes := Scopes(apiToken)
+	claims := &models.UserClaims{
+		Id:          user.ID,
+		Email:       user.Email,
+		Admin:       user.Admin && slices.Contains(scopes, constants.APITokenScopeAdmin),
+		APITokenID:  apiToken.ID,
+		Scopes:      scopes,
+		DocumentIDs: DocumentIDs(apiToken),
+	}
+
+	if !apiToken.ExpiresAt.IsZero() {
+		claims.ExpiresAt = apiToken.ExpiresAt.Unix()
+	}
+
+	return claims, nil
+}
+
+// Hash is how tokens are stored, tokens are random so a plain sha256 is
+// enough
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func IsExpired(apiToken *models.APIToken) bool {
+	return !apiToken.ExpiresAt.IsZero() && apiToken.ExpiresAt.Before(time.Now())
+}
+
+func Scopes(apiToken *models.APIToken) []string {
+	return splitList(apiToken.Scopes)
+}
+
+// DocumentIDs is nil when the token isn't limited to particular documents
+func DocumentIDs(apiToken *models.APIToken) []string {
+	if apiToken.DocumentIds == nil {
+		return nil
+	}
+
+	return splitList(*apiToken.DocumentIds)
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(s, ",")
+}
@@ -0,0 +1,113 @@
+package apitokens_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fivetentaylor/pointy/pkg/constants"
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/service/apitokens"
+	"github.com/fivetentaylor/pointy/pkg/testutils"
+)
+
+func TestAPITokens(t *testing.T) {
+	testutils.EnsureStorage()
+	ctx := testutils.TestContext()
+
+	user := testutils.CreateUser(t, ctx)
+	docID := uuid.NewString()
+	testutils.CreateTestDocument(t, ctx, docID, "hello")
+	testutils.AddOwnerToDocument(t, ctx, docID, user.ID)
+
+	token, apiToken, err := apitokens.Create(ctx, user, apitokens.CreateInput{
+		Name:        "ci",
+		Scopes:      []string{constants.APITokenScopeReadDocs},
+		DocumentIDs: []string{docID},
+	})
+	require.NoError(t, err)
+	assert.True(t, apitokens.IsAPIToken(token))
+	assert.True(t, len(token) > len(apiToken.TokenPrefix))
+	assert.NotContains(t, apiToken.TokenHash, token)
+
+	claims, err := apitokens.Authenticate(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, claims.Id)
+	assert.True(t, claims.IsAPIToken())
+	assert.True(t, claims.HasScope(constants.APITokenScopeReadDocs))
+	assert.False(t, claims.HasScope(constants.APITokenScopeWriteDocs))
+	assert.True(t, claims.CanAccessDocument(docID))
+	assert.False(t, claims.CanAccessDocument(uuid.NewString()))
+
+	tokenTbl := env.Query(ctx).APIToken
+	used, err := tokenTbl.Where(tokenTbl.ID.Eq(apiToken.ID)).First()
+	require.NoError(t, err)
+	assert.False(t, used.LastUsedAt.IsZero())
+
+	tokens, err := apitokens.List(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+
+	err = apitokens.Revoke(ctx, user.ID, apiToken.ID)
+	require.NoError(t, err)
+
+	_, err = apitokens.Authenticate(ctx, token)
+	assert.ErrorIs(t, err, apitokens.ErrInvalidToken)
+
+	err = apitokens.Revoke(ctx, user.ID, apiToken.ID)
+	assert.ErrorIs(t, err, apitokens.ErrNotFound)
+}
+
+func TestCreateAPITokenValidation(t *testing.T) {
+	testutils.EnsureStorage()
+	ctx := testutils.TestContext()
+
+	user := testutils.CreateUser(t, ctx)
+	past := time.Now().Add(-time.Hour)
+
+	cases := map[string]apitokens.CreateInput{
+		"no name":        {Scopes: []string{constants.APITokenScopeReadDocs}},
+		"no scopes":      {Name: "ci"},
+		"unknown scope":  {Name: "ci", Scopes: []string{"everything"}},
+		"admin scope":    {Name: "ci", Scopes: []string{constants.APITokenScopeAdmin}},
+		"expired":        {Name: "ci", Scopes: []string{constants.APITokenScopeReadDocs}, ExpiresAt: &past},
+		"other document": {Name: "ci", Scopes: []string{constants.APITokenScopeReadDocs}, DocumentIDs: []string{uuid.NewString()}},
+	}
+
+	for name, input := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := apitokens.Create(ctx, user, input)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestAuthenticateExpiredToken(t *testing.T) {
+	testutils.EnsureStorage()
+	ctx := testutils.TestContext()
+
+	user := testutils.CreateUser(t, ctx)
+	expiresAt := time.Now().Add(time.Hour)
+
+	token, apiToken, err := apitokens.Create(ctx, user, apitokens.CreateInput{
+		Name:      "ci",
+		Scopes:    []string{constants.APITokenScopeReadDocs},
+		ExpiresAt: &expiresAt,
+	})
+	require.NoError(t, err)
+
+	tokenTbl := env.Query(ctx).APIToken
+	_, err = tokenTbl.
+		Where(tokenTbl.ID.Eq(apiToken.ID)).
+		UpdateColumn(tokenTbl.ExpiresAt, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+
+	_, err = apitokens.Authenticate(ctx, token)
+	assert.ErrorIs(t, err, apitokens.ErrExpiredToken)
+
+	_, err = apitokens.Authenticate(ctx, "not a token")
+	assert.ErrorIs(t, err, apitokens.ErrInvalidToken)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/google/uuid"
+	"github.com/fivetentaylor/pointy/pkg/background/wire"
 	"github.com/fivetentaylor/pointy/pkg/constants"
 	"github.com/fivetentaylor/pointy/pkg/env"
 	"github.com/fivetentaylor/pointy/pkg/models"
@@ -69,5 +70,10 @@ func Upload(ctx context.Context, file graphql.Upload, docID, userID string) (*mo
 		return nil, fmt.Errorf("internal error")
 	}
 
+	_, err = env.Background(ctx).Enqueue(ctx, &wire.EmbedAttachment{AttachmentId: attachment.ID})
+	if err != nil {
+		log.Error("error enqueueing embed attachment job", slog.Any("error", err))
+	}
+
 	return attachment, nil
 }
@@ -0,0 +1,201 @@
+package branches
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/query"
+	"github.com/fivetentaylor/pointy/pkg/rogue"
+	"github.com/fivetentaylor/pointy/pkg/service/document"
+	"github.com/fivetentaylor/pointy/pkg/service/timeline"
+	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+var (
+	ErrNotBranch     = errors.New("document is not a branch")
+	ErrAlreadyMerged = errors.New("branch has already been merged")
+)
+
+// Conflict is a part of the parent that was changed by the merge and had
+// also been edited on the parent since the branch was forked
+type Conflict struct {
+	StartID  v3.ID
+	EndID    v3.ID
+	Html     string
+	DiffHtml string
+}
+
+type MergeResult struct {
+	Parent    *models.Document
+	Branch    *models.Document
+	Conflicts []Conflict
+}
+
+// Merge replays the edits made on the branch since it was forked into its
+// parent. A branch can only be merged once since merging it again would
+// duplicate everything it inserted.
+func Merge(ctx context.Context, userID, branchID string) (*MergeResult, error) {
+	log := env.SLog(ctx)
+	q := env.Query(ctx)
+
+	branch, err := query.GetReadableDocumentForUser(q, branchID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if branch.ParentID == nil || branch.ParentAddress == nil {
+		return nil, ErrNotBranch
+	}
+
+	if branch.MergedAt != nil {
+		return nil, ErrAlreadyMerged
+	}
+
+	parent, err := query.GetEditableDocumentForUser(q, *branch.ParentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := v3.ParseContentAddress(*branch.ParentAddress)
+	if err != nil {
+		return nil, fmt.Errorf("v3.ParseContentAddress(%q): %w", *branch.ParentAddress, err)
+	}
+
+	docStore := rogue.NewDocStore(env.S3(ctx), q, env.Redis(ctx))
+	_, branchDoc, err := docStore.GetCurrentDoc(ctx, branch.ID)
+	if err != nil {
+		return nil, fmt.Errorf("docStore.GetCurrentDoc(ctx, %s): %w", branch.ID, err)
+	}
+
+	_, parentDoc, err := docStore.GetCurrentDoc(ctx, parent.ID)
+	if err != nil {
+		return nil, fmt.Errorf("docStore.GetCurrentDoc(ctx, %s): %w", parent.ID, err)
+	}
+
+	authorID, err := document.NewAuthorID(ctx, parent.ID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("document.NewAuthorID(ctx, %s, %s): %w", parent.ID, userID, err)
+	}
+
+	mop, err := parentDoc.MergeBranch(authorID, base, branchDoc)
+	if err != nil {
+		return nil, fmt.Errorf("MergeBranch(%s, %v): %w", authorID, base, err)
+	}
+
+	result := &MergeResult{
+		Parent:    parent,
+		Branch:    branch,
+		Conflicts: []Conflict{},
+	}
+
+	event := &models.TimelineBranchMerge{
+		BranchId: branch.ID,
+		ParentId: parent.ID,
+	}
+
+	// claim the merge before committing anything so two merges of the same
+	// branch can't both replay it into the parent
+	now := time.Now()
+	docTbl := q.Document
+	info, err := docTbl.
+		Where(docTbl.ID.Eq(branch.ID), docTbl.MergedAt.IsNull()).
+		Updates(map[string]interface{}{
+			"merged_at": now,
+		})
+	if err != nil {
+		return nil, err
+	}
+	if info.RowsAffected == 0 {
+		return nil, ErrAlreadyMerged
+	}
+	branch.MergedAt = &now
+
+	if len(mop.Mops) > 0 {
+		err = rogue.CommitOp(ctx, parent.ID, mop)
+		if err != nil {
+			// nothing was merged, so the branch can be merged again
+			_, unclaimErr := docTbl.
+				Where(docTbl.ID.Eq(branch.ID)).
+				Updates(map[string]interface{}{
+					"merged_at": nil,
+				})
+			if unclaimErr != nil {
+				log.Error("error releasing branch merge", "error", unclaimErr, "branchID", branch.ID)
+			}
+			return nil, err
+		}
+
+		// the op is committed at this point, a failed summary shouldn't fail it
+		mergeSpans, err := parentDoc.SummarizeMerge(mop, base)
+		if err != nil {
+			log.Error("error summarizing branch merge", "error", err, "branchID", branch.ID)
+		}
+
+		for _, span := range mergeSpans {
+			if !span.Concurrent {
+				continue
+			}
+
+			result.Conflicts = append(result.Conflicts, Conflict{
+				StartID:  span.ToStartID,
+				EndID:    span.ToEndID,
+				Html:     span.Html,
+				DiffHtml: span.DiffHtml,
+			})
+		}
+		event.ConcurrentSpans = int32(len(result.Conflicts))
+
+		event.ContentAddressBefore, event.ContentAddressAfter, err = addressesAroundOp(parentDoc, mop)
+		if err != nil {
+			log.Error("error getting branch merge addresses", "error", err, "branchID", branch.ID)
+		}
+	}
+
+	for _, docID := range []string{parent.ID, branch.ID} {
+		err = timeline.CreateTimelineEvent(ctx, &dynamo.TimelineEvent{
+			UserID:   userID,
+			AuthorID: authorID,
+			DocID:    docID,
+			Event: &models.TimelineEventPayload{
+				Payload: &models.TimelineEventPayload_BranchMerge{
+					BranchMerge: event,
+				},
+			},
+		})
+		if err != nil {
+			log.Error("error creating branch merge timeline event", "error", err, "docID", docID)
+		}
+	}
+
+	return result, nil
+}
+
+func addressesAroundOp(doc *v3.Rogue, op v3.Op) (before, after string, err error) {
+	beforeAddress, err := doc.AddressBeforeOp(op)
+	if err != nil {
+		return "", "", err
+	}
+
+	afterAddress, err := doc.AddressAfterOp(op)
+	if err != nil {
+		return "", "", err
+	}
+
+	beforeBytes, err := json.Marshal(beforeAddress)
+	if err != nil {
+		return "", "", err
+	}
+
+	afterBytes, err := json.Marshal(afterAddress)
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(beforeBytes), string(afterBytes), nil
+}
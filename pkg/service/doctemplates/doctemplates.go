@@ -0,0 +1,296 @@
+package doctemplates
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/query"
+	"github.com/fivetentaylor/pointy/pkg/rogue"
+	"github.com/fivetentaylor/pointy/pkg/service/document"
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+var (
+	ErrNotFound       = errors.New("template not found")
+	ErrInvalidAddress = errors.New("invalid content address")
+)
+
+// placeholderRegex matches fields like {{client_name}}
+var placeholderRegex = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+
+type SaveInput struct {
+	// Title defaults to the document's title when empty
+	Title       string
+	Description string
+	// Address pins the template to a version of the document, it's the
+	// document as it is now when nil
+	Address *string
+	// Shared lets everyone in the user's domain use the template, it needs
+	// edit access to the document
+	Shared bool
+}
+
+// Placeholders returns the names of the placeholder fields in text, in the
+// order they first appear
+func Placeholders(text string) []string {
+	names := []string{}
+	seen := map[string]bool{}
+
+	for _, match := range placeholderRegex.FindAllStringSubmatch(text, -1) {
+		if seen[match[1]] {
+			continue
+		}
+		seen[match[1]] = true
+		names = append(names, match[1])
+	}
+
+	return names
+}
+
+// PlaceholdersFor splits a template's stored placeholders
+func PlaceholdersFor(tmpl *models.DocumentTemplate) []string {
+	if tmpl.Placeholders == "" {
+		return []string{}
+	}
+
+	return strings.Split(tmpl.Placeholders, ",")
+}
+
+// FillString replaces the placeholders in s that have a value, the rest are
+// left as they are
+func FillString(s string, values map[string]string) string {
+	return placeholderRegex.ReplaceAllStringFunc(s, func(match string) string {
+		name := placeholderRegex.FindStringSubmatch(match)[1]
+		if value, ok := values[name]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// FillPlaceholders replaces the placeholders in the document that have a
+// value, returning the ops that did it. The placeholders are replaced from
+// the end of the document so the earlier indexes stay put.
+func FillPlaceholders(r *v3.Rogue, values map[string]string) (v3.MultiOp, error) {
+	mop := v3.MultiOp{}
+	text := r.GetText()
+	matches := placeholderRegex.FindAllStringSubmatchIndex(text, -1)
+
+	for i := len(matches) - 1; i >= 0; i-- {
+		match := matches[i]
+		value, ok := values[text[match[2]:match[3]]]
+		if !ok {
+			continue
+		}
+
+		// rogue indexes are utf16
+		ix := len(v3.StrToUint16(text[:match[0]]))
+		length := len(v3.StrToUint16(text[match[0]:match[1]]))
+
+		op, err := r.Delete(ix, length)
+		if err != nil {
+			return mop, fmt.Errorf("r.Delete(%d, %d): %w", ix, length, err)
+		}
+		mop.Mops = append(mop.Mops, op)
+
+		if value == "" {
+			continue
+		}
+
+		insertOp, err := r.Insert(ix, value)
+		if err != nil {
+			return mop, fmt.Errorf("r.Insert(%d, %q): %w", ix, value, err)
+		}
+		mop.Mops = append(mop.Mops, insertOp)
+	}
+
+	return mop, nil
+}
+
+// Save saves a document, or a version of it, as a template
+func Save(ctx context.Context, userID, docID string, input SaveInput) (*models.DocumentTemplate, error) {
+	q := env.Query(ctx)
+
+	// anyone who can read a document can keep a private copy of it, but
+	// handing it to everyone else takes edit access
+	var doc *models.Document
+	var err error
+	if input.Shared {
+		doc, err = query.GetEditableDocumentForUser(q, docID, userID)
+	} else {
+		doc, err = query.GetReadableDocumentForUser(q, docID, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	docStore := rogue.NewDocStore(env.S3(ctx), q, env.Redis(ctx))
+	_, r, err := docStore.GetCurrentDoc(ctx, docID)
+	if err != nil {
+		return nil, fmt.Errorf("docStore.GetCurrentDoc(ctx, %s): %w", docID, err)
+	}
+
+	var address *v3.ContentAddress
+	if input.Address != nil {
+		address, err = v3.ParseContentAddress(*input.Address)
+		if err != nil || !r.ValidAddress(*address) {
+			return nil, ErrInvalidAddress
+		}
+	} else {
+		address, err = r.GetFullAddress()
+		if err != nil {
+			return nil, fmt.Errorf("r.GetFullAddress(): %w", err)
+		}
+	}
+
+	addressBytes, err := json.Marshal(address)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling address: %w", err)
+	}
+
+	compacted, err := r.Compact(address)
+	if err != nil {
+		return nil, fmt.Errorf("r.Compact(%v): %w", address, err)
+	}
+
+	title := input.Title
+	if title == "" {
+		title = doc.Title
+	}
+
+	tmpl := &models.DocumentTemplate{
+		UserID:       userID,
+		DocumentID:   docID,
+		Address:      string(addressBytes),
+		Title:        title,
+		Description:  input.Description,
+		Placeholders: strings.Join(Placeholders(title+"\n"+compacted.GetText()), ","),
+		Shared:       input.Shared,
+	}
+
+	err = q.DocumentTemplate.Create(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("error creating template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// visibleTemplates is the user's own templates and the shared templates of
+// everyone in their domain
+func visibleTemplates(ctx context.Context, userID string) *gorm.DB {
+	db := env.RawDB(ctx)
+
+	return db.Model(&models.DocumentTemplate{}).Where(
+		"(user_id = ? OR (shared AND user_id IN (?)))",
+		userID, query.UsersInDomainIDs(db, userID),
+	)
+}
+
+// List returns the templates the user can use, by title
+func List(ctx context.Context, userID string) ([]*models.DocumentTemplate, error) {
+	tmpls := []*models.DocumentTemplate{}
+
+	err := visibleTemplates(ctx, userID).Order("title, created_at").Find(&tmpls).Error
+	if err != nil {
+		return nil, fmt.Errorf("error listing templates: %w", err)
+	}
+
+	return tmpls, nil
+}
+
+// Get returns a template the user can use
+func Get(ctx context.Context, userID, id string) (*models.DocumentTemplate, error) {
+	tmpl := &models.DocumentTemplate{}
+
+	err := visibleTemplates(ctx, userID).Where("id = ?", id).First(tmpl).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// Delete deletes one of the user's templates, the document it was saved from
+// is left alone
+func Delete(ctx context.Context, userID, id string) error {
+	tbl := env.Query(ctx).DocumentTemplate
+
+	info, err := tbl.Where(tbl.ID.Eq(id), tbl.UserID.Eq(userID)).Delete()
+	if err != nil {
+		return fmt.Errorf("error deleting template: %w", err)
+	}
+
+	if info.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// CreateDocument makes a new document for the user from a template, with its
+// placeholders filled in from values. The template's content is copied
+// without its history, so the filled in values are the first edits on it.
+func CreateDocument(ctx context.Context, userID, templateID string, values map[string]string) (*models.Document, error) {
+	q := env.Query(ctx)
+
+	tmpl, err := Get(ctx, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := document.CreateCustom(ctx, userID, &models.Document{
+		Title: FillString(tmpl.Title, values),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating document: %w", err)
+	}
+
+	docStore := rogue.NewDocStore(env.S3(ctx), q, env.Redis(ctx))
+	err = docStore.DuplicateDoc(ctx, tmpl.DocumentID, doc.ID, &tmpl.Address)
+	if err != nil {
+		return nil, fmt.Errorf("docStore.DuplicateDoc(ctx, %s, %s): %w", tmpl.DocumentID, doc.ID, err)
+	}
+
+	if len(values) == 0 {
+		return doc, nil
+	}
+
+	_, r, err := docStore.GetCurrentDoc(ctx, doc.ID)
+	if err != nil {
+		return nil, fmt.Errorf("docStore.GetCurrentDoc(ctx, %s): %w", doc.ID, err)
+	}
+
+	authorID, err := document.NewAuthorID(ctx, doc.ID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("document.NewAuthorID(ctx, %s, %s): %w", doc.ID, userID, err)
+	}
+	r.Author = authorID
+
+	mop, err := FillPlaceholders(r, values)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(mop.Mops) == 0 {
+		return doc, nil
+	}
+
+	err = rogue.CommitOp(ctx, doc.ID, mop)
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
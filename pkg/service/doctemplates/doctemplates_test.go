@@ -0,0 +1,96 @@
+package doctemplates_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fivetentaylor/pointy/pkg/constants"
+	"github.com/fivetentaylor/pointy/pkg/service/doctemplates"
+	"github.com/fivetentaylor/pointy/pkg/testutils"
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+func TestPlaceholders(t *testing.T) {
+	assert.Equal(t, []string{}, doctemplates.Placeholders("no fields here"))
+	assert.Equal(t,
+		[]string{"client_name", "date"},
+		doctemplates.Placeholders("Dear {{client_name}}, on {{ date }} {{client_name}} agreed to {{not a field}}"),
+	)
+}
+
+func TestFillString(t *testing.T) {
+	values := map[string]string{"client_name": "Acme", "empty": ""}
+
+	assert.Equal(t, "Proposal for Acme", doctemplates.FillString("Proposal for {{client_name}}", values))
+	assert.Equal(t, "Proposal  for {{other}}", doctemplates.FillString("Proposal {{empty}} for {{other}}", values))
+}
+
+func TestFillPlaceholders(t *testing.T) {
+	doc := v3.NewRogueForQuill("0")
+	_, err := doc.Insert(0, "Hi {{name}} 👋 {{ name }}, see {{other}} by {{date}}")
+	require.NoError(t, err)
+
+	mop, err := doctemplates.FillPlaceholders(doc, map[string]string{
+		"name": "Ada",
+		"date": "",
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, mop.Mops, 5)
+	assert.Equal(t, "Hi Ada 👋 Ada, see {{other}} by \n", doc.GetText())
+}
+
+func TestGetVisibility(t *testing.T) {
+	testutils.EnsureStorage()
+	ctx := testutils.TestContext()
+
+	owner := testutils.CreateUser(t, ctx)
+	reader := testutils.CreateUser(t, ctx)
+	stranger := testutils.CreateUser(t, ctx)
+
+	docID := uuid.NewString()
+	testutils.CreateTestDocument(t, ctx, docID, "Dear {{name}},")
+	testutils.AddOwnerToDocument(t, ctx, docID, owner.ID)
+	testutils.AddUserToDocument(t, ctx, docID, reader.ID, constants.AccessLevelRead)
+
+	otherDocID := uuid.NewString()
+	testutils.CreateTestDocument(t, ctx, otherDocID, "")
+	testutils.AddOwnerToDocument(t, ctx, otherDocID, stranger.ID)
+
+	private, err := doctemplates.Save(ctx, owner.ID, docID, doctemplates.SaveInput{Title: "private"})
+	require.NoError(t, err)
+
+	shared, err := doctemplates.Save(ctx, owner.ID, docID, doctemplates.SaveInput{Title: "shared", Shared: true})
+	require.NoError(t, err)
+
+	// readers can keep their own copy but can't share it
+	_, err = doctemplates.Save(ctx, reader.ID, docID, doctemplates.SaveInput{Shared: true})
+	require.Error(t, err)
+
+	readerCopy, err := doctemplates.Save(ctx, reader.ID, docID, doctemplates.SaveInput{Title: "mine"})
+	require.NoError(t, err)
+
+	tmpl, err := doctemplates.Get(ctx, owner.ID, private.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "private", tmpl.Title)
+
+	tmpl, err = doctemplates.Get(ctx, reader.ID, shared.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name"}, doctemplates.PlaceholdersFor(tmpl))
+
+	_, err = doctemplates.Get(ctx, reader.ID, private.ID)
+	assert.ErrorIs(t, err, doctemplates.ErrNotFound)
+
+	_, err = doctemplates.Get(ctx, owner.ID, readerCopy.ID)
+	assert.ErrorIs(t, err, doctemplates.ErrNotFound)
+
+	_, err = doctemplates.Get(ctx, stranger.ID, shared.ID)
+	assert.ErrorIs(t, err, doctemplates.ErrNotFound)
+
+	tmpls, err := doctemplates.List(ctx, stranger.ID)
+	require.NoError(t, err)
+	assert.Empty(t, tmpls)
+}
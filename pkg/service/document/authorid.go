@@ -2,12 +2,21 @@ package document
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
+	"github.com/redis/go-redis/v9"
+
+	"github.com/fivetentaylor/pointy/pkg/constants"
 	"github.com/fivetentaylor/pointy/pkg/env"
 )
 
+// apiAuthorTTL is how long an unused api author is kept before the user's
+// next api edit gets a new one
+const apiAuthorTTL = 30 * 24 * time.Hour
+
 const newAuthorIDSQL = `
 INSERT INTO author_ids (author_id, document_id, user_id)
 VALUES (increment_author_id(?), ?, ?)
@@ -25,6 +34,38 @@ func NewAuthorID(ctx context.Context, docID, userID string) (string, error) {
 	return strconv.FormatInt(int64(newAuthorID), 16), nil
 }
 
+// APIAuthorID returns the author the user's api edits to the document are
+// made as. It's the same author every time, but never one of their editor
+// sessions' authors so the ids the two generate can't collide.
+func APIAuthorID(ctx context.Context, docID, userID string) (string, error) {
+	rds := env.Redis(ctx)
+	key := fmt.Sprintf(constants.DocUserAPIAuthorKey, docID, userID)
+
+	authorID, err := rds.GetEx(ctx, key, apiAuthorTTL).Result()
+	if err == nil {
+		return authorID, nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		return "", fmt.Errorf("error getting api author: %w", err)
+	}
+
+	authorID, err = NewAuthorID(ctx, docID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	ok, err := rds.SetNX(ctx, key, authorID, apiAuthorTTL).Result()
+	if err != nil {
+		return "", fmt.Errorf("error setting api author: %w", err)
+	}
+	if !ok {
+		// another request created one first
+		return rds.Get(ctx, key).Result()
+	}
+
+	return authorID, nil
+}
+
 func ValidateAuthorID(ctx context.Context, authorID, docID, userID string) (bool, error) {
 	authorIDInt, err := strconv.ParseInt(authorID, 16, 32)
 	if err != nil {
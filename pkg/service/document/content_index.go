@@ -0,0 +1,167 @@
+package document
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+const contentIndexBatchSize = 500
+
+// ContentSearchResult is a document whose contents matched a search, along
+// with the best matching line so the client can jump to it
+type ContentSearchResult struct {
+	Document *models.Document
+	Rank     float64
+	Snippet  string
+	StartID  string
+	EndID    string
+}
+
+type contentLine struct {
+	DocumentID string `gorm:"column:document_id"`
+	Position   int    `gorm:"column:position"`
+	StartID    string `gorm:"column:start_id"`
+	EndID      string `gorm:"column:end_id"`
+	Content    string `gorm:"column:content"`
+}
+
+// IndexContent replaces the searchable contents of a document with the lines
+// of doc, it's called whenever a snapshot of the doc is saved
+func IndexContent(ctx context.Context, docID string, doc *v3.Rogue) error {
+	lines, err := doc.GetTextLines()
+	if err != nil {
+		return fmt.Errorf("error getting text lines: %w", err)
+	}
+
+	rows := make([]contentLine, len(lines))
+	for i, line := range lines {
+		rows[i] = contentLine{
+			DocumentID: docID,
+			Position:   i,
+			StartID:    line.StartID.String(),
+			EndID:      line.EndID.String(),
+			Content:    line.Text,
+		}
+	}
+
+	return env.RawDB(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("DELETE FROM document_content_lines WHERE document_id = ?", docID).Error
+		if err != nil {
+			return err
+		}
+
+		if len(rows) == 0 {
+			return nil
+		}
+
+		return tx.Table("document_content_lines").CreateInBatches(rows, contentIndexBatchSize).Error
+	})
+}
+
+// SearchContent ranks the documents the user can access by how well their
+// contents match query, query supports the web search syntax e.g. "exact
+// phrase", or, -exclude
+func SearchContent(ctx context.Context, query string, userID string, limit, offset int) ([]*ContentSearchResult, error) {
+	baseQuery := `
+    WITH q AS (
+        SELECT websearch_to_tsquery('english', ?) AS query
+    ), matches AS (
+        SELECT
+            dcl.document_id,
+            dcl.start_id,
+            dcl.end_id,
+            dcl.content,
+            ts_rank(dcl.content_tsv, q.query) AS rank,
+            row_number() OVER (
+                PARTITION BY dcl.document_id
+                ORDER BY ts_rank(dcl.content_tsv, q.query) DESC, dcl.position
+            ) AS rn,
+            sum(ts_rank(dcl.content_tsv, q.query)) OVER (PARTITION BY dcl.document_id) AS doc_rank
+        FROM document_content_lines dcl
+        CROSS JOIN q
+        JOIN document_access da ON da.document_id = dcl.document_id
+        JOIN documents d ON d.id = dcl.document_id
+        WHERE da.user_id = ?
+        AND d.is_folder = false
+        AND d.deleted_at IS NULL
+        AND dcl.content_tsv @@ q.query
+    )
+    SELECT
+        m.document_id,
+        m.doc_rank AS rank,
+        ts_headline('english', m.content, q.query, 'StartSel=' || chr(2) || ', StopSel=' || chr(3) || ', MaxFragments=2') AS snippet,
+        m.start_id,
+        m.end_id
+    FROM matches m
+    CROSS JOIN q
+    WHERE m.rn = 1
+    ORDER BY m.doc_rank DESC, m.document_id
+    LIMIT ? OFFSET ?
+  `
+
+	var rows []struct {
+		DocumentID string
+		Rank       float64
+		Snippet    string
+		StartID    string
+		EndID      string
+	}
+
+	db := env.RawDB(ctx)
+	err := db.Raw(baseQuery, query, userID, limit, offset).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return []*ContentSearchResult{}, nil
+	}
+
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.DocumentID
+	}
+
+	var documents []*models.Document
+	err = db.Where("id IN ?", ids).Find(&documents).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*models.Document, len(documents))
+	for _, doc := range documents {
+		byID[doc.ID] = doc
+	}
+
+	results := make([]*ContentSearchResult, 0, len(rows))
+	for _, row := range rows {
+		doc, ok := byID[row.DocumentID]
+		if !ok {
+			continue
+		}
+
+		results = append(results, &ContentSearchResult{
+			Document: doc,
+			Rank:     row.Rank,
+			Snippet:  highlightSnippet(row.Snippet),
+			StartID:  row.StartID,
+			EndID:    row.EndID,
+		})
+	}
+
+	return results, nil
+}
+
+// highlightSnippet escapes the snippet for html and wraps the matches, which
+// ts_headline marks with control characters, in <mark> tags
+func highlightSnippet(snippet string) string {
+	return strings.NewReplacer("\x02", "<mark>", "\x03", "</mark>").Replace(html.EscapeString(snippet))
+}
@@ -0,0 +1,63 @@
+package document_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fivetentaylor/pointy/pkg/service/document"
+	"github.com/fivetentaylor/pointy/pkg/testutils"
+)
+
+func TestSearchContent(t *testing.T) {
+	testutils.EnsureStorage()
+	ctx := testutils.TestContext()
+
+	owner := testutils.CreateUser(t, ctx)
+	other := testutils.CreateUser(t, ctx)
+
+	docID := uuid.New().String()
+	doc, _ := testutils.CreateTestDocument(t, ctx, docID, "Quarterly plan\nWe will ship <search> in the spring\nunrelated line")
+	testutils.AddOwnerToDocument(t, ctx, docID, owner.ID)
+
+	err := document.IndexContent(ctx, docID, doc)
+	require.NoError(t, err)
+
+	results, err := document.SearchContent(ctx, "shipping search", owner.ID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	result := results[0]
+	assert.Equal(t, docID, result.Document.ID)
+	assert.Greater(t, result.Rank, 0.0)
+	assert.Contains(t, result.Snippet, "<mark>ship</mark>")
+	assert.Contains(t, result.Snippet, "&lt;<mark>search</mark>&gt;")
+
+	lines, err := doc.GetTextLines()
+	require.NoError(t, err)
+	assert.Equal(t, lines[1].StartID.String(), result.StartID)
+	assert.Equal(t, lines[1].EndID.String(), result.EndID)
+
+	// no access, no results
+	results, err = document.SearchContent(ctx, "shipping search", other.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	// reindexing replaces the old contents
+	_, err = doc.Insert(0, "winter ")
+	require.NoError(t, err)
+	_, err = doc.Delete(22, 35)
+	require.NoError(t, err)
+	err = document.IndexContent(ctx, docID, doc)
+	require.NoError(t, err)
+
+	results, err = document.SearchContent(ctx, "spring", owner.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	results, err = document.SearchContent(ctx, "winter", owner.ID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+}
@@ -0,0 +1,107 @@
+package document
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/fivetentaylor/pointy/pkg/constants"
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/service/images"
+	"github.com/fivetentaylor/pointy/pkg/service/rogue"
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+const MaxImportSize = 50 * 1024 * 1024
+
+type ErrUnsupportedImport struct {
+	Filename string
+}
+
+func (e ErrUnsupportedImport) Error() string {
+	return fmt.Sprintf("unsupported file type: %s", e.Filename)
+}
+
+// Import creates a new document owned by userID from a .docx or .odt file,
+// keeping its headings, lists, span formatting and images
+func Import(ctx context.Context, userID, filename string, data []byte) (*models.Document, error) {
+	log := env.SLog(ctx)
+
+	var parse func([]byte, v3.ImageSaver) (string, []v3.TextSpan, error)
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".docx":
+		parse = v3.ParseDocx
+	case ".odt":
+		parse = v3.ParseOdt
+	default:
+		return nil, ErrUnsupportedImport{Filename: filename}
+	}
+
+	if len(data) > MaxImportSize {
+		return nil, fmt.Errorf("file size exceeds the maximum limit of %d bytes", MaxImportSize)
+	}
+
+	// the id is needed up front so embedded images can be stored under it
+	docID := uuid.NewString()
+	saveImage := func(name string, img []byte) (string, error) {
+		src, err := images.SaveImage(ctx, docID, name, img)
+		if err != nil {
+			// a broken image shouldn't fail the whole import
+			log.Error("error saving imported image", "doc_id", docID, "name", name, "error", err)
+			return "", nil
+		}
+		return src, nil
+	}
+
+	text, spans, err := parse(data, saveImage)
+	if err != nil {
+		log.Error("error parsing imported file", "filename", filename, "error", err)
+		return nil, fmt.Errorf("error parsing %s: %w", filename, err)
+	}
+
+	title := strings.TrimSpace(strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename)))
+	if title == "" {
+		title = constants.DefaultDocumentTitle
+	}
+
+	authorID, err := NewAuthorID(ctx, docID, userID)
+	if err != nil {
+		log.Error("error creating author id", "doc_id", docID, "error", err)
+		return nil, err
+	}
+
+	rd := v3.NewRogueForQuill(authorID)
+	_, err = rd.InsertSpans(0, text, spans)
+	if err != nil {
+		log.Error("error inserting imported content", "doc_id", docID, "error", err)
+		return nil, err
+	}
+
+	// the snapshot is saved before the row exists so a failed save can't
+	// leave an empty document in the user's list
+	err = rogue.SaveDocToS3(ctx, docID, 0, rd)
+	if err != nil {
+		log.Error("error saving imported document", "doc_id", docID, "error", err)
+		return nil, err
+	}
+
+	doc, err := CreateCustom(ctx, userID, &models.Document{ID: docID, Title: title})
+	if err != nil {
+		if err := DeleteStorage(ctx, docID); err != nil {
+			log.Error("error deleting imported document storage", "doc_id", docID, "error", err)
+		}
+		return nil, err
+	}
+
+	err = IndexContent(ctx, doc.ID, rd)
+	if err != nil {
+		log.Error("error indexing imported document", "doc_id", doc.ID, "error", err)
+	}
+
+	log.Info("document imported", "doc_id", doc.ID, "filename", filename, "event", "document_imported")
+	return doc, nil
+}
@@ -0,0 +1,57 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/service/email/templates"
+)
+
+func SendDigest(
+	ctx context.Context,
+	to *models.User,
+	frequency models.DigestFrequency,
+	docs []templates.DigestDocument,
+) error {
+	log := env.Log(ctx)
+	c := env.SES(ctx)
+	from := fmt.Sprintf("Pointy <pointy@%s>", c.EmailDomain())
+
+	period := "daily"
+	if frequency == models.DigestFrequency_DigestHourly {
+		period = "hourly"
+	}
+
+	count := 0
+	for _, doc := range docs {
+		count += len(doc.Items)
+	}
+
+	subject := fmt.Sprintf("Your %s Pointy digest", period)
+	title := fmt.Sprintf("%d updates on your documents", count)
+	if count == 1 {
+		title = "1 update on your documents"
+	}
+	preheader := title
+
+	log.Infof("sending %s digest email to %s", period, to.Email)
+
+	rctx := c.AttachHostValues(ctx)
+
+	htmlBody := &strings.Builder{}
+	err := templates.DigestHTML(preheader, title, docs).Render(rctx, htmlBody)
+	if err != nil {
+		return fmt.Errorf("failed to render digest html: %w", err)
+	}
+
+	textBody := &strings.Builder{}
+	err = templates.DigestText(title, docs).Render(rctx, textBody)
+	if err != nil {
+		return fmt.Errorf("failed to render digest text: %w", err)
+	}
+
+	return c.EnqueueEmail(from, to.Email, subject, textBody.String(), htmlBody.String())
+}
@@ -0,0 +1,47 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/service/email/templates"
+)
+
+func SendTaskReminder(
+	ctx context.Context,
+	to *models.User,
+	tasks []templates.TaskReminderItem,
+) error {
+	log := env.Log(ctx)
+	c := env.SES(ctx)
+	from := fmt.Sprintf("Pointy <pointy@%s>", c.EmailDomain())
+
+	subject := "You have a task due"
+	title := "1 task assigned to you is due"
+	if len(tasks) != 1 {
+		subject = "You have tasks due"
+		title = fmt.Sprintf("%d tasks assigned to you are due", len(tasks))
+	}
+	preheader := title
+
+	log.Infof("sending task reminder email to %s", to.Email)
+
+	rctx := c.AttachHostValues(ctx)
+
+	htmlBody := &strings.Builder{}
+	err := templates.TaskReminderHTML(preheader, title, tasks).Render(rctx, htmlBody)
+	if err != nil {
+		return fmt.Errorf("failed to render task reminder html: %w", err)
+	}
+
+	textBody := &strings.Builder{}
+	err = templates.TaskReminderText(title, tasks).Render(rctx, textBody)
+	if err != nil {
+		return fmt.Errorf("failed to render task reminder text: %w", err)
+	}
+
+	return c.EnqueueEmail(from, to.Email, subject, textBody.String(), htmlBody.String())
+}
@@ -0,0 +1,437 @@
+package embeddings
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+
+	"github.com/fivetentaylor/pointy/pkg/constants"
+	"github.com/fivetentaylor/pointy/pkg/dag"
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/service/attachments"
+	"github.com/fivetentaylor/pointy/pkg/service/rogue"
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+const (
+	// embeddedHashTTL is how long the hash of a document's embedded content
+	// is kept, a document that's edited after that is embedded again, most
+	// of its chunks will reuse their embeddings
+	embeddedHashTTL = 30 * 24 * time.Hour
+
+	// ChunkTokens is the rough size of the pieces documents and attachments
+	// are split into before they're embedded
+	ChunkTokens = 256
+
+	embedBatchSize  = 64
+	insertBatchSize = 100
+)
+
+// Result is a chunk of a document or attachment that matched a search,
+// StartID and EndID are only set for document chunks
+type Result struct {
+	DocumentID   string
+	AttachmentID *string
+	StartID      *string
+	EndID        *string
+	Content      string
+	Score        float64
+}
+
+type embeddingRow struct {
+	DocumentID   string  `gorm:"column:document_id"`
+	AttachmentID *string `gorm:"column:attachment_id"`
+	Position     int     `gorm:"column:position"`
+	StartID      *string `gorm:"column:start_id"`
+	EndID        *string `gorm:"column:end_id"`
+	Content      string  `gorm:"column:content"`
+	Model        string  `gorm:"column:model"`
+	Embedding    vector  `gorm:"column:embedding"`
+}
+
+// vector is stored as a pgvector vector
+type vector []float32
+
+func (v vector) Value() (driver.Value, error) {
+	parts := make([]string, len(v))
+	for i, f := range v {
+		parts[i] = strconv.FormatFloat(float64(f), 'g', -1, 32)
+	}
+
+	return "[" + strings.Join(parts, ",") + "]", nil
+}
+
+func (v *vector) Scan(src any) error {
+	var s string
+	switch src := src.(type) {
+	case string:
+		s = src
+	case []byte:
+		s = string(src)
+	default:
+		return fmt.Errorf("can't scan %T into a vector", src)
+	}
+
+	s = strings.Trim(s, "[]")
+	if s == "" {
+		*v = vector{}
+		return nil
+	}
+
+	parts := strings.Split(s, ",")
+	out := make(vector, len(parts))
+	for i, part := range parts {
+		f, err := strconv.ParseFloat(part, 32)
+		if err != nil {
+			return fmt.Errorf("error parsing vector: %w", err)
+		}
+		out[i] = float32(f)
+	}
+
+	*v = out
+	return nil
+}
+
+// Adapter returns the llm adapter used for embeddings, the provider and model
+// can be overridden with EMBEDDING_PROVIDER and EMBEDDING_MODEL, e.g.
+// EMBEDDING_PROVIDER=test for a local deterministic embedder
+func Adapter() *dag.DefaultLLMAdapter {
+	provider := dag.LLMProvider(os.Getenv("EMBEDDING_PROVIDER"))
+	if provider == "" {
+		provider = dag.OpenAI
+	}
+
+	return &dag.DefaultLLMAdapter{
+		Provider: provider,
+		Model:    os.Getenv("EMBEDDING_MODEL"),
+	}
+}
+
+// modelKey identifies the vector space an embedding is in, vectors from
+// different models can't be compared
+func modelKey(adapter *dag.DefaultLLMAdapter) string {
+	return fmt.Sprintf("%s/%s", adapter.GetProvider(), adapter.Model)
+}
+
+// EmbedDocument replaces the embeddings of a document's content, chunks that
+// haven't changed since the last run reuse their embeddings. Attachments are
+// embedded by EmbedAttachment when they're uploaded.
+func EmbedDocument(ctx context.Context, docID string) error {
+	log := env.SLog(ctx)
+
+	doc, err := rogue.CurrentDocument(ctx, docID)
+	if err != nil {
+		return fmt.Errorf("error loading document: %w", err)
+	}
+
+	rows, err := documentChunks(docID, doc)
+	if err != nil {
+		return err
+	}
+
+	adapter := Adapter()
+	embedded, err := embedRows(ctx, adapter, rows, "document_id = ? AND attachment_id IS NULL", docID)
+	if err != nil {
+		return err
+	}
+
+	// the next index only re-embeds the document if this changes
+	err = env.Redis(ctx).Set(ctx, fmt.Sprintf(constants.DocEmbeddedHashKey, docID), contentHash(adapter, doc), embeddedHashTTL).Err()
+	if err != nil {
+		log.Error("error saving embedded content hash", "doc_id", docID, "error", err)
+	}
+
+	log.Info("document embedded", "doc_id", docID, "chunks", len(rows), "embedded", embedded, "model", modelKey(adapter))
+	return nil
+}
+
+// EmbedAttachment replaces the embeddings of an attachment, an attachment's
+// text doesn't change so it's only embedded once it's uploaded
+func EmbedAttachment(ctx context.Context, attachmentID string) error {
+	log := env.SLog(ctx)
+	attachmentTbl := env.Query(ctx).DocumentAttachment
+
+	file, err := attachmentTbl.Where(attachmentTbl.ID.Eq(attachmentID)).First()
+	if err != nil {
+		return fmt.Errorf("error loading attachment: %w", err)
+	}
+
+	text, err := attachments.ExtractedText(ctx, file)
+	if err != nil {
+		return fmt.Errorf("error extracting attachment text: %w", err)
+	}
+
+	rows := []embeddingRow{}
+	for i, chunk := range ChunkText(text, ChunkTokens) {
+		rows = append(rows, embeddingRow{
+			DocumentID:   file.DocumentID,
+			AttachmentID: &file.ID,
+			Position:     i,
+			Content:      chunk,
+		})
+	}
+
+	adapter := Adapter()
+	embedded, err := embedRows(ctx, adapter, rows, "attachment_id = ?", file.ID)
+	if err != nil {
+		return err
+	}
+
+	log.Info("attachment embedded", "attachment_id", file.ID, "doc_id", file.DocumentID, "chunks", len(rows), "embedded", embedded, "model", modelKey(adapter))
+	return nil
+}
+
+// ContentChanged is true when the document's content, or the embedding
+// model, has changed since the document was last embedded
+func ContentChanged(ctx context.Context, docID string, doc *v3.Rogue) (bool, error) {
+	hash, err := env.Redis(ctx).Get(ctx, fmt.Sprintf(constants.DocEmbeddedHashKey, docID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return true, nil
+	}
+	if err != nil {
+		return true, fmt.Errorf("error getting embedded content hash: %w", err)
+	}
+
+	return hash != contentHash(Adapter(), doc), nil
+}
+
+func contentHash(adapter *dag.DefaultLLMAdapter, doc *v3.Rogue) string {
+	h := sha256.New()
+	h.Write([]byte(modelKey(adapter)))
+	h.Write([]byte{0})
+	h.Write([]byte(doc.GetText()))
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// embedRows replaces the embeddings matching scope with rows, reusing the
+// existing embeddings of any chunk whose content hasn't changed. It returns
+// how many chunks had to be embedded.
+func embedRows(ctx context.Context, adapter *dag.DefaultLLMAdapter, rows []embeddingRow, scope string, args ...any) (int, error) {
+	embedder, err := adapter.GetEmbedder(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error getting embedder: %w", err)
+	}
+	model := modelKey(adapter)
+
+	var existing []embeddingRow
+	err = env.RawDB(ctx).
+		Table("document_embeddings").
+		Select("content, embedding::text AS embedding").
+		Where(scope, args...).
+		Where("model = ?", model).
+		Scan(&existing).Error
+	if err != nil {
+		return 0, fmt.Errorf("error loading existing embeddings: %w", err)
+	}
+
+	known := make(map[string]vector, len(existing))
+	for _, row := range existing {
+		known[row.Content] = row.Embedding
+	}
+
+	missing := []string{}
+	for i := range rows {
+		rows[i].Model = model
+		if v, ok := known[rows[i].Content]; ok {
+			rows[i].Embedding = v
+			continue
+		}
+		missing = append(missing, rows[i].Content)
+		known[rows[i].Content] = nil
+	}
+
+	for start := 0; start < len(missing); start += embedBatchSize {
+		batch := missing[start:min(start+embedBatchSize, len(missing))]
+		vecs, err := embedder.CreateEmbedding(ctx, batch)
+		if err != nil {
+			return 0, fmt.Errorf("error creating embeddings: %w", err)
+		}
+		if len(vecs) != len(batch) {
+			return 0, fmt.Errorf("expected %d embeddings, got %d", len(batch), len(vecs))
+		}
+
+		for i, content := range batch {
+			known[content] = normalize(vecs[i])
+		}
+	}
+
+	for i := range rows {
+		if rows[i].Embedding == nil {
+			rows[i].Embedding = known[rows[i].Content]
+		}
+	}
+
+	err = env.RawDB(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("DELETE FROM document_embeddings WHERE "+scope, args...).Error
+		if err != nil {
+			return err
+		}
+
+		if len(rows) == 0 {
+			return nil
+		}
+
+		return tx.Table("document_embeddings").CreateInBatches(rows, insertBatchSize).Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error saving embeddings: %w", err)
+	}
+
+	return len(missing), nil
+}
+
+func documentChunks(docID string, doc *v3.Rogue) ([]embeddingRow, error) {
+	targets, err := dag.ChunkDocument(doc, ChunkTokens)
+	if err != nil {
+		return nil, fmt.Errorf("error chunking document: %w", err)
+	}
+
+	rows := []embeddingRow{}
+	for _, target := range targets {
+		if strings.TrimSpace(target.Markdown) == "" {
+			continue
+		}
+
+		startID, endID := target.BeforeID.String(), target.AfterID.String()
+		rows = append(rows, embeddingRow{
+			DocumentID: docID,
+			Position:   len(rows),
+			StartID:    &startID,
+			EndID:      &endID,
+			Content:    target.Markdown,
+		})
+	}
+
+	return rows, nil
+}
+
+// ChunkText splits plain text into pieces of about maxWords words, breaking
+// on paragraphs where it can
+func ChunkText(text string, maxWords int) []string {
+	chunks := []string{}
+	current := []string{}
+	size := 0
+
+	flush := func() {
+		if len(current) > 0 {
+			chunks = append(chunks, strings.Join(current, "\n\n"))
+		}
+		current = []string{}
+		size = 0
+	}
+
+	for _, para := range strings.Split(text, "\n\n") {
+		words := strings.Fields(para)
+		if len(words) == 0 {
+			continue
+		}
+
+		// paragraphs longer than a chunk are split on words
+		for len(words) > maxWords {
+			flush()
+			chunks = append(chunks, strings.Join(words[:maxWords], " "))
+			words = words[maxWords:]
+		}
+
+		if size+len(words) > maxWords {
+			flush()
+		}
+		current = append(current, strings.Join(words, " "))
+		size += len(words)
+	}
+	flush()
+
+	return chunks
+}
+
+// normalize scales v to unit length so a dot product is the cosine similarity
+func normalize(v []float32) vector {
+	var sum float64
+	for _, f := range v {
+		sum += float64(f) * float64(f)
+	}
+	if sum == 0 {
+		return v
+	}
+
+	norm := float32(1 / math.Sqrt(sum))
+	out := make(vector, len(v))
+	for i, f := range v {
+		out[i] = f * norm
+	}
+
+	return out
+}
+
+// Search ranks the chunks of the documents the user can access, and of their
+// attachments, by similarity to query
+func Search(ctx context.Context, query, userID string, limit, offset int) ([]*Result, error) {
+	adapter := Adapter()
+	embedder, err := adapter.GetEmbedder(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting embedder: %w", err)
+	}
+
+	vecs, err := embedder.CreateEmbedding(ctx, []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("error embedding query: %w", err)
+	}
+	if len(vecs) != 1 {
+		return nil, fmt.Errorf("expected 1 embedding, got %d", len(vecs))
+	}
+	queryVec := normalize(vecs[0])
+
+	// the dimensions are part of the sql, rather than a parameter, so the
+	// planner can match the query to the model's hnsw index
+	baseQuery := fmt.Sprintf(`
+    SELECT
+        e.document_id,
+        e.attachment_id,
+        e.start_id,
+        e.end_id,
+        e.content,
+        -((e.embedding::vector(%[1]d)) <#> ?::vector(%[1]d)) AS score
+    FROM document_embeddings e
+    JOIN documents d ON d.id = e.document_id
+    WHERE e.model = ?
+    AND vector_dims(e.embedding) = %[1]d
+    AND d.deleted_at IS NULL
+    AND EXISTS (
+        SELECT 1 FROM document_access da
+        WHERE da.document_id = e.document_id AND da.user_id = ?
+    )
+    ORDER BY (e.embedding::vector(%[1]d)) <#> ?::vector(%[1]d)
+    LIMIT ? OFFSET ?
+  `, len(queryVec))
+
+	results := []*Result{}
+	err = env.RawDB(ctx).Transaction(func(tx *gorm.DB) error {
+		// access is checked on the rows the index returns, so keep scanning
+		// the index until there are enough rows the user can see
+		err := tx.Exec("SET LOCAL hnsw.iterative_scan = strict_order").Error
+		if err != nil {
+			return err
+		}
+
+		return tx.
+			Raw(baseQuery, queryVec, modelKey(adapter), userID, queryVec, limit, offset).
+			Scan(&results).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,114 @@
+package embeddings_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fivetentaylor/pointy/pkg/constants"
+	"github.com/fivetentaylor/pointy/pkg/service/embeddings"
+	"github.com/fivetentaylor/pointy/pkg/testutils"
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+func TestChunkText(t *testing.T) {
+	text := "one two three\n\nfour five\n\n\n\nsix seven eight nine ten eleven"
+
+	assert.Equal(t, []string{
+		"one two three\n\nfour five",
+		"six seven eight nine ten",
+		"eleven",
+	}, embeddings.ChunkText(text, 5))
+
+	assert.Empty(t, embeddings.ChunkText(" \n\n ", 5))
+}
+
+func TestEmbedDocumentAndSearch(t *testing.T) {
+	testutils.EnsureStorage()
+	ctx := testutils.TestContext()
+
+	os.Setenv("EMBEDDING_PROVIDER", "test")
+	defer os.Unsetenv("EMBEDDING_PROVIDER")
+
+	owner := testutils.CreateUser(t, ctx)
+	other := testutils.CreateUser(t, ctx)
+
+	docID := uuid.New().String()
+	testutils.CreateTestDocument(t, ctx, docID, strings.Join([]string{
+		"Onboarding churn doubled after we removed the welcome email",
+		"",
+		"The garlic bread recipe needs more butter",
+	}, "\n"))
+	testutils.AddOwnerToDocument(t, ctx, docID, owner.ID)
+
+	err := embeddings.EmbedDocument(ctx, docID)
+	require.NoError(t, err)
+
+	results, err := embeddings.Search(ctx, "where did I write about onboarding churn", owner.ID, 10, 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	assert.Equal(t, docID, results[0].DocumentID)
+	assert.Contains(t, results[0].Content, "Onboarding churn")
+	assert.NotNil(t, results[0].StartID)
+	assert.NotNil(t, results[0].EndID)
+	assert.Nil(t, results[0].AttachmentID)
+
+	// embedding again is idempotent
+	err = embeddings.EmbedDocument(ctx, docID)
+	require.NoError(t, err)
+
+	again, err := embeddings.Search(ctx, "where did I write about onboarding churn", owner.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, len(results), len(again))
+
+	results, err = embeddings.Search(ctx, "onboarding churn", other.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	// anyone the document is shared with can search it
+	testutils.AddUserToDocument(t, ctx, docID, other.ID, constants.AccessLevelRead)
+	results, err = embeddings.Search(ctx, "onboarding churn", other.ID, 10, 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	assert.Equal(t, docID, results[0].DocumentID)
+}
+
+func TestContentChanged(t *testing.T) {
+	testutils.EnsureStorage()
+	ctx := testutils.TestContext()
+
+	os.Setenv("EMBEDDING_PROVIDER", "test")
+	defer os.Unsetenv("EMBEDDING_PROVIDER")
+
+	docID := uuid.New().String()
+	rd, _ := testutils.CreateTestDocument(t, ctx, docID, "Onboarding churn doubled")
+
+	changed, err := embeddings.ContentChanged(ctx, docID, rd)
+	require.NoError(t, err)
+	assert.True(t, changed, "never embedded")
+
+	err = embeddings.EmbedDocument(ctx, docID)
+	require.NoError(t, err)
+
+	changed, err = embeddings.ContentChanged(ctx, docID, rd)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	_, err = rd.Format(0, 10, v3.FormatV3Span{"b": "true"})
+	require.NoError(t, err)
+
+	changed, err = embeddings.ContentChanged(ctx, docID, rd)
+	require.NoError(t, err)
+	assert.False(t, changed, "formatting doesn't change the embedded text")
+
+	_, err = rd.Insert(0, "Retention: ")
+	require.NoError(t, err)
+
+	changed, err = embeddings.ContentChanged(ctx, docID, rd)
+	require.NoError(t, err)
+	assert.True(t, changed)
+}
@@ -11,6 +11,7 @@ import (
 	"image/png"
 	"io"
 	"log/slog"
+	"net/url"
 	"path/filepath"
 	"runtime/debug"
 	"strings"
@@ -22,6 +23,7 @@ import (
 	"github.com/fivetentaylor/pointy/pkg/env"
 	"github.com/fivetentaylor/pointy/pkg/graph/model"
 	"github.com/fivetentaylor/pointy/pkg/stackerr"
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
 )
 
 const MaxFileSize = 10 * 1024 * 1024
@@ -63,6 +65,63 @@ func UploadImage(ctx context.Context, file graphql.Upload, docID string) (img *m
 	return image, nil
 }
 
+// SaveImage processes and stores an image for the document synchronously,
+// unlike UploadImage, and returns the url the image is served from
+func SaveImage(ctx context.Context, docID string, filename string, data []byte) (string, error) {
+	if len(data) > MaxFileSize {
+		return "", stackerr.New(fmt.Errorf("file size exceeds the maximum limit of %d bytes", MaxFileSize))
+	}
+
+	processedImage, mimeType, err := processImage(bytes.NewReader(data), filename)
+	if err != nil {
+		return "", stackerr.New(err)
+	}
+
+	imageID := uuid.NewString()
+	s3 := env.S3(ctx)
+	key := fmt.Sprintf("%s/%s", docID, imageID)
+	err = s3.PutObject(s3.ImagesBucket, key, mimeType, processedImage)
+	if err != nil {
+		return "", stackerr.New(err)
+	}
+
+	return fmt.Sprintf("%s/drafts/%s/images/%s", s3.AppHost, docID, imageID), nil
+}
+
+// DocumentImageLoader loads the document's own images from s3 so they can be
+// embedded in an export, any other src is left as a link
+func DocumentImageLoader(ctx context.Context, docID string) v3.ImageLoader {
+	s3 := env.S3(ctx)
+
+	return func(src string) ([]byte, error) {
+		u, err := url.Parse(src)
+		if err != nil {
+			return nil, nil
+		}
+
+		if app, err := url.Parse(s3.AppHost); err == nil && app.Host != "" && app.Host != u.Host {
+			return nil, nil
+		}
+
+		imageID, ok := strings.CutPrefix(u.Path, fmt.Sprintf("/drafts/%s/images/", docID))
+		if !ok || imageID == "" || strings.Contains(imageID, "/") {
+			return nil, nil
+		}
+
+		key := fmt.Sprintf("%s/%s", docID, imageID)
+		exists, err := s3.Exists(s3.ImagesBucket, key)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			// still processing
+			return nil, nil
+		}
+
+		return s3.GetObject(s3.ImagesBucket, key)
+	}
+}
+
 func GetImage(ctx context.Context, docID string, imageID string) (*model.Image, error) {
 	s3 := env.S3(ctx)
 
@@ -0,0 +1,333 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/query"
+	"github.com/fivetentaylor/pointy/pkg/service/email"
+	"github.com/fivetentaylor/pointy/pkg/service/email/templates"
+	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
+)
+
+const (
+	DigestKindComment   = "comment"
+	DigestKindMention   = "mention"
+	DigestKindShare     = "share"
+	DigestKindFirstOpen = "first_open"
+)
+
+// DigestSweepInterval is how often the worker checks for digests to send
+const DigestSweepInterval = 10 * time.Minute
+
+// DailyDigestHour is the hour of the day, in UTC, daily digests are sent
+const DailyDigestHour = 14
+
+// DigestEntry is a notification waiting to go out in a user's next digest
+type DigestEntry struct {
+	Kind  string `json:"kind"`
+	DocID string `json:"docID"`
+	// EventID is the timeline event, first opens don't have one
+	EventID    string `json:"eventID,omitempty"`
+	FromUserID string `json:"fromUserID"`
+}
+
+func digestUsersKey(freq models.DigestFrequency) string {
+	return fmt.Sprintf("notifications:digest:users:%s", freq)
+}
+
+func digestEntriesKey(userID string) string {
+	return fmt.Sprintf("notifications:digest:entries:%s", userID)
+}
+
+func digestSentKey(freq models.DigestFrequency, period string) string {
+	return fmt.Sprintf("notifications:digest:sent:%s:%s", freq, period)
+}
+
+// DigestPeriod names the period the digests of freq sent at now belong to,
+// ok is false if they aren't sent at now
+func DigestPeriod(freq models.DigestFrequency, now time.Time) (period string, ok bool) {
+	now = now.UTC()
+
+	switch freq {
+	case models.DigestFrequency_DigestHourly:
+		return now.Truncate(time.Hour).Format(time.RFC3339), true
+	case models.DigestFrequency_DigestDaily:
+		if now.Hour() < DailyDigestHour {
+			return "", false
+		}
+		return now.Format(time.DateOnly), true
+	}
+
+	return "", false
+}
+
+// GroupDigest drops the entries that were seen or repeated and groups the
+// rest by document, in the order the documents first appear
+func GroupDigest(entries []DigestEntry, seen func(DigestEntry) bool) [][]DigestEntry {
+	groups := [][]DigestEntry{}
+	docIxs := map[string]int{}
+	added := map[DigestEntry]bool{}
+
+	for _, entry := range entries {
+		if added[entry] || seen(entry) {
+			continue
+		}
+		added[entry] = true
+
+		ix, ok := docIxs[entry.DocID]
+		if !ok {
+			ix = len(groups)
+			docIxs[entry.DocID] = ix
+			groups = append(groups, nil)
+		}
+		groups[ix] = append(groups[ix], entry)
+	}
+
+	return groups
+}
+
+// queueForDigest adds a notification to the user's next digest rather than
+// emailing it now, queued is false if the user wants their emails straight away
+func queueForDigest(ctx context.Context, userID string, entry DigestEntry) (queued bool, err error) {
+	pref, err := env.Dynamo(ctx).GetUserPreference(userID)
+	if err != nil {
+		return false, err
+	}
+
+	freq := pref.Preference.DigestFrequency
+	if freq == models.DigestFrequency_DigestImmediate {
+		return false, nil
+	}
+
+	bts, err := json.Marshal(entry)
+	if err != nil {
+		return false, err
+	}
+
+	rdb := env.Redis(ctx)
+	_, err = rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, digestEntriesKey(userID), redis.Z{
+			Score:  float64(time.Now().UnixMilli()),
+			Member: string(bts),
+		})
+		pipe.SAdd(ctx, digestUsersKey(freq), userID)
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// sendOrQueue is true when the notification should be emailed now, otherwise
+// it's been queued for the user's digest. Queueing failures send it now.
+func sendOrQueue(ctx context.Context, userID string, entry DigestEntry) bool {
+	queued, err := queueForDigest(ctx, userID, entry)
+	if err != nil {
+		env.Log(ctx).Errorf("error queueing %s notification for digest: %s", entry.Kind, err)
+		return true
+	}
+
+	return !queued
+}
+
+// SendDigests emails the users whose digest is due at now everything that
+// was queued for them. It's safe to call as often as needed, each period's
+// digests are only sent once.
+func SendDigests(ctx context.Context, now time.Time) error {
+	log := env.Log(ctx)
+	rdb := env.Redis(ctx)
+
+	for _, freq := range []models.DigestFrequency{
+		models.DigestFrequency_DigestHourly,
+		models.DigestFrequency_DigestDaily,
+	} {
+		period, ok := DigestPeriod(freq, now)
+		if !ok {
+			continue
+		}
+
+		ok, err := rdb.SetNX(ctx, digestSentKey(freq, period), "1", 48*time.Hour).Result()
+		if err != nil {
+			return fmt.Errorf("error locking %s digests: %w", freq, err)
+		}
+
+		if !ok {
+			continue
+		}
+
+		userIDs, err := rdb.SMembers(ctx, digestUsersKey(freq)).Result()
+		if err != nil {
+			return fmt.Errorf("error getting %s digest users: %w", freq, err)
+		}
+
+		log.Info("📣 sending digests", "frequency", freq, "period", period, "users", len(userIDs))
+
+		for _, userID := range userIDs {
+			err := sendDigest(ctx, userID, freq, now)
+			if err != nil {
+				log.Errorf("error sending digest to %s: %s", userID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func sendDigest(ctx context.Context, userID string, freq models.DigestFrequency, now time.Time) error {
+	rdb := env.Redis(ctx)
+	dydb := env.Dynamo(ctx)
+	usersKey := digestUsersKey(freq)
+	entriesKey := digestEntriesKey(userID)
+
+	// the user may have changed how often they want digests since the
+	// entries were queued
+	pref, err := dydb.GetUserPreference(userID)
+	if err != nil {
+		return err
+	}
+
+	current := pref.Preference.DigestFrequency
+	if current != freq && current != models.DigestFrequency_DigestImmediate {
+		_, err = rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.SRem(ctx, usersKey, userID)
+			pipe.SAdd(ctx, digestUsersKey(current), userID)
+			return nil
+		})
+		return err
+	}
+
+	until := strconv.FormatInt(now.UnixMilli(), 10)
+	members, err := rdb.ZRangeByScore(ctx, entriesKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: until,
+	}).Result()
+	if err != nil {
+		return err
+	}
+
+	entries := make([]DigestEntry, 0, len(members))
+	for _, member := range members {
+		var entry DigestEntry
+		if err := json.Unmarshal([]byte(member), &entry); err != nil {
+			env.Log(ctx).Errorf("error unmarshalling digest entry %q: %s", member, err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	groups := GroupDigest(entries, func(entry DigestEntry) bool {
+		if entry.EventID == "" {
+			return false
+		}
+
+		rr, err := dydb.GetReadReceipt(userID, entry.EventID)
+		if err != nil {
+			if !errors.Is(err, dynamo.ErrReadReceiptNotFound) {
+				env.Log(ctx).Errorf("error getting read receipt: %s", err)
+			}
+			return false
+		}
+
+		return rr.Read
+	})
+
+	docs := digestDocuments(ctx, userID, groups)
+	if len(docs) > 0 {
+		usertbl := env.Query(ctx).User
+		toUser, err := usertbl.Where(usertbl.ID.Eq(userID)).First()
+		if err != nil {
+			return fmt.Errorf("error getting user for digest email: %w", err)
+		}
+
+		err = email.SendDigest(ctx, toUser, freq, docs)
+		if err != nil {
+			return fmt.Errorf("error sending digest email: %w", err)
+		}
+	}
+
+	err = rdb.ZRemRangeByScore(ctx, entriesKey, "-inf", until).Err()
+	if err != nil {
+		return err
+	}
+
+	remaining, err := rdb.ZCard(ctx, entriesKey).Result()
+	if err != nil {
+		return err
+	}
+
+	if remaining == 0 {
+		return rdb.SRem(ctx, usersKey, userID).Err()
+	}
+
+	return nil
+}
+
+// digestDocuments loads what the digest email shows for each group of
+// entries, entries whose document, event or user is gone are left out. So are
+// documents the user lost access to since the entries were queued.
+func digestDocuments(ctx context.Context, userID string, groups [][]DigestEntry) []templates.DigestDocument {
+	log := env.Log(ctx)
+	q := env.Query(ctx)
+	dydb := env.Dynamo(ctx)
+
+	users := map[string]*models.User{}
+	getUser := func(userID string) *models.User {
+		if user, ok := users[userID]; ok {
+			return user
+		}
+
+		user, err := q.User.Where(q.User.ID.Eq(userID)).First()
+		if err != nil {
+			log.Errorf("error getting user %s for digest: %s", userID, err)
+		}
+		users[userID] = user
+		return user
+	}
+
+	docs := []templates.DigestDocument{}
+	for _, group := range groups {
+		doc, err := query.GetReadableDocumentForUser(q, group[0].DocID, userID)
+		if err != nil {
+			log.Errorf("error getting document %s for digest: %s", group[0].DocID, err)
+			continue
+		}
+
+		digestDoc := templates.DigestDocument{Document: doc}
+		for _, entry := range group {
+			item := templates.DigestItem{
+				Kind:     entry.Kind,
+				FromUser: getUser(entry.FromUserID),
+			}
+			if item.FromUser == nil {
+				continue
+			}
+
+			if entry.EventID != "" {
+				event, err := dydb.GetTimelineEvent(entry.DocID, entry.EventID)
+				if err != nil || event == nil || event.Event.GetMessage() == nil {
+					continue
+				}
+				item.Message = event.Event.GetMessage()
+			}
+
+			digestDoc.Items = append(digestDoc.Items, item)
+		}
+
+		if len(digestDoc.Items) > 0 {
+			docs = append(docs, digestDoc)
+		}
+	}
+
+	return docs
+}
@@ -0,0 +1,50 @@
+package notifications_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/service/notifications"
+)
+
+func TestDigestPeriod(t *testing.T) {
+	morning := time.Date(2024, 5, 10, 9, 30, 0, 0, time.UTC)
+	afternoon := time.Date(2024, 5, 10, 15, 45, 0, 0, time.UTC)
+
+	period, ok := notifications.DigestPeriod(models.DigestFrequency_DigestHourly, morning)
+	assert.True(t, ok)
+	assert.Equal(t, "2024-05-10T09:00:00Z", period)
+
+	_, ok = notifications.DigestPeriod(models.DigestFrequency_DigestDaily, morning)
+	assert.False(t, ok)
+
+	period, ok = notifications.DigestPeriod(models.DigestFrequency_DigestDaily, afternoon)
+	assert.True(t, ok)
+	assert.Equal(t, "2024-05-10", period)
+
+	_, ok = notifications.DigestPeriod(models.DigestFrequency_DigestImmediate, afternoon)
+	assert.False(t, ok)
+}
+
+func TestGroupDigest(t *testing.T) {
+	comment := notifications.DigestEntry{Kind: notifications.DigestKindComment, DocID: "doc-1", EventID: "event-1", FromUserID: "user-1"}
+	seen := notifications.DigestEntry{Kind: notifications.DigestKindComment, DocID: "doc-1", EventID: "event-2", FromUserID: "user-1"}
+	mention := notifications.DigestEntry{Kind: notifications.DigestKindMention, DocID: "doc-2", EventID: "event-3", FromUserID: "user-2"}
+	firstOpen := notifications.DigestEntry{Kind: notifications.DigestKindFirstOpen, DocID: "doc-1", FromUserID: "user-3"}
+
+	groups := notifications.GroupDigest(
+		[]notifications.DigestEntry{comment, seen, mention, comment, firstOpen},
+		func(entry notifications.DigestEntry) bool {
+			return entry.EventID == "event-2"
+		},
+	)
+
+	require.Equal(t, [][]notifications.DigestEntry{
+		{comment, firstOpen},
+		{mention},
+	}, groups)
+}
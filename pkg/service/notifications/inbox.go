@@ -0,0 +1,230 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/fivetentaylor/pointy/pkg/constants"
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
+)
+
+// markAllPageSize stays well under the 100 item limit on dynamo transactions
+const markAllPageSize = 25
+
+// Notify adds a notification to the user's inbox, unless they've muted its
+// document or threadID, and sends it to their open sessions
+func Notify(ctx context.Context, n *dynamo.Notification, threadID string) error {
+	log := env.SLog(ctx)
+	dydb := env.Dynamo(ctx)
+
+	muted, err := dydb.IsNotificationMuted(n.UserID, n.DocID, threadID)
+	if err != nil {
+		return err
+	}
+
+	if muted {
+		return nil
+	}
+
+	err = dydb.UpsertNotification(n)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf(constants.NotificationChanFormat, n.UserID)
+	err = env.Redis(ctx).Publish(ctx, key, n.ID).Err()
+	if err != nil {
+		log.Error("error publishing notification", "error", err, "userID", n.UserID)
+	}
+
+	return nil
+}
+
+// notifyTimelineComment adds a comment or mention on the timeline to the
+// user's inbox
+func notifyTimelineComment(ctx context.Context, userID string, event *dynamo.TimelineEvent, commentType models.CommentType) error {
+	threadID := event.EventID
+	if event.ReplyToID != "" {
+		threadID = event.ReplyToID
+	}
+
+	return Notify(ctx, &dynamo.Notification{
+		ID:     fmt.Sprintf("timeline#%s", event.EventID),
+		UserID: userID,
+		DocID:  event.DocID,
+		Payload: &models.NotificationPayload{
+			Value: &models.NotificationPayload_TimelineComment{
+				TimelineComment: &models.TimelineCommentNotification{
+					Type:       commentType,
+					DocumentId: event.DocID,
+					EventId:    event.EventID,
+					ReplyToId:  event.ReplyToID,
+					AuthorId:   event.UserID,
+				},
+			},
+		},
+	}, threadID)
+}
+
+// NotifyShare adds a document being shared with the user to their inbox,
+// eventID is the comment they were mentioned in if that's how it was shared
+func NotifyShare(ctx context.Context, userID, docID, fromUserID, eventID string) error {
+	return Notify(ctx, &dynamo.Notification{
+		ID:     fmt.Sprintf("share#%s#%s", docID, fromUserID),
+		UserID: userID,
+		DocID:  docID,
+		Payload: &models.NotificationPayload{
+			Value: &models.NotificationPayload_Share{
+				Share: &models.ShareNotification{
+					DocumentId: docID,
+					FromUserId: fromUserID,
+					EventId:    eventID,
+				},
+			},
+		},
+	}, "")
+}
+
+// NotifyRevision adds an ai revision the user asked for being finished to
+// their inbox
+func NotifyRevision(ctx context.Context, userID, docID, threadID, messageID string) error {
+	return Notify(ctx, &dynamo.Notification{
+		ID:     fmt.Sprintf("revision#%s", messageID),
+		UserID: userID,
+		DocID:  docID,
+		Payload: &models.NotificationPayload{
+			Value: &models.NotificationPayload_Revision{
+				Revision: &models.RevisionNotification{
+					DocumentId: docID,
+					ThreadId:   threadID,
+					MessageId:  messageID,
+				},
+			},
+		},
+	}, threadID)
+}
+
+// ListenForNotifications sends the user's new notifications to ch until ctx
+// is done
+func ListenForNotifications(ctx context.Context, ch chan *dynamo.Notification, userID string) {
+	log := env.Log(ctx)
+	dydb := env.Dynamo(ctx)
+
+	pubsub := env.Redis(ctx).Subscribe(ctx, fmt.Sprintf(constants.NotificationChanFormat, userID))
+	incoming := pubsub.Channel()
+
+	defer func() {
+		pubsub.Unsubscribe(ctx)
+		pubsub.Close()
+		close(ch)
+	}()
+
+	for {
+		select {
+		case rm := <-incoming:
+			n, err := dydb.GetNotification(userID, rm.Payload)
+			if err != nil {
+				log.Errorf("error getting notification %s: %s", rm.Payload, err)
+				continue
+			}
+
+			ch <- n
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// MarkRead marks one of the user's notifications read or unread
+func MarkRead(ctx context.Context, userID, id string, read bool) (*dynamo.Notification, error) {
+	dydb := env.Dynamo(ctx)
+
+	n, err := dydb.GetNotification(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.Read == read {
+		return n, nil
+	}
+
+	err = dydb.MarkNotifications(userID, n.DocID, []string{id}, read)
+	if err != nil {
+		return nil, err
+	}
+	n.Read = read
+
+	return n, nil
+}
+
+// MarkAllRead marks all the user's unread notifications read, or just the
+// ones for docID when it's set
+func MarkAllRead(ctx context.Context, userID string, docID *string) error {
+	dydb := env.Dynamo(ctx)
+
+	// the unread index is eventually consistent, so marked notifications can
+	// still show up on the first page for a while. Paging on from the last
+	// key instead of starting over means each one is only seen once.
+	cursor := ""
+	for {
+		unread, next, err := List(ctx, userID, docID, false, markAllPageSize, cursor)
+		if err != nil {
+			return err
+		}
+
+		idsByDoc := map[string][]string{}
+		for _, n := range unread {
+			idsByDoc[n.DocID] = append(idsByDoc[n.DocID], n.ID)
+		}
+
+		for id, ids := range idsByDoc {
+			err = dydb.MarkNotifications(userID, id, ids, true)
+			if err != nil {
+				return err
+			}
+		}
+
+		if next == "" {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// List returns a page of the user's notifications, newest first, along with
+// the cursor for the next page which is empty on the last one
+func List(ctx context.Context, userID string, docID *string, read bool, limit int64, after string) ([]*dynamo.Notification, string, error) {
+	dydb := env.Dynamo(ctx)
+
+	startKey, err := dynamo.DecodeCursor(after)
+	if err != nil {
+		return nil, "", err
+	}
+	params := dynamo.PaginationParams{Limit: limit, ExclusiveStartKey: startKey}
+
+	var page []*dynamo.Notification
+	var lastKey map[string]*dynamodb.AttributeValue
+	if docID != nil {
+		page, lastKey, err = dydb.GetNotificationsForUserDocument(userID, *docID, read, params)
+	} else {
+		var ns []dynamo.Notification
+		ns, lastKey, err = dydb.GetNotificationsForUser(userID, read, params)
+		for i := range ns {
+			page = append(page, &ns[i])
+		}
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	cursor, err := dynamo.EncodeCursor(lastKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return page, cursor, nil
+}
@@ -78,6 +78,19 @@ func SendNewComment(ctx context.Context, docID, eventID string, excludeUserIds [
 	var errs []error
 
 	for _, owner := range ownersAccess {
+		// users shared the document through a mention get a share in their inbox
+		if !utils.Contains(excludeUserIds, owner.UserID) {
+			commentType := models.CommentType_Comment
+			if utils.Contains(message.MentionedUserIds, owner.UserID) {
+				commentType = models.CommentType_Mention
+			}
+
+			err = notifyTimelineComment(ctx, owner.UserID, event, commentType)
+			if err != nil {
+				log.Errorf("error adding comment to inbox: %s", err)
+			}
+		}
+
 		docPref, err := dydb.GetDocNotificationPreference(owner.UserID, docID)
 		if err != nil {
 			log.Error("Failed to get notification preference", "err", err)
@@ -89,6 +102,15 @@ func SendNewComment(ctx context.Context, docID, eventID string, excludeUserIds [
 
 		if utils.Contains(message.MentionedUserIds, owner.UserID) && !utils.Contains(excludeUserIds, owner.UserID) {
 			if docPref.Preference.EnableMentionNotifications {
+				if !sendOrQueue(ctx, owner.UserID, DigestEntry{
+					Kind:       DigestKindMention,
+					DocID:      docID,
+					EventID:    eventID,
+					FromUserID: event.UserID,
+				}) {
+					continue
+				}
+
 				toUser, err := usertbl.Where(usertbl.ID.Eq(owner.UserID)).First()
 				if err != nil {
 					log.Errorf("error getting user for mention email: %s", err)
@@ -107,6 +129,15 @@ func SendNewComment(ctx context.Context, docID, eventID string, excludeUserIds [
 			continue
 		}
 
+		if !sendOrQueue(ctx, owner.UserID, DigestEntry{
+			Kind:       DigestKindComment,
+			DocID:      docID,
+			EventID:    eventID,
+			FromUserID: event.UserID,
+		}) {
+			continue
+		}
+
 		toUser, err := usertbl.Where(usertbl.ID.Eq(owner.UserID)).First()
 		if err != nil {
 			log.Errorf("error getting user for mention email: %s", err)
@@ -188,6 +219,20 @@ func SendNewMentionShare(ctx context.Context, docID, eventID, recipientID string
 		return err
 	}
 
+	err = NotifyShare(ctx, recipientID, docID, event.UserID, eventID)
+	if err != nil {
+		log.Errorf("error adding share to inbox: %s", err)
+	}
+
+	if !sendOrQueue(ctx, recipientID, DigestEntry{
+		Kind:       DigestKindShare,
+		DocID:      docID,
+		EventID:    eventID,
+		FromUserID: event.UserID,
+	}) {
+		return nil
+	}
+
 	toUser, err := usertbl.Where(usertbl.ID.Eq(recipientID)).First()
 	if err != nil {
 		log.Errorf("error getting user for mention email: %s", err)
@@ -264,6 +309,14 @@ func SendFirstOpen(ctx context.Context, docID, readerID string) error {
 			continue
 		}
 
+		if !sendOrQueue(ctx, owner.UserID, DigestEntry{
+			Kind:       DigestKindFirstOpen,
+			DocID:      docID,
+			FromUserID: readerID,
+		}) {
+			continue
+		}
+
 		toUser, err := userTlb.Where(userTlb.ID.Eq(owner.UserID)).First()
 		if err != nil {
 			log.Errorf("error finding user: %s", err)
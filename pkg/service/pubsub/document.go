@@ -113,3 +113,8 @@ func PublishNewDocument(ctx context.Context, userID, docID string) error {
 	key := fmt.Sprintf(constants.ChannelNewDocsFormat, userID)
 	return env.Redis(ctx).Publish(ctx, key, docID).Err()
 }
+
+func PublishDocumentAccess(ctx context.Context, docID, userID string) error {
+	key := fmt.Sprintf(constants.DocAccessChanFormat, docID)
+	return env.Redis(ctx).Publish(ctx, key, userID).Err()
+}
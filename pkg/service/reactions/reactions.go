@@ -0,0 +1,211 @@
+package reactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fivetentaylor/pointy/pkg/constants"
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/service/messaging"
+	"github.com/fivetentaylor/pointy/pkg/service/timeline"
+	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
+)
+
+const (
+	ThumbsUp   = "👍"
+	ThumbsDown = "👎"
+)
+
+// maxEmojiRunes allows for the longest zwj sequences, like families with skin
+// tones
+const maxEmojiRunes = 10
+
+var (
+	ErrInvalidEmoji = errors.New("invalid emoji")
+	ErrNotReactable = errors.New("only comments can be reacted to")
+	ErrNotFound     = errors.New("comment not found")
+)
+
+// Group is everyone who reacted with the same emoji
+type Group struct {
+	Emoji   string
+	UserIDs []string
+}
+
+// ValidEmoji is a loose check that emoji is a single emoji rather than text
+func ValidEmoji(emoji string) bool {
+	if emoji == "" || !utf8.ValidString(emoji) || utf8.RuneCountInString(emoji) > maxEmojiRunes {
+		return false
+	}
+
+	for _, r := range emoji {
+		if r < utf8.RuneSelf && r != '#' && r != '*' && !unicode.IsDigit(r) {
+			return false
+		}
+		if unicode.IsSpace(r) || unicode.IsLetter(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GroupReactions groups reactions by emoji, in the order each emoji was first
+// used
+func GroupReactions(reactions []dynamo.Reaction) []Group {
+	groups := []Group{}
+	ixs := map[string]int{}
+
+	for _, r := range reactions {
+		ix, ok := ixs[r.Emoji]
+		if !ok {
+			ix = len(groups)
+			ixs[r.Emoji] = ix
+			groups = append(groups, Group{Emoji: r.Emoji})
+		}
+		groups[ix].UserIDs = append(groups[ix].UserIDs, r.UserID)
+	}
+
+	return groups
+}
+
+// ReactToTimelineEvent adds or removes the user's reaction on a timeline
+// comment and tells the document's subscribers
+func ReactToTimelineEvent(ctx context.Context, userID, docID, eventID, emoji string, add bool) (*dynamo.TimelineEvent, error) {
+	dydb := env.Dynamo(ctx)
+
+	if !ValidEmoji(emoji) {
+		return nil, ErrInvalidEmoji
+	}
+
+	event, err := dydb.GetTimelineEvent(docID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event == nil {
+		return nil, ErrNotFound
+	}
+
+	if event.Event.GetMessage() == nil {
+		return nil, ErrNotReactable
+	}
+
+	// like new replies, reactions on replies update the comment they're on
+	eventToPublish := event
+	if event.ReplyToID != "" {
+		eventToPublish, err = dydb.GetTimelineEvent(docID, event.ReplyToID)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching parent event: %w", err)
+		}
+		if eventToPublish == nil {
+			return nil, ErrNotFound
+		}
+	}
+
+	if add {
+		err = dydb.AddReaction(&dynamo.Reaction{
+			TargetID: eventID,
+			UserID:   userID,
+			Emoji:    emoji,
+			DocID:    docID,
+		})
+	} else {
+		err = dydb.RemoveReaction(eventID, userID, emoji)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = timeline.PublishTimelineEvent(ctx, eventToPublish, timeline.EventTypeUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// ReactToAiThreadMessage adds or removes the user's reaction on an ask ai
+// message and tells the thread's subscribers. Thumbs up and down on the AI's
+// replies are also kept as feedback on the prompt that wrote them, so a user
+// can only have one of them at a time.
+func ReactToAiThreadMessage(ctx context.Context, userID, docID, threadID, messageID, emoji string, add bool) (*dynamo.Message, error) {
+	log := env.Log(ctx)
+	dydb := env.Dynamo(ctx)
+
+	if !ValidEmoji(emoji) {
+		return nil, ErrInvalidEmoji
+	}
+
+	msg, err := dydb.GetAiThreadMessage(threadID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if add {
+		err = dydb.AddReaction(&dynamo.Reaction{
+			TargetID: messageID,
+			UserID:   userID,
+			Emoji:    emoji,
+			DocID:    docID,
+		})
+	} else {
+		err = dydb.RemoveReaction(messageID, userID, emoji)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	isFeedback := msg.UserID == constants.RevisoUserID && (emoji == ThumbsUp || emoji == ThumbsDown)
+	if isFeedback && add {
+		opposite := ThumbsDown
+		if emoji == ThumbsDown {
+			opposite = ThumbsUp
+		}
+
+		err = dydb.RemoveReaction(messageID, userID, opposite)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if isFeedback {
+		err = recordFeedback(ctx, userID, docID, threadID, msg, emoji == ThumbsUp, add)
+		if err != nil {
+			// the reaction still counts even if the feedback is lost
+			log.Errorf("error recording ai feedback on %s: %s", messageID, err)
+		}
+	}
+
+	err = messaging.PublishMessage(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+func recordFeedback(ctx context.Context, userID, docID, threadID string, msg *dynamo.Message, positive, add bool) error {
+	dydb := env.Dynamo(ctx)
+
+	promptName := msg.MessageMetadata.GetPromptName()
+	if promptName == "" {
+		return nil
+	}
+
+	if !add {
+		return dydb.DeleteAIFeedback(promptName, msg.MessageID, userID, positive)
+	}
+
+	return dydb.UpsertAIFeedback(&dynamo.AIFeedback{
+		PromptName: promptName,
+		MessageID:  msg.MessageID,
+		UserID:     userID,
+		DocID:      docID,
+		ThreadID:   threadID,
+		Positive:   positive,
+	})
+}
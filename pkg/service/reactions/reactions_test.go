@@ -0,0 +1,37 @@
+package reactions_test
+
+import (
+	"testing"
+
+	"github.com/fivetentaylor/pointy/pkg/service/reactions"
+	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidEmoji(t *testing.T) {
+	for _, emoji := range []string{"👍", "👎", "🎉", "#️⃣", "1️⃣", "🇺🇸", "👩🏽‍💻", "👨‍👩‍👧‍👦", "❤️"} {
+		assert.True(t, reactions.ValidEmoji(emoji), emoji)
+	}
+
+	for _, emoji := range []string{"", "a", "lol", "👍 ", "👍a", "中", "<script>", "🎉🎉🎉🎉🎉🎉🎉🎉🎉🎉🎉"} {
+		assert.False(t, reactions.ValidEmoji(emoji), emoji)
+	}
+}
+
+func TestGroupReactions(t *testing.T) {
+	groups := reactions.GroupReactions([]dynamo.Reaction{
+		{UserID: "a", Emoji: "👍"},
+		{UserID: "b", Emoji: "🎉"},
+		{UserID: "b", Emoji: "👍"},
+		{UserID: "c", Emoji: "🎉"},
+		{UserID: "c", Emoji: "👎"},
+	})
+
+	assert.Equal(t, []reactions.Group{
+		{Emoji: "👍", UserIDs: []string{"a", "b"}},
+		{Emoji: "🎉", UserIDs: []string{"b", "c"}},
+		{Emoji: "👎", UserIDs: []string{"c"}},
+	}, groups)
+
+	assert.Empty(t, reactions.GroupReactions(nil))
+}
@@ -10,6 +10,17 @@ import (
 )
 
 func JoinDoc(ctx context.Context, docId string, userID string, accessLevel string) (*models.Document, error) {
+	return joinDoc(ctx, docId, userID, accessLevel, nil)
+}
+
+// JoinLink gives the invitee write access through the share link. The access
+// is tied to the link, so it's what gets removed if the link is deactivated,
+// access the user already had is left alone.
+func JoinLink(ctx context.Context, link *models.SharedDocumentLink, userID string) (*models.Document, error) {
+	return joinDoc(ctx, link.DocumentID, userID, "write", &link.ID)
+}
+
+func joinDoc(ctx context.Context, docId string, userID string, accessLevel string, sharedLinkID *int32) (*models.Document, error) {
 	log := env.Log(ctx)
 	documentTbl := env.Query(ctx).Document
 
@@ -35,9 +46,10 @@ func JoinDoc(ctx context.Context, docId string, userID string, accessLevel strin
 		docAccessTbl := tx.DocumentAccess
 
 		err = docAccessTbl.Create(&models.DocumentAccess{
-			UserID:      userID,
-			DocumentID:  doc.ID,
-			AccessLevel: accessLevel,
+			UserID:       userID,
+			DocumentID:   doc.ID,
+			AccessLevel:  accessLevel,
+			SharedLinkID: sharedLinkID,
 		})
 		return err
 	})
@@ -7,6 +7,7 @@ import (
 	"github.com/fivetentaylor/pointy/pkg/env"
 	"github.com/fivetentaylor/pointy/pkg/models"
 	"github.com/fivetentaylor/pointy/pkg/service/email"
+	"github.com/fivetentaylor/pointy/pkg/service/notifications"
 	"github.com/fivetentaylor/pointy/pkg/service/pubsub"
 	"github.com/fivetentaylor/pointy/pkg/service/timeline"
 	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
@@ -102,6 +103,11 @@ func shareDocToUser(
 		log.Errorf("error publishing document: %s", err)
 	}
 
+	err = notifications.NotifyShare(ctx, invitedUser.ID, document.ID, invitedBy.ID, "")
+	if err != nil {
+		log.Errorf("error adding share to inbox: %s", err)
+	}
+
 	err = email.SendSharedToUserEmail(
 		ctx,
 		invitedUser.Email,
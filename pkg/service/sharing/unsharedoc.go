@@ -50,6 +50,12 @@ func UnshareDoc(
 		log.Errorf("error publishing document: %s", err)
 	}
 
+	// disconnect any open editor sessions for the removed user
+	err = pubsub.PublishDocumentAccess(ctx, document.ID, editorID)
+	if err != nil {
+		log.Errorf("error publishing document access: %s", err)
+	}
+
 	event := &dynamo.TimelineEvent{
 		DocID:  document.ID,
 		UserID: userID,
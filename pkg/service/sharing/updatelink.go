@@ -2,11 +2,16 @@ package sharing
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/charmbracelet/log"
+	"gorm.io/gorm"
+
+	"github.com/fivetentaylor/pointy/pkg/constants"
 	"github.com/fivetentaylor/pointy/pkg/env"
 	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/service/pubsub"
 	"github.com/fivetentaylor/pointy/pkg/service/timeline"
 	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
 )
@@ -23,6 +28,11 @@ func UpdateLink(ctx context.Context, userId string, link *models.SharedDocumentL
 	}
 
 	if !isActive && link.IsActive {
+		err = revokeLinkAccess(ctx, link)
+		if err != nil {
+			log.Errorf("error revoking share link access: %s", err)
+		}
+
 		event := &dynamo.TimelineEvent{
 			DocID:  link.DocumentID,
 			UserID: userId,
@@ -48,3 +58,38 @@ func UpdateLink(ctx context.Context, userId string, link *models.SharedDocumentL
 
 	return link, nil
 }
+
+// revokeLinkAccess removes the access the invitee was given by the link and
+// pushes the change to their open sessions. Access they have some other way,
+// e.g. the doc was shared with them directly, is kept.
+func revokeLinkAccess(ctx context.Context, link *models.SharedDocumentLink) error {
+	userTbl := env.Query(ctx).User
+	invitee, err := userTbl.Where(userTbl.Email.Eq(link.InviteeEmail)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil // the invitee never signed up
+	}
+	if err != nil {
+		return fmt.Errorf("error getting invitee: %w", err)
+	}
+
+	docAccessTbl := env.Query(ctx).DocumentAccess
+	result, err := docAccessTbl.
+		Where(docAccessTbl.DocumentID.Eq(link.DocumentID)).
+		Where(docAccessTbl.UserID.Eq(invitee.ID)).
+		Where(docAccessTbl.SharedLinkID.Eq(link.ID)).
+		Where(docAccessTbl.AccessLevel.Neq(constants.AccessLevelOwner)).
+		Delete()
+	if err != nil {
+		return fmt.Errorf("error deleting document access: %w", err)
+	}
+	if result.RowsAffected == 0 {
+		return nil
+	}
+
+	err = pubsub.PublishDocument(ctx, link.DocumentID)
+	if err != nil {
+		log.Errorf("error publishing document: %s", err)
+	}
+
+	return pubsub.PublishDocumentAccess(ctx, link.DocumentID, invitee.ID)
+}
@@ -0,0 +1,289 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/fivetentaylor/pointy/pkg/constants"
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/query"
+	"github.com/fivetentaylor/pointy/pkg/service/email"
+	"github.com/fivetentaylor/pointy/pkg/service/email/templates"
+	"github.com/fivetentaylor/pointy/pkg/service/timeline"
+	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
+)
+
+// ReminderSweepInterval is how often the worker checks for tasks that are due
+const ReminderSweepInterval = 15 * time.Minute
+
+var (
+	ErrNotAssignable   = errors.New("only comments can be assigned")
+	ErrNotCollaborator = errors.New("tasks can only be assigned to people the document is shared with")
+	ErrTaskNotFound    = errors.New("task not found")
+	ErrCommentNotFound = errors.New("comment not found")
+)
+
+// DueDay is the day, in UTC, a task due at t is due on
+func DueDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Get returns the task on a comment, nil if there isn't one
+func Get(ctx context.Context, docID, eventID string) (*models.CommentTask, error) {
+	tbl := env.Query(ctx).CommentTask
+
+	task, err := tbl.Where(tbl.DocumentID.Eq(docID), tbl.EventID.Eq(eventID)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return task, err
+}
+
+// Assign turns a comment into a task for the assignee, or reassigns it. The
+// assignee has to already be able to see the document, assigning a task
+// never shares it.
+func Assign(ctx context.Context, userID, docID, eventID, assigneeID string, dueDate time.Time) (*models.CommentTask, error) {
+	q := env.Query(ctx)
+	dydb := env.Dynamo(ctx)
+
+	_, err := query.GetEditableDocumentForUser(q, docID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := dydb.GetTimelineEvent(docID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event == nil {
+		return nil, ErrCommentNotFound
+	}
+
+	if event.ReplyToID != "" || event.Event.GetMessage() == nil {
+		return nil, ErrNotAssignable
+	}
+
+	access, err := query.AccessLevelForDocument(q, docID, assigneeID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error checking assignee access: %w", err)
+	}
+	if access == constants.AccessLevelNone {
+		return nil, ErrNotCollaborator
+	}
+
+	replies, err := dydb.GetDocumentTimelineReplies(docID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	task := &models.CommentTask{
+		DocumentID:   docID,
+		EventID:      eventID,
+		AssigneeID:   assigneeID,
+		AssignedByID: userID,
+		DueDate:      DueDay(dueDate),
+		Done:         timeline.IsThreadResolved(replies),
+	}
+
+	// reassigning or moving the due date means the assignee should be
+	// reminded again
+	err = q.CommentTask.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "document_id"}, {Name: "event_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"assignee_id":    task.AssigneeID,
+			"assigned_by_id": task.AssignedByID,
+			"due_date":       task.DueDate,
+			"done":           task.Done,
+			"reminded":       false,
+			"updated_at":     time.Now(),
+		}),
+	}).Create(task)
+	if err != nil {
+		return nil, fmt.Errorf("error saving task: %w", err)
+	}
+
+	return Get(ctx, docID, eventID)
+}
+
+// Unassign turns a task back into a plain comment
+func Unassign(ctx context.Context, userID, docID, eventID string) error {
+	q := env.Query(ctx)
+
+	_, err := query.GetEditableDocumentForUser(q, docID, userID)
+	if err != nil {
+		return err
+	}
+
+	tbl := q.CommentTask
+	_, err = tbl.Where(tbl.DocumentID.Eq(docID), tbl.EventID.Eq(eventID)).Delete()
+
+	return err
+}
+
+// SetDone marks a task done or open by resolving or reopening its comment
+// thread, the task follows the thread's resolution
+func SetDone(ctx context.Context, userID, docID, eventID string, done bool) (*models.CommentTask, error) {
+	_, err := query.GetEditableDocumentForUser(env.Query(ctx), docID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := Get(ctx, docID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, ErrTaskNotFound
+	}
+
+	if task.Done == done {
+		return task, nil
+	}
+
+	err = timeline.CreateTimelineEvent(ctx, &dynamo.TimelineEvent{
+		DocID:     docID,
+		UserID:    userID,
+		ReplyToID: eventID,
+		Event: &models.TimelineEventPayload{
+			Payload: &models.TimelineEventPayload_Resolution{
+				Resolution: &models.TimelineMessageResolutionV1{
+					Resolved: done,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	task.Done = done
+	return task, nil
+}
+
+// OpenTasksForUser returns the tasks assigned to the user that aren't done,
+// across the documents they can still read, soonest due first
+func OpenTasksForUser(ctx context.Context, userID string) ([]*models.CommentTask, error) {
+	q := env.Query(ctx)
+	tbl := q.CommentTask
+	documentTbl := q.Document
+	docAccessTbl := q.DocumentAccess
+
+	return tbl.
+		Select(tbl.ALL).
+		Join(documentTbl, documentTbl.ID.EqCol(tbl.DocumentID)).
+		Join(docAccessTbl, docAccessTbl.DocumentID.EqCol(tbl.DocumentID), docAccessTbl.UserID.EqCol(tbl.AssigneeID)).
+		Where(
+			tbl.AssigneeID.Eq(userID),
+			tbl.Done.Is(false),
+			documentTbl.DeletedAt.IsNull(),
+		).
+		Order(tbl.DueDate, tbl.CreatedAt).
+		Find()
+}
+
+
+// SendReminders emails assignees about their tasks that are due at now. Each
+// task is only reminded about once, unless it's reassigned.
+func SendReminders(ctx context.Context, now time.Time) error {
+	log := env.Log(ctx)
+	tbl := env.Query(ctx).CommentTask
+
+	due, err := tbl.
+		Where(
+			tbl.Done.Is(false),
+			tbl.Reminded.Is(false),
+			tbl.DueDate.Lte(DueDay(now)),
+		).
+		Order(tbl.AssigneeID, tbl.DueDate).
+		Find()
+	if err != nil {
+		return fmt.Errorf("error getting due tasks: %w", err)
+	}
+
+	assigneeIDs := []string{}
+	byAssignee := map[string][]*models.CommentTask{}
+	for _, task := range due {
+		if _, ok := byAssignee[task.AssigneeID]; !ok {
+			assigneeIDs = append(assigneeIDs, task.AssigneeID)
+		}
+		byAssignee[task.AssigneeID] = append(byAssignee[task.AssigneeID], task)
+	}
+
+	log.Info("⏰ sending task reminders", "tasks", len(due), "users", len(assigneeIDs))
+
+	for _, assigneeID := range assigneeIDs {
+		err := sendReminder(ctx, assigneeID, byAssignee[assigneeID])
+		if err != nil {
+			log.Errorf("error sending task reminder to %s: %s", assigneeID, err)
+		}
+	}
+
+	return nil
+}
+
+func sendReminder(ctx context.Context, assigneeID string, tasks []*models.CommentTask) error {
+	log := env.Log(ctx)
+	q := env.Query(ctx)
+	dydb := env.Dynamo(ctx)
+
+	assignee, err := q.User.Where(q.User.ID.Eq(assigneeID)).First()
+	if err != nil {
+		return fmt.Errorf("error getting assignee: %w", err)
+	}
+
+	ids := []string{}
+	items := []templates.TaskReminderItem{}
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+
+		// the assignee may have lost access since the task was assigned, the
+		// task is still marked reminded so it isn't checked again
+		doc, err := query.GetReadableDocumentForUser(q, task.DocumentID, assigneeID)
+		if err != nil {
+			log.Infof("skipping task reminder for %s on %s: %s", assigneeID, task.DocumentID, err)
+			continue
+		}
+
+		assignedBy, err := q.User.Where(q.User.ID.Eq(task.AssignedByID)).First()
+		if err != nil {
+			log.Errorf("error getting user %s for task reminder: %s", task.AssignedByID, err)
+			continue
+		}
+
+		event, err := dydb.GetTimelineEvent(task.DocumentID, task.EventID)
+		if err != nil || event == nil || event.Event.GetMessage() == nil {
+			continue
+		}
+
+		items = append(items, templates.TaskReminderItem{
+			Document:   doc,
+			AssignedBy: assignedBy,
+			Message:    event.Event.GetMessage(),
+			DueDate:    task.DueDate,
+		})
+	}
+
+	if len(items) > 0 {
+		err = email.SendTaskReminder(ctx, assignee, items)
+		if err != nil {
+			return fmt.Errorf("error sending task reminder email: %w", err)
+		}
+	}
+
+	tbl := q.CommentTask
+	_, err = tbl.Where(tbl.ID.In(ids...)).Updates(map[string]interface{}{
+		"reminded":   true,
+		"updated_at": time.Now(),
+	})
+
+	return err
+}
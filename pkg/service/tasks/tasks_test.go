@@ -0,0 +1,98 @@
+package tasks_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/service/tasks"
+	"github.com/fivetentaylor/pointy/pkg/testutils"
+)
+
+func TestDueDay(t *testing.T) {
+	pst := time.FixedZone("PST", -8*60*60)
+
+	tests := []struct {
+		name string
+		in   time.Time
+		want time.Time
+	}{
+		{
+			name: "midnight utc",
+			in:   time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "later in the day",
+			in:   time.Date(2024, 3, 5, 23, 59, 59, 0, time.UTC),
+			want: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "evening west of utc is the next day",
+			in:   time.Date(2024, 3, 5, 20, 0, 0, 0, pst),
+			want: time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tasks.DueDay(tt.in))
+		})
+	}
+}
+
+func TestOpenTasksForUser(t *testing.T) {
+	testutils.EnsureStorage()
+	ctx := testutils.TestContext()
+	q := env.Query(ctx)
+
+	owner := testutils.CreateUser(t, ctx)
+	assignee := testutils.CreateUser(t, ctx)
+
+	newTask := func(docID string, due time.Time) {
+		err := q.CommentTask.Create(&models.CommentTask{
+			DocumentID:   docID,
+			EventID:      uuid.NewString(),
+			AssigneeID:   assignee.ID,
+			AssignedByID: owner.ID,
+			DueDate:      tasks.DueDay(due),
+		})
+		require.NoError(t, err)
+	}
+
+	now := time.Now()
+
+	shared := uuid.NewString()
+	testutils.CreateTestDocument(t, ctx, shared, "shared")
+	testutils.AddOwnerToDocument(t, ctx, shared, owner.ID)
+	testutils.AddUserToDocument(t, ctx, shared, assignee.ID, "write")
+	newTask(shared, now.Add(48*time.Hour))
+	newTask(shared, now)
+
+	revoked := uuid.NewString()
+	testutils.CreateTestDocument(t, ctx, revoked, "revoked")
+	testutils.AddOwnerToDocument(t, ctx, revoked, owner.ID)
+	newTask(revoked, now)
+
+	deleted := uuid.NewString()
+	testutils.CreateTestDocument(t, ctx, deleted, "deleted")
+	testutils.AddOwnerToDocument(t, ctx, deleted, owner.ID)
+	testutils.AddUserToDocument(t, ctx, deleted, assignee.ID, "write")
+	newTask(deleted, now)
+	_, err := q.Document.Where(q.Document.ID.Eq(deleted)).Delete()
+	require.NoError(t, err)
+
+	open, err := tasks.OpenTasksForUser(ctx, assignee.ID)
+	require.NoError(t, err)
+	require.Len(t, open, 2)
+	for _, task := range open {
+		require.Equal(t, shared, task.DocumentID)
+		require.NotEmpty(t, task.ID)
+	}
+	require.True(t, open[0].DueDate.Before(open[1].DueDate))
+}
@@ -0,0 +1,29 @@
+package timeline
+
+import (
+	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
+)
+
+// HasAnchor is true for comments attached to a selection in the document,
+// replies and comments without a selection have no anchor
+func HasAnchor(event *dynamo.TimelineEvent) bool {
+	msg := event.Event.GetMessage()
+	if msg == nil || event.ReplyToID != "" {
+		return false
+	}
+
+	return msg.SelectionStartId != "" && msg.SelectionEndId != ""
+}
+
+// IsThreadResolved returns the state of the latest resolution in a comment
+// thread's replies, which are in the order they were created
+func IsThreadResolved(replies []*dynamo.TimelineEvent) bool {
+	for i := len(replies) - 1; i >= 0; i-- {
+		resolution := replies[i].Event.GetResolution()
+		if resolution != nil {
+			return resolution.Resolved
+		}
+	}
+
+	return false
+}
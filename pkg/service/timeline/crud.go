@@ -10,6 +10,7 @@ import (
 	"github.com/fivetentaylor/pointy/pkg/query"
 	"github.com/fivetentaylor/pointy/pkg/service/notifications"
 	"github.com/fivetentaylor/pointy/pkg/service/pubsub"
+	"github.com/fivetentaylor/pointy/pkg/service/webhooks"
 	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
 	"gorm.io/gorm"
 )
@@ -62,6 +63,18 @@ func CreateTimelineEvent(ctx context.Context, event *dynamo.TimelineEvent) error
 		return err
 	}
 
+	// the task catches up the next time the thread is resolved or reopened
+	err = syncTaskDone(ctx, event)
+	if err != nil {
+		log.Error("error updating comment task", "error", err)
+	}
+
+	// a broken webhook shouldn't stop the event from being created
+	err = webhooks.EnqueueForTimelineEvent(ctx, event)
+	if err != nil {
+		log.Error("error enqueueing webhooks", "error", err)
+	}
+
 	log.Info("timeline event created", "eventID", event.EventID, "docID", event.DocID, "event", "timeline_event_created")
 	return nil
 }
@@ -76,6 +89,14 @@ func UpdateTimelineEvent(ctx context.Context, event *dynamo.TimelineEvent) error
 		return err
 	}
 
+	// the update has to be checked against what's stored before it's
+	// overwritten
+	completed, err := isNewlyCompletedUpdate(ctx, event)
+	if err != nil {
+		log.Errorf("error getting timeline event: %s", err)
+		return err
+	}
+
 	err = env.Dynamo(ctx).UpdateTimelineEvent(event)
 	if err != nil {
 		log.Errorf("error updating timeline event: %s", err)
@@ -100,9 +121,34 @@ func UpdateTimelineEvent(ctx context.Context, event *dynamo.TimelineEvent) error
 		return err
 	}
 
+	// edits to comments aren't sent, but document updates are only complete
+	// once they've been summarized. Later edits to a complete update, like
+	// flagging a version, aren't sent again.
+	if completed {
+		err = webhooks.EnqueueForTimelineEvent(ctx, event)
+		if err != nil {
+			log.Errorf("error enqueueing webhooks: %s", err)
+		}
+	}
+
 	return nil
 }
 
+// isNewlyCompletedUpdate is true if event is a document update being moved to
+// the complete state
+func isNewlyCompletedUpdate(ctx context.Context, event *dynamo.TimelineEvent) (bool, error) {
+	if event.Event.GetUpdate().GetState() != models.UpdateState_COMPLETE_STATE {
+		return false, nil
+	}
+
+	stored, err := env.Dynamo(ctx).GetTimelineEvent(event.DocID, event.EventID)
+	if err != nil {
+		return false, err
+	}
+
+	return stored == nil || stored.Event.GetUpdate().GetState() != models.UpdateState_COMPLETE_STATE, nil
+}
+
 func DeleteTimelineEvent(ctx context.Context, event *dynamo.TimelineEvent) error {
 	log := env.Log(ctx)
 	log.Debug("updating timeline event", "eventID", event.EventID, "docID", event.DocID)
@@ -113,6 +159,12 @@ func DeleteTimelineEvent(ctx context.Context, event *dynamo.TimelineEvent) error
 		return err
 	}
 
+	err = deleteTask(ctx, event)
+	if err != nil {
+		log.Errorf("error deleting comment task: %s", err)
+		return err
+	}
+
 	eventToPublish := event
 	eventType := EventTypeDelete
 
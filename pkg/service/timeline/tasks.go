@@ -0,0 +1,44 @@
+package timeline
+
+import (
+	"context"
+	"time"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
+)
+
+// syncTaskDone marks the task on a comment thread done or open again when the
+// thread is resolved or reopened
+func syncTaskDone(ctx context.Context, event *dynamo.TimelineEvent) error {
+	resolution := event.Event.GetResolution()
+	if resolution == nil || event.ReplyToID == "" {
+		return nil
+	}
+
+	tbl := env.Query(ctx).CommentTask
+	_, err := tbl.Where(
+		tbl.DocumentID.Eq(event.DocID),
+		tbl.EventID.Eq(event.ReplyToID),
+	).Updates(map[string]interface{}{
+		"done":       resolution.Resolved,
+		"updated_at": time.Now(),
+	})
+
+	return err
+}
+
+// deleteTask removes the task on a comment when the comment is deleted
+func deleteTask(ctx context.Context, event *dynamo.TimelineEvent) error {
+	if event.ReplyToID != "" {
+		return nil
+	}
+
+	tbl := env.Query(ctx).CommentTask
+	_, err := tbl.Where(
+		tbl.DocumentID.Eq(event.DocID),
+		tbl.EventID.Eq(event.EventID),
+	).Delete()
+
+	return err
+}
@@ -131,6 +131,14 @@ func (uds *UserDeletionService) deletePostgreSQLData(ctx context.Context, userID
 			result, err := q.OneTimeAccessToken.Where(q.OneTimeAccessToken.UserID.Eq(userID)).Delete()
 			return result.RowsAffected, err
 		}},
+		{"api_tokens", func() (int64, error) {
+			result, err := q.APIToken.Where(q.APIToken.UserID.Eq(userID)).Delete()
+			return result.RowsAffected, err
+		}},
+		{"webhooks", func() (int64, error) {
+			result, err := q.Webhook.Where(q.Webhook.UserID.Eq(userID)).Delete()
+			return result.RowsAffected, err
+		}},
 		{"payment_history", func() (int64, error) {
 			result, err := q.PaymentHistory.Where(q.PaymentHistory.UserID.Eq(userID)).Delete()
 			return result.RowsAffected, err
@@ -0,0 +1,309 @@
+package versions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/rogue"
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+const (
+	NameHourly   = "Hourly checkpoint"
+	NameDaily    = "Daily checkpoint"
+	NameBeforeAI = "Before AI revision"
+)
+
+// SweepInterval is how often the worker looks for documents that are due an
+// automatic version
+const SweepInterval = 10 * time.Minute
+
+// Policy controls when automatic versions are made and how long they're kept,
+// versions flagged by users are never removed
+type Policy struct {
+	// ActiveInterval is how often versions are made while a document is being
+	// edited, it's active if it was edited within the last interval
+	ActiveInterval time.Duration
+	// IdleInterval is how often versions are made of documents that have
+	// changed but aren't being edited anymore
+	IdleInterval time.Duration
+	// KeepAllFor is how long every automatic version is kept
+	KeepAllFor time.Duration
+	// KeepHourlyFor is how long one automatic version an hour is kept, after
+	// that one a day is kept
+	KeepHourlyFor time.Duration
+}
+
+var DefaultPolicy = Policy{
+	ActiveInterval: time.Hour,
+	IdleInterval:   24 * time.Hour,
+	KeepAllFor:     24 * time.Hour,
+	KeepHourlyFor:  7 * 24 * time.Hour,
+}
+
+// PolicyFromEnv is the default policy with any of AUTO_VERSION_ACTIVE_INTERVAL,
+// AUTO_VERSION_IDLE_INTERVAL, AUTO_VERSION_KEEP_ALL_FOR and
+// AUTO_VERSION_KEEP_HOURLY_FOR overriding it, e.g. AUTO_VERSION_KEEP_ALL_FOR=48h
+func PolicyFromEnv(ctx context.Context) Policy {
+	log := env.SLog(ctx)
+	policy := DefaultPolicy
+
+	for key, field := range map[string]*time.Duration{
+		"AUTO_VERSION_ACTIVE_INTERVAL": &policy.ActiveInterval,
+		"AUTO_VERSION_IDLE_INTERVAL":   &policy.IdleInterval,
+		"AUTO_VERSION_KEEP_ALL_FOR":    &policy.KeepAllFor,
+		"AUTO_VERSION_KEEP_HOURLY_FOR": &policy.KeepHourlyFor,
+	} {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			log.Warn("ignoring invalid auto version setting", "key", key, "value", value)
+			continue
+		}
+		*field = d
+	}
+
+	return policy
+}
+
+// Due decides whether a document that was last edited at updatedAt needs an
+// automatic version, lastVersionAt is when its last one was made if it has one
+func Due(updatedAt time.Time, lastVersionAt *time.Time, now time.Time, policy Policy) (name string, ok bool) {
+	if lastVersionAt != nil && !lastVersionAt.Before(updatedAt) {
+		return "", false
+	}
+
+	if now.Sub(updatedAt) < policy.ActiveInterval {
+		if lastVersionAt == nil || now.Sub(*lastVersionAt) >= policy.ActiveInterval {
+			return NameHourly, true
+		}
+		return "", false
+	}
+
+	if lastVersionAt == nil || now.Sub(*lastVersionAt) >= policy.IdleInterval {
+		return NameDaily, true
+	}
+
+	return "", false
+}
+
+// Expired returns the automatic versions the policy no longer keeps. Every
+// version is kept for KeepAllFor, then the newest of each hour until
+// KeepHourlyFor and the newest of each day after that.
+func Expired(versions []*models.DocumentVersion, now time.Time, policy Policy) []*models.DocumentVersion {
+	sorted := slices.Clone(versions)
+	slices.SortFunc(sorted, func(a, b *models.DocumentVersion) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	kept := map[string]bool{}
+	expired := []*models.DocumentVersion{}
+	for _, version := range sorted {
+		if !version.IsAuto {
+			continue
+		}
+
+		age := now.Sub(version.CreatedAt)
+		if age < policy.KeepAllFor {
+			continue
+		}
+
+		bucket := "d" + version.CreatedAt.UTC().Format(time.DateOnly)
+		if age < policy.KeepHourlyFor {
+			bucket = "h" + version.CreatedAt.UTC().Truncate(time.Hour).Format(time.RFC3339)
+		}
+
+		if kept[bucket] {
+			expired = append(expired, version)
+			continue
+		}
+		kept[bucket] = true
+	}
+
+	return expired
+}
+
+// CreateAuto makes an automatic version of doc at its current address, it's
+// skipped if the document's latest version is already at that address
+func CreateAuto(ctx context.Context, docID string, doc *v3.Rogue, name string) (*models.DocumentVersion, error) {
+	q := env.Query(ctx)
+
+	address, err := doc.GetFullAddress()
+	if err != nil {
+		return nil, fmt.Errorf("doc.GetFullAddress(): %w", err)
+	}
+
+	addressBytes, err := json.Marshal(address)
+	if err != nil {
+		return nil, err
+	}
+
+	versionTbl := q.DocumentVersion
+	latest, err := versionTbl.
+		Where(versionTbl.DocumentID.Eq(docID)).
+		Order(versionTbl.CreatedAt.Desc()).
+		Limit(1).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(latest) > 0 && latest[0].ContentAddress == string(addressBytes) {
+		return nil, nil
+	}
+
+	// versions need a user, automatic ones belong to the document's owner
+	accessTbl := q.DocumentAccess
+	owner, err := accessTbl.
+		Where(accessTbl.DocumentID.Eq(docID)).
+		Where(accessTbl.AccessLevel.Eq("owner")).
+		First()
+	if err != nil {
+		return nil, fmt.Errorf("error getting document owner: %w", err)
+	}
+
+	version := &models.DocumentVersion{
+		DocumentID:     docID,
+		Name:           name,
+		ContentAddress: string(addressBytes),
+		CreatedBy:      owner.UserID,
+		UpdatedBy:      owner.UserID,
+		IsAuto:         true,
+	}
+
+	err = versionTbl.Create(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+type dueDocument struct {
+	ID            string
+	UpdatedAt     time.Time
+	LastVersionAt *time.Time
+}
+
+// CreateDue makes automatic versions of every document that's due one
+func CreateDue(ctx context.Context, now time.Time, policy Policy) error {
+	log := env.SLog(ctx)
+
+	// documents that changed after their last automatic version, anything
+	// older than two idle intervals would have been picked up already
+	var docs []dueDocument
+	err := env.RawDB(ctx).Raw(`
+		SELECT d.id, d.updated_at, max(v.created_at) AS last_version_at
+		FROM documents d
+		LEFT JOIN document_versions v ON v.document_id = d.id AND v.is_auto
+		WHERE d.deleted_at IS NULL
+		AND d.is_folder IS NOT TRUE
+		AND d.updated_at > ?
+		GROUP BY d.id, d.updated_at
+		HAVING max(v.created_at) IS NULL OR max(v.created_at) < d.updated_at
+	`, now.Add(-2*policy.IdleInterval)).Scan(&docs).Error
+	if err != nil {
+		return fmt.Errorf("error finding documents due a version: %w", err)
+	}
+
+	docStore := rogue.NewDocStore(env.S3(ctx), env.Query(ctx), env.Redis(ctx))
+	for _, d := range docs {
+		name, ok := Due(d.UpdatedAt, d.LastVersionAt, now, policy)
+		if !ok {
+			continue
+		}
+
+		_, doc, err := docStore.GetCurrentDoc(ctx, d.ID)
+		if err != nil {
+			log.Error("error getting document for auto version", "docID", d.ID, "error", err)
+			continue
+		}
+
+		_, err = CreateAuto(ctx, d.ID, doc, name)
+		if err != nil {
+			log.Error("error creating auto version", "docID", d.ID, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// Prune removes the automatic versions of a document the policy no longer keeps
+func Prune(ctx context.Context, docID string, now time.Time, policy Policy) (int, error) {
+	versionTbl := env.Query(ctx).DocumentVersion
+	versions, err := versionTbl.
+		Where(versionTbl.DocumentID.Eq(docID)).
+		Where(versionTbl.IsAuto.Is(true)).
+		Where(versionTbl.CreatedAt.Lt(now.Add(-policy.KeepAllFor))).
+		Find()
+	if err != nil {
+		return 0, err
+	}
+
+	expired := Expired(versions, now, policy)
+	if len(expired) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]string, len(expired))
+	for i, version := range expired {
+		ids[i] = version.ID
+	}
+
+	result, err := versionTbl.
+		Where(versionTbl.ID.In(ids...)).
+		Where(versionTbl.IsAuto.Is(true)).
+		Delete()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+// PruneAll thins the automatic versions of every document that may have some
+// the policy no longer keeps
+func PruneAll(ctx context.Context, now time.Time, policy Policy) error {
+	log := env.SLog(ctx)
+
+	// versions only need thinning as they age past KeepAllFor or KeepHourlyFor,
+	// older ones were thinned on an earlier sweep. A day of slack covers
+	// sweeps that didn't run.
+	var docIDs []string
+	err := env.RawDB(ctx).Raw(`
+		SELECT document_id
+		FROM document_versions
+		WHERE is_auto
+		AND created_at < ?
+		AND created_at > ?
+		GROUP BY document_id
+		HAVING count(*) > 1
+	`, now.Add(-policy.KeepAllFor), now.Add(-policy.KeepHourlyFor-24*time.Hour)).Scan(&docIDs).Error
+	if err != nil {
+		return fmt.Errorf("error finding documents to prune: %w", err)
+	}
+
+	for _, docID := range docIDs {
+		n, err := Prune(ctx, docID, now, policy)
+		if err != nil {
+			log.Error("error pruning auto versions", "docID", docID, "error", err)
+			continue
+		}
+
+		if n > 0 {
+			log.Info("pruned auto versions", "docID", docID, "count", n)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package versions_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/service/versions"
+)
+
+func TestDue(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	policy := versions.DefaultPolicy
+	ago := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+
+	// being edited
+	name, ok := versions.Due(*ago(time.Minute), nil, now, policy)
+	assert.True(t, ok)
+	assert.Equal(t, versions.NameHourly, name)
+
+	_, ok = versions.Due(*ago(time.Minute), ago(30*time.Minute), now, policy)
+	assert.False(t, ok)
+
+	name, ok = versions.Due(*ago(time.Minute), ago(61*time.Minute), now, policy)
+	assert.True(t, ok)
+	assert.Equal(t, versions.NameHourly, name)
+
+	// changed since the last version but not being edited
+	_, ok = versions.Due(*ago(3 * time.Hour), ago(4*time.Hour), now, policy)
+	assert.False(t, ok)
+
+	name, ok = versions.Due(*ago(3 * time.Hour), ago(25*time.Hour), now, policy)
+	assert.True(t, ok)
+	assert.Equal(t, versions.NameDaily, name)
+
+	// nothing changed since the last version
+	_, ok = versions.Due(*ago(30 * time.Hour), ago(26*time.Hour), now, policy)
+	assert.False(t, ok)
+}
+
+func TestExpired(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	policy := versions.DefaultPolicy
+
+	var all []*models.DocumentVersion
+	add := func(id string, age time.Duration, isAuto bool) {
+		all = append(all, &models.DocumentVersion{
+			ID:        id,
+			CreatedAt: now.Add(-age),
+			IsAuto:    isAuto,
+		})
+	}
+
+	// within a day, all kept
+	add("recent-1", 10*time.Minute, true)
+	add("recent-2", 20*time.Minute, true)
+	// two days ago, one an hour
+	add("hourly-1", 48*time.Hour+10*time.Minute, true)
+	add("hourly-2", 48*time.Hour+20*time.Minute, true)
+	add("hourly-3", 49*time.Hour+10*time.Minute, true)
+	// two weeks ago, one a day
+	add("daily-1", 14*24*time.Hour+time.Hour, true)
+	add("daily-2", 14*24*time.Hour+2*time.Hour, true)
+	add("daily-3", 15*24*time.Hour+time.Hour, true)
+	// flagged by a user, never expires
+	add("flagged", 14*24*time.Hour+90*time.Minute, false)
+
+	expired := versions.Expired(all, now, policy)
+
+	ids := []string{}
+	for _, version := range expired {
+		ids = append(ids, version.ID)
+	}
+	require.ElementsMatch(t, []string{"hourly-2", "daily-2"}, ids)
+}
@@ -0,0 +1,176 @@
+package versions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/query"
+	"github.com/fivetentaylor/pointy/pkg/rogue"
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+var (
+	ErrVersionNotFound = errors.New("version not found")
+	ErrInvalidRef      = errors.New("only one of flagged version, timeline event or address can be set")
+)
+
+// Ref points at a version of a document. At most one field is set, when
+// none are it's the current version.
+type Ref struct {
+	FlaggedVersionID string
+	TimelineEventID  string
+	// Address is a json content address
+	Address string
+}
+
+func (ref Ref) IsNow() bool {
+	return ref.FlaggedVersionID == "" && ref.TimelineEventID == "" && ref.Address == ""
+}
+
+// Name labels the version in diffs
+func (ref Ref) Name() string {
+	switch {
+	case ref.FlaggedVersionID != "":
+		return "version/" + ref.FlaggedVersionID
+	case ref.TimelineEventID != "":
+		return "timeline/" + ref.TimelineEventID
+	case ref.Address != "":
+		return "address"
+	}
+
+	return "now"
+}
+
+type Comparison struct {
+	Changes []v3.Change
+	// Html is the doc with insertions and deletions marked up
+	Html string
+	// Markdown is a unified diff of the doc rendered as markdown
+	Markdown string
+}
+
+// Compare diffs two versions of a document the user can read
+func Compare(ctx context.Context, userID, docID string, from, to Ref) (*Comparison, error) {
+	_, err := query.GetReadableDocumentForUser(env.Query(ctx), docID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	docStore := rogue.NewDocStore(env.S3(ctx), env.Query(ctx), env.Redis(ctx))
+	_, doc, err := docStore.GetCurrentDoc(ctx, docID)
+	if err != nil {
+		return nil, fmt.Errorf("docStore.GetCurrentDoc(ctx, %s): %w", docID, err)
+	}
+
+	fromAddress, err := ResolveAddress(ctx, doc, docID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toAddress, err := ResolveAddress(ctx, doc, docID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := doc.Compare(fromAddress, toAddress)
+	if err != nil {
+		return nil, fmt.Errorf("doc.Compare(%v, %v): %w", fromAddress, toAddress, err)
+	}
+
+	firstID, lastID, err := doc.GetWrappingTotIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	html, err := doc.GetHtmlDiffBetween(firstID, lastID, fromAddress, toAddress, false, false)
+	if err != nil {
+		return nil, fmt.Errorf("doc.GetHtmlDiffBetween(%v, %v): %w", fromAddress, toAddress, err)
+	}
+
+	markdown, err := doc.GetMarkdownDiff(fromAddress, toAddress, from.Name(), to.Name())
+	if err != nil {
+		return nil, fmt.Errorf("doc.GetMarkdownDiff(%v, %v): %w", fromAddress, toAddress, err)
+	}
+
+	return &Comparison{
+		Changes:  changes,
+		Html:     html,
+		Markdown: markdown,
+	}, nil
+}
+
+// ResolveAddress finds the content address of a version of doc, it's nil for
+// the current version. Timeline events resolve to the address after the
+// event's edits.
+func ResolveAddress(ctx context.Context, doc *v3.Rogue, docID string, ref Ref) (*v3.ContentAddress, error) {
+	set := 0
+	for _, s := range []string{ref.FlaggedVersionID, ref.TimelineEventID, ref.Address} {
+		if s != "" {
+			set++
+		}
+	}
+
+	if set > 1 {
+		return nil, ErrInvalidRef
+	}
+
+	address := ""
+	switch {
+	case ref.FlaggedVersionID != "":
+		versionTbl := env.Query(ctx).DocumentVersion
+		version, err := versionTbl.
+			Where(versionTbl.ID.Eq(ref.FlaggedVersionID)).
+			Where(versionTbl.DocumentID.Eq(docID)).
+			First()
+		if err != nil {
+			return nil, ErrVersionNotFound
+		}
+		address = version.ContentAddress
+	case ref.TimelineEventID != "":
+		event, err := env.Dynamo(ctx).GetTimelineEvent(docID, ref.TimelineEventID)
+		if err != nil {
+			return nil, err
+		}
+
+		if event == nil {
+			return nil, ErrVersionNotFound
+		}
+
+		address = eventAddress(event.Event)
+		if address == "" {
+			return nil, ErrVersionNotFound
+		}
+	case ref.Address != "":
+		address = ref.Address
+	default:
+		return nil, nil
+	}
+
+	ca, err := v3.ParseContentAddress(address)
+	if err != nil || !doc.ValidAddress(*ca) {
+		return nil, ErrVersionNotFound
+	}
+
+	return ca, nil
+}
+
+// eventAddress is the address after the edits of timeline events that have one
+func eventAddress(event *models.TimelineEventPayload) string {
+	switch v := event.GetPayload().(type) {
+	case *models.TimelineEventPayload_Update:
+		return v.Update.EndingContentAddress
+	case *models.TimelineEventPayload_Paste:
+		return v.Paste.ContentAddressAfter
+	case *models.TimelineEventPayload_OfflineEdits:
+		return v.OfflineEdits.ContentAddressAfter
+	case *models.TimelineEventPayload_BranchMerge:
+		return v.BranchMerge.ContentAddressAfter
+	case *models.TimelineEventPayload_Restore:
+		return v.Restore.ContentAddressAfter
+	}
+
+	return ""
+}
@@ -0,0 +1,25 @@
+package versions
+
+import (
+	"context"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/query"
+)
+
+// List returns the versions of a document the user can read, newest first
+func List(ctx context.Context, userID, docID string, includeAuto bool) ([]*models.DocumentVersion, error) {
+	_, err := query.GetReadableDocumentForUser(env.Query(ctx), docID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	versionTbl := env.Query(ctx).DocumentVersion
+	q := versionTbl.Where(versionTbl.DocumentID.Eq(docID))
+	if !includeAuto {
+		q = q.Where(versionTbl.IsAuto.Is(false))
+	}
+
+	return q.Order(versionTbl.CreatedAt.Desc()).Find()
+}
@@ -0,0 +1,175 @@
+package versions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/query"
+	"github.com/fivetentaylor/pointy/pkg/rogue"
+	"github.com/fivetentaylor/pointy/pkg/service/document"
+	"github.com/fivetentaylor/pointy/pkg/service/timeline"
+	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+var (
+	ErrInvalidRange = errors.New("invalid range")
+	ErrNotRestore   = errors.New("timeline event is not a restore")
+)
+
+// Restore moves the document, or just the part of it between startID and
+// endID, back to how it was at address. It's applied as a rewind op so anyone
+// editing the document sees it straight away, and it's recorded on the
+// timeline so it can be undone with UndoRestore.
+func Restore(ctx context.Context, userID, docID, address string, startID, endID *string) (*models.Document, error) {
+	return restore(ctx, userID, docID, address, startID, endID, "")
+}
+
+// UndoRestore puts the part of the document a restore changed back to how it
+// was just before the restore
+func UndoRestore(ctx context.Context, userID, docID, eventID string) (*models.Document, error) {
+	_, err := query.GetEditableDocumentForUser(env.Query(ctx), docID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := env.Dynamo(ctx).GetTimelineEvent(docID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event == nil || event.Event.GetRestore() == nil {
+		return nil, ErrNotRestore
+	}
+
+	payload := event.Event.GetRestore()
+	return restore(
+		ctx, userID, docID, payload.ContentAddressBefore,
+		&payload.StartId, &payload.EndId, eventID,
+	)
+}
+
+func restore(ctx context.Context, userID, docID, address string, startID, endID *string, undoOf string) (*models.Document, error) {
+	log := env.SLog(ctx)
+	q := env.Query(ctx)
+
+	doc, err := query.GetEditableDocumentForUser(q, docID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	docStore := rogue.NewDocStore(env.S3(ctx), q, env.Redis(ctx))
+	_, r, err := docStore.GetCurrentDoc(ctx, docID)
+	if err != nil {
+		return nil, fmt.Errorf("docStore.GetCurrentDoc(ctx, %s): %w", docID, err)
+	}
+
+	target, err := v3.ParseContentAddress(address)
+	if err != nil || !r.ValidAddress(*target) {
+		return nil, ErrVersionNotFound
+	}
+
+	start, end, err := restoreRange(r, startID, endID)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := r.GetFullAddress()
+	if err != nil {
+		return nil, fmt.Errorf("r.GetFullAddress(): %w", err)
+	}
+
+	authorID, err := document.NewAuthorID(ctx, docID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("document.NewAuthorID(ctx, %s, %s): %w", docID, userID, err)
+	}
+	r.Author = authorID
+
+	mop, err := r.Rewind(start, end, *target)
+	if err != nil {
+		return nil, fmt.Errorf("r.Rewind(%v, %v, %v): %w", start, end, target, err)
+	}
+
+	err = rogue.CommitOp(ctx, docID, mop)
+	if err != nil {
+		return nil, err
+	}
+
+	// the op is committed at this point, a missing event shouldn't fail it
+	after, err := r.GetFullAddress()
+	if err != nil {
+		log.Error("error getting restore address", "error", err, "docID", docID)
+	}
+
+	beforeBytes, _ := json.Marshal(before)
+	afterBytes, _ := json.Marshal(after)
+	err = timeline.CreateTimelineEvent(ctx, &dynamo.TimelineEvent{
+		UserID:   userID,
+		AuthorID: authorID,
+		DocID:    docID,
+		Event: &models.TimelineEventPayload{
+			Payload: &models.TimelineEventPayload_Restore{
+				Restore: &models.TimelineRestore{
+					ContentAddressTarget: address,
+					ContentAddressBefore: string(beforeBytes),
+					ContentAddressAfter:  string(afterBytes),
+					StartId:              start.String(),
+					EndId:                end.String(),
+					UndoOfEventId:        undoOf,
+				},
+			},
+		},
+	})
+	if err != nil {
+		log.Error("error creating restore timeline event", "error", err, "docID", docID)
+	}
+
+	return doc, nil
+}
+
+// restoreRange parses the ids of a selected range, it's the whole document
+// when they aren't set. The start has to come before the end.
+func restoreRange(r *v3.Rogue, startID, endID *string) (start, end v3.ID, err error) {
+	start, end, err = r.GetWrappingTotIDs()
+	if err != nil {
+		return v3.NoID, v3.NoID, err
+	}
+
+	if (startID == nil || *startID == "") != (endID == nil || *endID == "") {
+		return v3.NoID, v3.NoID, ErrInvalidRange
+	}
+
+	if startID == nil || *startID == "" {
+		return start, end, nil
+	}
+
+	start, err = v3.ParseID(*startID)
+	if err != nil || !r.ContainsID(start) {
+		return v3.NoID, v3.NoID, ErrInvalidRange
+	}
+
+	end, err = v3.ParseID(*endID)
+	if err != nil || !r.ContainsID(end) {
+		return v3.NoID, v3.NoID, ErrInvalidRange
+	}
+
+	_, startIx, err := r.Rope.GetIndex(start)
+	if err != nil {
+		return v3.NoID, v3.NoID, ErrInvalidRange
+	}
+
+	_, endIx, err := r.Rope.GetIndex(end)
+	if err != nil {
+		return v3.NoID, v3.NoID, ErrInvalidRange
+	}
+
+	if startIx > endIx {
+		return v3.NoID, v3.NoID, ErrInvalidRange
+	}
+
+	return start, end, nil
+}
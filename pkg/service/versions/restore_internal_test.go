@@ -0,0 +1,58 @@
+package versions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+func TestRestoreRange(t *testing.T) {
+	r := v3.NewRogueForQuill("0")
+	_, err := r.Insert(0, "hello world")
+	require.NoError(t, err)
+
+	first, last, err := r.GetWrappingTotIDs()
+	require.NoError(t, err)
+
+	hello, err := r.Rope.GetVisID(0)
+	require.NoError(t, err)
+	world, err := r.Rope.GetVisID(6)
+	require.NoError(t, err)
+	ptr := func(id v3.ID) *string {
+		s := id.String()
+		return &s
+	}
+
+	// the whole document
+	start, end, err := restoreRange(r, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, first, start)
+	assert.Equal(t, last, end)
+
+	empty := ""
+	start, end, err = restoreRange(r, &empty, &empty)
+	require.NoError(t, err)
+	assert.Equal(t, first, start)
+	assert.Equal(t, last, end)
+
+	start, end, err = restoreRange(r, ptr(hello), ptr(world))
+	require.NoError(t, err)
+	assert.Equal(t, hello, start)
+	assert.Equal(t, world, end)
+
+	_, _, err = restoreRange(r, ptr(world), ptr(hello))
+	assert.ErrorIs(t, err, ErrInvalidRange, "start after end")
+
+	_, _, err = restoreRange(r, ptr(hello), nil)
+	assert.ErrorIs(t, err, ErrInvalidRange, "only one end")
+
+	_, _, err = restoreRange(r, ptr(hello), ptr(v3.ID{Author: "9", Seq: 99}))
+	assert.ErrorIs(t, err, ErrInvalidRange, "not in the document")
+
+	bad := "nope"
+	_, _, err = restoreRange(r, ptr(hello), &bad)
+	assert.ErrorIs(t, err, ErrInvalidRange, "not an id")
+}
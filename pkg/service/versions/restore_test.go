@@ -0,0 +1,77 @@
+package versions_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/rogue"
+	"github.com/fivetentaylor/pointy/pkg/service/versions"
+	"github.com/fivetentaylor/pointy/pkg/testutils"
+)
+
+func TestRestoreRangeAndUndo(t *testing.T) {
+	testutils.EnsureStorage()
+	ctx := testutils.TestContext()
+
+	docID := uuid.NewString()
+	owner := testutils.CreateUser(t, ctx)
+	_, store := testutils.CreateTestDocument(t, ctx, docID, "hello world")
+	testutils.AddOwnerToDocument(t, ctx, docID, owner.ID)
+
+	_, doc, err := store.GetCurrentDoc(ctx, docID)
+	require.NoError(t, err)
+
+	addr, err := doc.GetFullAddress()
+	require.NoError(t, err)
+	address, err := json.Marshal(addr)
+	require.NoError(t, err)
+
+	hello, err := doc.Rope.GetVisID(0)
+	require.NoError(t, err)
+	world, err := doc.Rope.GetVisID(10)
+	require.NoError(t, err)
+	start, end := hello.String(), world.String()
+
+	// one edit inside the range and one outside it
+	op, err := doc.Insert(6, "brave ")
+	require.NoError(t, err)
+	require.NoError(t, rogue.CommitOp(ctx, docID, op))
+
+	op, err = doc.Insert(0, "oh ")
+	require.NoError(t, err)
+	require.NoError(t, rogue.CommitOp(ctx, docID, op))
+
+	text := func() string {
+		_, doc, err := store.GetCurrentDoc(ctx, docID)
+		require.NoError(t, err)
+		return doc.GetText()
+	}
+	require.Equal(t, "oh hello brave world\n", text())
+
+	_, err = versions.Restore(ctx, owner.ID, docID, string(address), &end, &start)
+	require.ErrorIs(t, err, versions.ErrInvalidRange)
+
+	_, err = versions.Restore(ctx, owner.ID, docID, string(address), &start, &end)
+	require.NoError(t, err)
+	assert.Equal(t, "oh hello world\n", text())
+
+	events, err := env.Dynamo(ctx).GetDocumentTimeline(docID)
+	require.NoError(t, err)
+
+	var eventID string
+	for _, event := range events {
+		if event.Event.GetRestore() != nil {
+			eventID = event.EventID
+		}
+	}
+	require.NotEmpty(t, eventID)
+
+	_, err = versions.UndoRestore(ctx, owner.ID, docID, eventID)
+	require.NoError(t, err)
+	assert.Equal(t, "oh hello brave world\n", text())
+}
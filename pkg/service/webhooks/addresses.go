@@ -0,0 +1,113 @@
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var ErrDisallowedAddress = errors.New("webhooks can't be delivered to internal addresses")
+
+// AllowPrivateAddresses turns off the internal address checks, it's only for
+// tests that deliver to a local server
+var AllowPrivateAddresses = false
+
+// metadataAddresses are the cloud metadata endpoints that aren't already
+// covered by the link local and shared ranges
+var metadataAddresses = []netip.Addr{
+	netip.MustParseAddr("fd00:ec2::254"),
+}
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), it isn't
+// covered by IsPrivate but is only reachable from inside a provider's network
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// httpClient checks the address it connects to after the host has been
+// resolved, so a host that resolves to a public address when the webhook is
+// created and an internal one later is still refused. Redirects aren't
+// followed since they could point anywhere.
+var httpClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: checkDialAddress,
+		}).DialContext,
+		TLSHandshakeTimeout: 5 * time.Second,
+		MaxIdleConns:        10,
+		IdleConnTimeout:     90 * time.Second,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return fmt.Errorf("webhook redirected to %s, redirects aren't followed", req.URL.Redacted())
+	},
+}
+
+// IsAllowedAddress is false for loopback, private, shared, link local,
+// multicast, unspecified and metadata addresses
+func IsAllowedAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	if !addr.IsValid() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() ||
+		sharedAddressSpace.Contains(addr) {
+		return false
+	}
+
+	for _, metadata := range metadataAddresses {
+		if addr == metadata {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkDialAddress is called with the resolved address of every connection
+// the client makes
+func checkDialAddress(network, address string, _ syscall.RawConn) error {
+	if AllowPrivateAddresses {
+		return nil
+	}
+
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %w", address, err)
+	}
+
+	if !IsAllowedAddress(addrPort.Addr()) {
+		return ErrDisallowedAddress
+	}
+
+	return nil
+}
+
+// checkHost refuses webhook urls that are obviously internal, hosts that only
+// resolve to internal addresses are caught when delivering
+func checkHost(host string) error {
+	if AllowPrivateAddresses {
+		return nil
+	}
+
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".internal") {
+		return ErrDisallowedAddress
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err == nil && !IsAllowedAddress(addr) {
+		return ErrDisallowedAddress
+	}
+
+	return nil
+}
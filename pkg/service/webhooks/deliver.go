@@ -0,0 +1,205 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jpoz/conveyor"
+	"gorm.io/gorm"
+
+	"github.com/fivetentaylor/pointy/pkg/background/wire"
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+)
+
+const (
+	SignatureHeader = "X-Pointy-Signature"
+	TimestampHeader = "X-Pointy-Timestamp"
+	EventHeader     = "X-Pointy-Event"
+	DeliveryHeader  = "X-Pointy-Delivery"
+)
+
+// MaxAttempts is how many times a delivery is tried before it's marked failed
+const MaxAttempts = 8
+
+const (
+	baseBackoff = 30 * time.Second
+	maxBackoff  = time.Hour
+)
+
+// Deliver posts a pending delivery to its webhook. Failed attempts are
+// retried with an exponential backoff by re-enqueueing the delivery, so
+// errors from the webhook itself aren't returned.
+func Deliver(ctx context.Context, deliveryID string) error {
+	q := env.Query(ctx)
+	log := env.SLog(ctx)
+
+	deliveryTbl := q.WebhookDelivery
+	delivery, err := deliveryTbl.Where(deliveryTbl.ID.Eq(deliveryID)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// the webhook was deleted along with its deliveries
+			return nil
+		}
+		return fmt.Errorf("error getting delivery: %w", err)
+	}
+
+	if delivery.Status != StatusPending {
+		return nil
+	}
+
+	webhookTbl := q.Webhook
+	webhook, err := webhookTbl.Where(webhookTbl.ID.Eq(delivery.WebhookID)).First()
+	if err != nil {
+		return fmt.Errorf("error getting webhook: %w", err)
+	}
+
+	updates := map[string]interface{}{
+		"attempts":   delivery.Attempts + 1,
+		"updated_at": time.Now(),
+	}
+
+	var deliveryErr error
+	if !webhook.Active {
+		deliveryErr = fmt.Errorf("webhook is disabled")
+	} else {
+		var status int
+		status, deliveryErr = send(ctx, webhook, delivery)
+		if status != 0 {
+			updates["response_status"] = status
+		}
+	}
+
+	switch {
+	case deliveryErr == nil:
+		updates["status"] = StatusSucceeded
+		updates["error"] = nil
+		updates["next_attempt_at"] = nil
+	case !webhook.Active || delivery.Attempts+1 >= MaxAttempts:
+		updates["status"] = StatusFailed
+		updates["error"] = deliveryErr.Error()
+		updates["next_attempt_at"] = nil
+	default:
+		delay := Backoff(delivery.Attempts + 1)
+		updates["error"] = deliveryErr.Error()
+		updates["next_attempt_at"] = time.Now().Add(delay)
+
+		_, err = env.Background(ctx).Enqueue(ctx, &wire.DeliverWebhook{
+			DeliveryId: delivery.ID,
+		}, conveyor.Delay(delay))
+		if err != nil {
+			log.Error("error re-enqueueing webhook delivery", "deliveryID", delivery.ID, "error", err)
+			updates["status"] = StatusFailed
+		}
+	}
+
+	_, err = deliveryTbl.Where(deliveryTbl.ID.Eq(delivery.ID)).Updates(updates)
+	if err != nil {
+		return fmt.Errorf("error updating delivery: %w", err)
+	}
+
+	if deliveryErr != nil {
+		log.Warn("webhook delivery failed", "deliveryID", delivery.ID, "webhookID", webhook.ID, "attempt", delivery.Attempts+1, "error", deliveryErr)
+	}
+
+	return nil
+}
+
+// Redeliver sends a failed delivery again with a fresh set of attempts
+func Redeliver(ctx context.Context, deliveryID string) error {
+	deliveryTbl := env.Query(ctx).WebhookDelivery
+
+	_, err := deliveryTbl.
+		Where(deliveryTbl.ID.Eq(deliveryID)).
+		Updates(map[string]interface{}{
+			"status":          StatusPending,
+			"attempts":        0,
+			"next_attempt_at": nil,
+			"updated_at":      time.Now(),
+		})
+	if err != nil {
+		return fmt.Errorf("error updating delivery: %w", err)
+	}
+
+	_, err = env.Background(ctx).Enqueue(ctx, &wire.DeliverWebhook{
+		DeliveryId: deliveryID,
+	})
+	return err
+}
+
+// send posts the delivery's payload and returns the response status, any
+// non 2xx response is an error
+func send(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("error creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Pointy-Webhooks/1.0")
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, body))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(EventHeader, delivery.Event)
+	req.Header.Set(DeliveryHeader, delivery.ID)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature header for a payload, an HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the webhook's secret
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature header the way a receiver should, receivers
+// should also reject old timestamps to stop replays
+func Verify(secret, timestamp, signature string, body []byte) bool {
+	if !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body)))
+}
+
+// Backoff is how long to wait after a delivery's nth failed attempt, it
+// doubles each time from 30 seconds up to an hour
+func Backoff(attempts int32) time.Duration {
+	delay := baseBackoff
+	for i := int32(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+
+	return delay
+}
@@ -0,0 +1,156 @@
+package webhooks_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/service/webhooks"
+	"github.com/fivetentaylor/pointy/pkg/testutils"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"event":"comment.created"}`)
+	signature := webhooks.Sign("whsec_test", "1700000000", body)
+
+	assert.True(t, webhooks.Verify("whsec_test", "1700000000", signature, body))
+	assert.False(t, webhooks.Verify("whsec_other", "1700000000", signature, body))
+	assert.False(t, webhooks.Verify("whsec_test", "1700000001", signature, body))
+	assert.False(t, webhooks.Verify("whsec_test", "1700000000", signature, []byte(`{}`)))
+	assert.False(t, webhooks.Verify("whsec_test", "1700000000", signature[len("sha256="):], body))
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, webhooks.Backoff(1))
+	assert.Equal(t, time.Minute, webhooks.Backoff(2))
+	assert.Equal(t, 4*time.Minute, webhooks.Backoff(4))
+	assert.Equal(t, time.Hour, webhooks.Backoff(webhooks.MaxAttempts))
+}
+
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+func TestIsAllowedAddress(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "100.64.0.1", "100.100.100.200", "100.127.255.254", "0.0.0.0", "::1", "fe80::1", "fd00:ec2::254", "::ffff:127.0.0.1"} {
+		assert.False(t, webhooks.IsAllowedAddress(netip.MustParseAddr(addr)), addr)
+	}
+
+	for _, addr := range []string{"93.184.216.34", "100.128.0.1", "2606:2800:220:1::"} {
+		assert.True(t, webhooks.IsAllowedAddress(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestDeliverRefusesInternalAddresses(t *testing.T) {
+	testutils.EnsureStorage()
+	ctx := testutils.TestContext()
+
+	user := testutils.CreateUser(t, ctx)
+
+	for _, u := range []string{"http://localhost:8080/hook", "http://127.0.0.1/hook", "http://169.254.169.254/latest/meta-data", "http://[::1]/hook"} {
+		_, err := webhooks.Create(ctx, user.ID, webhooks.CreateInput{
+			URL:    u,
+			Events: []string{webhooks.EventCommentCreated},
+		})
+		assert.ErrorIs(t, err, webhooks.ErrDisallowedAddress, u)
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	testutils.EnsureStorage()
+	ctx := testutils.TestContext()
+
+	// the test server is on localhost
+	webhooks.AllowPrivateAddresses = true
+	defer func() { webhooks.AllowPrivateAddresses = false }()
+
+	requests := make(chan received, 10)
+	status := http.StatusInternalServerError
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- received{header: r.Header, body: body}
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	user := testutils.CreateUser(t, ctx)
+	docID := uuid.NewString()
+	testutils.CreateTestDocument(t, ctx, docID, "hello")
+	testutils.AddOwnerToDocument(t, ctx, docID, user.ID)
+
+	webhook, err := webhooks.Create(ctx, user.ID, webhooks.CreateInput{
+		URL:        srv.URL,
+		DocumentID: &docID,
+		Events:     []string{webhooks.EventCommentCreated},
+	})
+	require.NoError(t, err)
+
+	// not subscribed to this event
+	err = webhooks.Enqueue(ctx, docID, webhooks.EventThreadResolved, webhooks.ResolutionData{})
+	require.NoError(t, err)
+
+	err = webhooks.Enqueue(ctx, docID, webhooks.EventCommentCreated, webhooks.CommentData{
+		EventID: "e1",
+		UserID:  user.ID,
+		Content: "looks good",
+	})
+	require.NoError(t, err)
+
+	deliveryTbl := env.Query(ctx).WebhookDelivery
+	deliveries, err := deliveryTbl.Where(deliveryTbl.WebhookID.Eq(webhook.ID)).Find()
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	delivery := deliveries[0]
+
+	// the first attempt fails and is scheduled again
+	err = webhooks.Deliver(ctx, delivery.ID)
+	require.NoError(t, err)
+	<-requests
+
+	delivery, err = deliveryTbl.Where(deliveryTbl.ID.Eq(delivery.ID)).First()
+	require.NoError(t, err)
+	assert.Equal(t, webhooks.StatusPending, delivery.Status)
+	assert.Equal(t, int32(1), delivery.Attempts)
+	assert.Equal(t, int32(http.StatusInternalServerError), delivery.ResponseStatus)
+	assert.False(t, delivery.NextAttemptAt.IsZero())
+
+	status = http.StatusOK
+	err = webhooks.Deliver(ctx, delivery.ID)
+	require.NoError(t, err)
+	req := <-requests
+
+	assert.Equal(t, webhooks.EventCommentCreated, req.header.Get(webhooks.EventHeader))
+	assert.Equal(t, delivery.ID, req.header.Get(webhooks.DeliveryHeader))
+	assert.True(t, webhooks.Verify(
+		webhook.Secret,
+		req.header.Get(webhooks.TimestampHeader),
+		req.header.Get(webhooks.SignatureHeader),
+		req.body,
+	))
+
+	var payload struct {
+		Event      string               `json:"event"`
+		DocumentID string               `json:"documentId"`
+		Data       webhooks.CommentData `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(req.body, &payload))
+	assert.Equal(t, webhooks.EventCommentCreated, payload.Event)
+	assert.Equal(t, docID, payload.DocumentID)
+	assert.Equal(t, "looks good", payload.Data.Content)
+
+	delivery, err = deliveryTbl.Where(deliveryTbl.ID.Eq(delivery.ID)).First()
+	require.NoError(t, err)
+	assert.Equal(t, webhooks.StatusSucceeded, delivery.Status)
+	assert.Equal(t, int32(2), delivery.Attempts)
+	assert.Nil(t, delivery.Error)
+}
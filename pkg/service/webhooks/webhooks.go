@@ -0,0 +1,325 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fivetentaylor/pointy/pkg/background/wire"
+	"github.com/fivetentaylor/pointy/pkg/env"
+	"github.com/fivetentaylor/pointy/pkg/models"
+	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
+)
+
+const (
+	EventDocumentUpdated = "document.updated"
+	EventCommentCreated  = "comment.created"
+	EventThreadResolved  = "thread.resolved"
+)
+
+var Events = []string{EventDocumentUpdated, EventCommentCreated, EventThreadResolved}
+
+const (
+	StatusPending   = "pending"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+const secretPrefix = "whsec_"
+
+var ErrNotFound = errors.New("webhook not found")
+
+type CreateInput struct {
+	URL string
+	// DocumentID limits the webhook to one document, otherwise it gets events
+	// for every document the user can access
+	DocumentID *string
+	// Events limits which events are sent, all of them are sent when empty
+	Events []string
+}
+
+// Payload is the JSON body posted to a webhook
+type Payload struct {
+	Event      string    `json:"event"`
+	DocumentID string    `json:"documentId"`
+	CreatedAt  time.Time `json:"createdAt"`
+	Data       any       `json:"data"`
+}
+
+type CommentData struct {
+	EventID           string `json:"eventId"`
+	ThreadID          string `json:"threadId,omitempty"`
+	UserID            string `json:"userId"`
+	Content           string `json:"content"`
+	SelectionMarkdown string `json:"selectionMarkdown,omitempty"`
+}
+
+type ResolutionData struct {
+	EventID  string `json:"eventId"`
+	ThreadID string `json:"threadId"`
+	UserID   string `json:"userId"`
+}
+
+type DocumentUpdateData struct {
+	EventID string `json:"eventId"`
+	UserID  string `json:"userId"`
+	Title   string `json:"title"`
+	Summary string `json:"summary"`
+}
+
+// Create adds a webhook for the user, the secret used to sign its deliveries
+// is generated here
+func Create(ctx context.Context, userID string, input CreateInput) (*models.Webhook, error) {
+	q := env.Query(ctx)
+
+	u, err := url.Parse(input.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("webhook url must be an http or https url")
+	}
+
+	err = checkHost(u.Hostname())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range input.Events {
+		if !slices.Contains(Events, event) {
+			return nil, fmt.Errorf("unknown event %q", event)
+		}
+	}
+
+	if input.DocumentID != nil {
+		docAccessTbl := q.DocumentAccess
+		count, err := docAccessTbl.
+			Where(docAccessTbl.UserID.Eq(userID)).
+			Where(docAccessTbl.DocumentID.Eq(*input.DocumentID)).
+			Count()
+		if err != nil {
+			return nil, fmt.Errorf("error checking document access: %w", err)
+		}
+
+		if count == 0 {
+			return nil, fmt.Errorf("webhooks can only be added to documents you have access to")
+		}
+	}
+
+	secret := make([]byte, 32)
+	_, err = rand.Read(secret)
+	if err != nil {
+		return nil, fmt.Errorf("error generating secret: %w", err)
+	}
+
+	webhook := &models.Webhook{
+		UserID:     userID,
+		DocumentID: input.DocumentID,
+		URL:        u.String(),
+		Secret:     secretPrefix + hex.EncodeToString(secret),
+		Events:     strings.Join(input.Events, ","),
+		Active:     true,
+	}
+
+	err = q.Webhook.Create(webhook)
+	if err != nil {
+		return nil, fmt.Errorf("error creating webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// List returns the user's webhooks, only the ones for documentID when it's set
+func List(ctx context.Context, userID string, documentID *string) ([]*models.Webhook, error) {
+	webhookTbl := env.Query(ctx).Webhook
+
+	query := webhookTbl.Where(webhookTbl.UserID.Eq(userID))
+	if documentID != nil {
+		query = query.Where(webhookTbl.DocumentID.Eq(*documentID))
+	}
+
+	return query.Order(webhookTbl.CreatedAt.Desc()).Find()
+}
+
+// Delete removes one of the user's webhooks along with its delivery log
+func Delete(ctx context.Context, userID, webhookID string) error {
+	webhookTbl := env.Query(ctx).Webhook
+
+	result, err := webhookTbl.
+		Where(webhookTbl.ID.Eq(webhookID)).
+		Where(webhookTbl.UserID.Eq(userID)).
+		Delete()
+	if err != nil {
+		return fmt.Errorf("error deleting webhook: %w", err)
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Deliveries returns the most recent deliveries to one of the user's webhooks
+func Deliveries(ctx context.Context, userID, webhookID string, limit int) ([]*models.WebhookDelivery, error) {
+	q := env.Query(ctx)
+
+	webhookTbl := q.Webhook
+	count, err := webhookTbl.
+		Where(webhookTbl.ID.Eq(webhookID)).
+		Where(webhookTbl.UserID.Eq(userID)).
+		Count()
+	if err != nil {
+		return nil, fmt.Errorf("error getting webhook: %w", err)
+	}
+
+	if count == 0 {
+		return nil, ErrNotFound
+	}
+
+	deliveryTbl := q.WebhookDelivery
+	return deliveryTbl.
+		Where(deliveryTbl.WebhookID.Eq(webhookID)).
+		Order(deliveryTbl.CreatedAt.Desc()).
+		Limit(limit).
+		Find()
+}
+
+// EventsFor is nil when the webhook gets every event
+func EventsFor(webhook *models.Webhook) []string {
+	if webhook.Events == "" {
+		return nil
+	}
+
+	return strings.Split(webhook.Events, ",")
+}
+
+// Subscribed reports whether the webhook wants event for docID
+func Subscribed(webhook *models.Webhook, docID, event string) bool {
+	if !webhook.Active {
+		return false
+	}
+
+	if webhook.DocumentID != nil && *webhook.DocumentID != docID {
+		return false
+	}
+
+	events := EventsFor(webhook)
+	return len(events) == 0 || slices.Contains(events, event)
+}
+
+// EnqueueForTimelineEvent sends the webhook events for a new timeline event,
+// timeline events that webhooks don't cover are ignored
+func EnqueueForTimelineEvent(ctx context.Context, event *dynamo.TimelineEvent) error {
+	switch p := event.Event.GetPayload().(type) {
+	case *models.TimelineEventPayload_Message:
+		return Enqueue(ctx, event.DocID, EventCommentCreated, CommentData{
+			EventID:           event.EventID,
+			ThreadID:          event.ReplyToID,
+			UserID:            event.UserID,
+			Content:           p.Message.Content,
+			SelectionMarkdown: p.Message.SelectionMarkdown,
+		})
+	case *models.TimelineEventPayload_Resolution:
+		if !p.Resolution.Resolved {
+			return nil
+		}
+
+		return Enqueue(ctx, event.DocID, EventThreadResolved, ResolutionData{
+			EventID:  event.EventID,
+			ThreadID: event.ReplyToID,
+			UserID:   event.UserID,
+		})
+	case *models.TimelineEventPayload_Update:
+		// updates are created while they're still being summarized, they're
+		// sent once the summary is done
+		if p.Update.State != models.UpdateState_COMPLETE_STATE {
+			return nil
+		}
+
+		return Enqueue(ctx, event.DocID, EventDocumentUpdated, DocumentUpdateData{
+			EventID: event.EventID,
+			UserID:  event.UserID,
+			Title:   p.Update.Title,
+			Summary: p.Update.Content,
+		})
+	}
+
+	return nil
+}
+
+// Enqueue records a delivery for every webhook subscribed to event on docID
+// and queues them to be sent. Webhooks only get events for documents their
+// owner can still access.
+func Enqueue(ctx context.Context, docID, event string, data any) error {
+	q := env.Query(ctx)
+	log := env.SLog(ctx)
+
+	var userIDs []string
+	docAccessTbl := q.DocumentAccess
+	err := docAccessTbl.
+		Where(docAccessTbl.DocumentID.Eq(docID)).
+		Pluck(docAccessTbl.UserID, &userIDs)
+	if err != nil {
+		return fmt.Errorf("error getting document users: %w", err)
+	}
+
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	webhookTbl := q.Webhook
+	webhooks, err := webhookTbl.
+		Where(webhookTbl.UserID.In(userIDs...)).
+		Where(webhookTbl.Active.Is(true)).
+		Find()
+	if err != nil {
+		return fmt.Errorf("error getting webhooks: %w", err)
+	}
+
+	var payload []byte
+	for _, webhook := range webhooks {
+		if !Subscribed(webhook, docID, event) {
+			continue
+		}
+
+		if payload == nil {
+			payload, err = json.Marshal(Payload{
+				Event:      event,
+				DocumentID: docID,
+				CreatedAt:  time.Now().UTC(),
+				Data:       data,
+			})
+			if err != nil {
+				return fmt.Errorf("error marshalling payload: %w", err)
+			}
+		}
+
+		delivery := &models.WebhookDelivery{
+			WebhookID: webhook.ID,
+			Event:     event,
+			Payload:   string(payload),
+			Status:    StatusPending,
+		}
+
+		deliveryTbl := q.WebhookDelivery
+		err = deliveryTbl.Omit(deliveryTbl.ResponseStatus, deliveryTbl.Error, deliveryTbl.NextAttemptAt).Create(delivery)
+		if err != nil {
+			return fmt.Errorf("error creating delivery: %w", err)
+		}
+
+		_, err = env.Background(ctx).Enqueue(ctx, &wire.DeliverWebhook{
+			DeliveryId: delivery.ID,
+		})
+		if err != nil {
+			log.Error("error enqueueing webhook delivery", "deliveryID", delivery.ID, "error", err)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package dynamo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+var aiFeedbackPrefix = "aifb#"
+
+// AIFeedback is a user's thumbs up or down on an ask ai message, kept by the
+// prompt that wrote the message so prompts can be compared
+type AIFeedback struct {
+	// PK
+	PromptName string `json:"promptName"`
+	// SK
+	MessageID string `json:"messageID"`
+	UserID    string `json:"userID"`
+
+	// Attributes
+	DocID     string `json:"docID"`
+	ThreadID  string `json:"threadID"`
+	Positive  bool   `json:"positive"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
+type dAIFeedback struct {
+	// aifb#{PromptName}
+	PK string `dynamodbav:"PK"`
+	// {MessageID}#{UserID}
+	SK string `dynamodbav:"SK"`
+
+	DocID     string `dynamodbav:"docID"`
+	ThreadID  string `dynamodbav:"threadID"`
+	Positive  bool   `dynamodbav:"positive"`
+	CreatedAt int64  `dynamodbav:"createdAt"`
+}
+
+func (item AIFeedback) Key() map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"PK": {S: aws.String(fmt.Sprintf("%s%s", aiFeedbackPrefix, item.PromptName))},
+		"SK": {S: aws.String(fmt.Sprintf("%s#%s", item.MessageID, item.UserID))},
+	}
+}
+
+func (item AIFeedback) MarshalDynamoDBAttributeValue(av *dynamodb.AttributeValue) error {
+	key := item.Key()
+	marshalItem := dAIFeedback{
+		PK:        *key["PK"].S,
+		SK:        *key["SK"].S,
+		DocID:     item.DocID,
+		ThreadID:  item.ThreadID,
+		Positive:  item.Positive,
+		CreatedAt: item.CreatedAt,
+	}
+	m, err := dynamodbattribute.MarshalMap(marshalItem)
+	if err != nil {
+		return err
+	}
+	av.M = m
+	return nil
+}
+
+func (item *AIFeedback) UnmarshalDynamoDBAttributeValue(av *dynamodb.AttributeValue) error {
+	tmp := dAIFeedback{}
+
+	if err := dynamodbattribute.UnmarshalMap(av.M, &tmp); err != nil {
+		return err
+	}
+
+	item.PromptName = strings.TrimPrefix(tmp.PK, aiFeedbackPrefix)
+	item.MessageID, item.UserID, _ = strings.Cut(tmp.SK, "#")
+	item.DocID = tmp.DocID
+	item.ThreadID = tmp.ThreadID
+	item.Positive = tmp.Positive
+	item.CreatedAt = tmp.CreatedAt
+
+	return nil
+}
+
+// UpsertAIFeedback saves the user's feedback on the message, replacing any
+// they gave before
+func (db *DB) UpsertAIFeedback(f *AIFeedback) error {
+	f.CreatedAt = time.Now().UnixNano()
+
+	av, err := dynamodbattribute.MarshalMap(f)
+	if err != nil {
+		return fmt.Errorf("UpsertAIFeedback: failed to marshal feedback: %s", err)
+	}
+
+	_, err = db.Client.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(db.TableName),
+		Item:      av,
+	})
+	if err != nil {
+		return fmt.Errorf("UpsertAIFeedback(%+v): %w", f, err)
+	}
+
+	return nil
+}
+
+// DeleteAIFeedback removes the user's feedback on the message if it's the
+// positive or negative kind given
+func (db *DB) DeleteAIFeedback(promptName, messageID, userID string, positive bool) error {
+	f := AIFeedback{PromptName: promptName, MessageID: messageID, UserID: userID}
+
+	_, err := db.Client.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName:           aws.String(db.TableName),
+		Key:                 f.Key(),
+		ConditionExpression: aws.String("positive = :positive"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":positive": {BOOL: aws.Bool(positive)},
+		},
+	})
+	if err != nil {
+		if isConditionalCheckFailedError(err) {
+			return nil
+		}
+		return fmt.Errorf("DeleteAIFeedback(%q, %q, %q): %w", promptName, messageID, userID, err)
+	}
+
+	return nil
+}
+
+func (db *DB) GetAIFeedbackForPrompt(promptName string) ([]AIFeedback, error) {
+	pk := fmt.Sprintf("%s%s", aiFeedbackPrefix, promptName)
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(db.TableName),
+		KeyConditionExpression: aws.String("PK = :PK"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":PK": {S: aws.String(pk)},
+		},
+	}
+
+	feedback := []AIFeedback{}
+	var unmarshalErr error
+	err := db.Client.QueryPages(input, func(page *dynamodb.QueryOutput, lastPage bool) bool {
+		for _, item := range page.Items {
+			f := AIFeedback{}
+			if unmarshalErr = dynamodbattribute.UnmarshalMap(item, &f); unmarshalErr != nil {
+				return false
+			}
+			feedback = append(feedback, f)
+		}
+		return true
+	})
+	if err == nil {
+		err = unmarshalErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetAIFeedbackForPrompt(%q): %w", promptName, err)
+	}
+
+	return feedback, nil
+}
@@ -0,0 +1,165 @@
+package dynamo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+var NotificationMutePrefix = "mute#"
+
+// NotificationMute stops a user getting notifications for a document, or for
+// one thread on it when ThreadID is set
+type NotificationMute struct {
+	// PK
+	UserID string `json:"userID"`
+	// SK
+	DocID    string `json:"docID"`
+	ThreadID string `json:"threadID"`
+
+	// Attributes
+	CreatedAt int64 `json:"createdAt"`
+}
+
+type dNotificationMute struct {
+	// PK: mute#userID
+	PK string `dynamodbav:"PK"`
+	// SK: docID or docID#threadID
+	SK string `dynamodbav:"SK"`
+
+	CreatedAt int64 `dynamodbav:"createdAt"`
+}
+
+func (m NotificationMute) Key() map[string]*dynamodb.AttributeValue {
+	sk := m.DocID
+	if m.ThreadID != "" {
+		sk = fmt.Sprintf("%s#%s", m.DocID, m.ThreadID)
+	}
+
+	return map[string]*dynamodb.AttributeValue{
+		"PK": {S: aws.String(fmt.Sprintf("%s%s", NotificationMutePrefix, m.UserID))},
+		"SK": {S: aws.String(sk)},
+	}
+}
+
+func (m NotificationMute) MarshalDynamoDBAttributeValue(av *dynamodb.AttributeValue) error {
+	key := m.Key()
+	marshalItem := dNotificationMute{
+		PK:        *key["PK"].S,
+		SK:        *key["SK"].S,
+		CreatedAt: m.CreatedAt,
+	}
+
+	ma, err := dynamodbattribute.MarshalMap(marshalItem)
+	if err != nil {
+		return err
+	}
+	av.M = ma
+
+	return nil
+}
+
+func (m *NotificationMute) UnmarshalDynamoDBAttributeValue(av *dynamodb.AttributeValue) error {
+	tmp := dNotificationMute{}
+
+	if err := dynamodbattribute.UnmarshalMap(av.M, &tmp); err != nil {
+		return err
+	}
+
+	m.UserID = strings.TrimPrefix(tmp.PK, NotificationMutePrefix)
+	m.DocID, m.ThreadID, _ = strings.Cut(tmp.SK, "#")
+	m.CreatedAt = tmp.CreatedAt
+
+	return nil
+}
+
+func (db *DB) MuteNotifications(m *NotificationMute) error {
+	if m.UserID == "" {
+		return fmt.Errorf("userID cannot be empty")
+	}
+	if m.DocID == "" {
+		return fmt.Errorf("docID cannot be empty")
+	}
+	m.CreatedAt = time.Now().UnixNano()
+
+	av, err := dynamodbattribute.MarshalMap(m)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification mute: %s", err)
+	}
+
+	_, err = db.Client.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(db.TableName),
+		Item:      av,
+	})
+	if err != nil {
+		return fmt.Errorf("MuteNotifications(%+v): %w", m, err)
+	}
+
+	return nil
+}
+
+func (db *DB) UnmuteNotifications(userID, docID, threadID string) error {
+	m := NotificationMute{UserID: userID, DocID: docID, ThreadID: threadID}
+
+	_, err := db.Client.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String(db.TableName),
+		Key:       m.Key(),
+	})
+	if err != nil {
+		return fmt.Errorf("UnmuteNotifications(%q, %q, %q): %w", userID, docID, threadID, err)
+	}
+
+	return nil
+}
+
+// IsNotificationMuted is true if the user muted the document or, when
+// threadID is set, that thread on it
+func (db *DB) IsNotificationMuted(userID, docID, threadID string) (bool, error) {
+	keys := []map[string]*dynamodb.AttributeValue{
+		NotificationMute{UserID: userID, DocID: docID}.Key(),
+	}
+	if threadID != "" {
+		keys = append(keys, NotificationMute{UserID: userID, DocID: docID, ThreadID: threadID}.Key())
+	}
+
+	result, err := db.Client.BatchGetItem(&dynamodb.BatchGetItemInput{
+		RequestItems: map[string]*dynamodb.KeysAndAttributes{
+			db.TableName: {Keys: keys},
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("IsNotificationMuted(%q, %q, %q): %w", userID, docID, threadID, err)
+	}
+
+	return len(result.Responses[db.TableName]) > 0, nil
+}
+
+func (db *DB) GetNotificationMutesForUser(userID string) ([]*NotificationMute, error) {
+	pk := fmt.Sprintf("%s%s", NotificationMutePrefix, userID)
+
+	results, err := db.Client.Query(&dynamodb.QueryInput{
+		TableName:              aws.String(db.TableName),
+		KeyConditionExpression: aws.String("PK = :PK"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":PK": {S: aws.String(pk)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetNotificationMutesForUser(%q): %w", userID, err)
+	}
+
+	mutes := make([]*NotificationMute, len(results.Items))
+	for i, item := range results.Items {
+		m := &NotificationMute{}
+		if err := dynamodbattribute.UnmarshalMap(item, m); err != nil {
+			return nil, fmt.Errorf("GetNotificationMutesForUser(%q): %w", userID, err)
+		}
+		mutes[i] = m
+	}
+
+	return mutes, nil
+}
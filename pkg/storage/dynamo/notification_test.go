@@ -131,3 +131,48 @@ func TestNotification_ReadNotification(t *testing.T) {
 	assert.Equal(t, notf.UserID, userID)
 	assert.NotEmpty(t, notf.CreatedAt)
 }
+
+func TestNotification_Mute(t *testing.T) {
+	db, err := dynamo.NewDB()
+	require.NoError(t, err)
+
+	userID := uuid.New().String()
+	docID := uuid.New().String()
+	threadID := uuid.New().String()
+
+	muted, err := db.IsNotificationMuted(userID, docID, threadID)
+	require.NoError(t, err)
+	assert.False(t, muted)
+
+	err = db.MuteNotifications(&dynamo.NotificationMute{UserID: userID, DocID: docID, ThreadID: threadID})
+	require.NoError(t, err)
+
+	muted, err = db.IsNotificationMuted(userID, docID, threadID)
+	require.NoError(t, err)
+	assert.True(t, muted)
+
+	// the rest of the document isn't muted
+	muted, err = db.IsNotificationMuted(userID, docID, "")
+	require.NoError(t, err)
+	assert.False(t, muted)
+
+	err = db.MuteNotifications(&dynamo.NotificationMute{UserID: userID, DocID: docID})
+	require.NoError(t, err)
+
+	muted, err = db.IsNotificationMuted(userID, docID, uuid.New().String())
+	require.NoError(t, err)
+	assert.True(t, muted)
+
+	mutes, err := db.GetNotificationMutesForUser(userID)
+	require.NoError(t, err)
+	assert.Len(t, mutes, 2)
+
+	err = db.UnmuteNotifications(userID, docID, "")
+	require.NoError(t, err)
+	err = db.UnmuteNotifications(userID, docID, threadID)
+	require.NoError(t, err)
+
+	muted, err = db.IsNotificationMuted(userID, docID, threadID)
+	require.NoError(t, err)
+	assert.False(t, muted)
+}
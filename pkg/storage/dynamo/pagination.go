@@ -1,9 +1,64 @@
 package dynamo
 
-import "github.com/aws/aws-sdk-go/service/dynamodb"
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
 
 // Pagination parameters
 type PaginationParams struct {
 	Limit             int64                               // Number of items to be returned in each page
 	ExclusiveStartKey map[string]*dynamodb.AttributeValue // Key to start with for the next page
 }
+
+// EncodeCursor turns the last evaluated key of a page into an opaque cursor
+// for clients, it's empty when there are no more pages. Our keys only have
+// string attributes.
+func EncodeCursor(key map[string]*dynamodb.AttributeValue) (string, error) {
+	if len(key) == 0 {
+		return "", nil
+	}
+
+	values := make(map[string]string, len(key))
+	for name, av := range key {
+		if av.S == nil {
+			return "", fmt.Errorf("EncodeCursor: %s is not a string", name)
+		}
+		values[name] = *av.S
+	}
+
+	bts, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bts), nil
+}
+
+// DecodeCursor is the exclusive start key for the page after cursor
+func DecodeCursor(cursor string) (map[string]*dynamodb.AttributeValue, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	bts, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeCursor(%q): %w", cursor, err)
+	}
+
+	values := map[string]string{}
+	if err := json.Unmarshal(bts, &values); err != nil {
+		return nil, fmt.Errorf("DecodeCursor(%q): %w", cursor, err)
+	}
+
+	key := make(map[string]*dynamodb.AttributeValue, len(values))
+	for name, value := range values {
+		key[name] = &dynamodb.AttributeValue{S: aws.String(value)}
+	}
+
+	return key, nil
+}
@@ -0,0 +1,36 @@
+package dynamo
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	key := map[string]*dynamodb.AttributeValue{
+		"PK":  {S: aws.String("notif#user")},
+		"SK":  {S: aws.String("timeline#event")},
+		"SK1": {S: aws.String("false#1715342400000000000")},
+	}
+
+	cursor, err := EncodeCursor(key)
+	require.NoError(t, err)
+	require.NotEmpty(t, cursor)
+
+	decoded, err := DecodeCursor(cursor)
+	require.NoError(t, err)
+	require.Equal(t, key, decoded)
+
+	cursor, err = EncodeCursor(nil)
+	require.NoError(t, err)
+	require.Empty(t, cursor)
+
+	decoded, err = DecodeCursor("")
+	require.NoError(t, err)
+	require.Nil(t, decoded)
+
+	_, err = DecodeCursor("not a cursor")
+	require.Error(t, err)
+}
@@ -0,0 +1,155 @@
+package dynamo
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+var reactionPrefix = "react#"
+
+// Reaction is a user's emoji on a timeline comment or ask ai message
+type Reaction struct {
+	// PK
+	TargetID string `json:"targetID"`
+	// SK
+	UserID string `json:"userID"`
+	Emoji  string `json:"emoji"`
+
+	// Attributes
+	DocID     string `json:"docID"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
+type dReaction struct {
+	// react#{TargetID}
+	PK string `dynamodbav:"PK"`
+	// {UserID}#{Emoji}
+	SK string `dynamodbav:"SK"`
+
+	DocID     string `dynamodbav:"docID"`
+	CreatedAt int64  `dynamodbav:"createdAt"`
+}
+
+func (item Reaction) Key() map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"PK": {S: aws.String(fmt.Sprintf("%s%s", reactionPrefix, item.TargetID))},
+		"SK": {S: aws.String(fmt.Sprintf("%s#%s", item.UserID, item.Emoji))},
+	}
+}
+
+func (item Reaction) MarshalDynamoDBAttributeValue(av *dynamodb.AttributeValue) error {
+	key := item.Key()
+	marshalItem := dReaction{
+		PK:        *key["PK"].S,
+		SK:        *key["SK"].S,
+		DocID:     item.DocID,
+		CreatedAt: item.CreatedAt,
+	}
+	m, err := dynamodbattribute.MarshalMap(marshalItem)
+	if err != nil {
+		return err
+	}
+	av.M = m
+	return nil
+}
+
+func (item *Reaction) UnmarshalDynamoDBAttributeValue(av *dynamodb.AttributeValue) error {
+	tmp := dReaction{}
+
+	if err := dynamodbattribute.UnmarshalMap(av.M, &tmp); err != nil {
+		return err
+	}
+
+	item.TargetID = strings.TrimPrefix(tmp.PK, reactionPrefix)
+	// user ids never have a # but some emoji do, like #️⃣
+	item.UserID, item.Emoji, _ = strings.Cut(tmp.SK, "#")
+	item.DocID = tmp.DocID
+	item.CreatedAt = tmp.CreatedAt
+
+	return nil
+}
+
+// AddReaction saves the reaction, reacting with the same emoji twice keeps the
+// first one
+func (db *DB) AddReaction(r *Reaction) error {
+	r.CreatedAt = time.Now().UnixNano()
+
+	av, err := dynamodbattribute.MarshalMap(r)
+	if err != nil {
+		return fmt.Errorf("AddReaction: failed to marshal reaction: %s", err)
+	}
+
+	_, err = db.Client.PutItem(&dynamodb.PutItemInput{
+		TableName:           aws.String(db.TableName),
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(PK) AND attribute_not_exists(SK)"),
+	})
+	if err != nil {
+		if isConditionalCheckFailedError(err) {
+			return nil
+		}
+		return fmt.Errorf("AddReaction(%+v): %w", r, err)
+	}
+
+	return nil
+}
+
+func (db *DB) RemoveReaction(targetID, userID, emoji string) error {
+	r := Reaction{TargetID: targetID, UserID: userID, Emoji: emoji}
+
+	_, err := db.Client.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String(db.TableName),
+		Key:       r.Key(),
+	})
+	if err != nil {
+		return fmt.Errorf("RemoveReaction(%q, %q, %q): %w", targetID, userID, emoji, err)
+	}
+
+	return nil
+}
+
+// GetReactions returns the reactions on a timeline event or message, oldest
+// first
+func (db *DB) GetReactions(targetID string) ([]Reaction, error) {
+	pk := fmt.Sprintf("%s%s", reactionPrefix, targetID)
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(db.TableName),
+		KeyConditionExpression: aws.String("PK = :PK"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":PK": {S: aws.String(pk)},
+		},
+	}
+
+	reactions := []Reaction{}
+	var unmarshalErr error
+	err := db.Client.QueryPages(input, func(page *dynamodb.QueryOutput, lastPage bool) bool {
+		for _, item := range page.Items {
+			r := Reaction{}
+			if unmarshalErr = dynamodbattribute.UnmarshalMap(item, &r); unmarshalErr != nil {
+				return false
+			}
+			reactions = append(reactions, r)
+		}
+		return true
+	})
+	if err == nil {
+		err = unmarshalErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetReactions(%q): %w", targetID, err)
+	}
+
+	slices.SortFunc(reactions, func(a, b Reaction) int {
+		return cmp.Compare(a.CreatedAt, b.CreatedAt)
+	})
+
+	return reactions, nil
+}
@@ -0,0 +1,66 @@
+package dynamo_test
+
+import (
+	"testing"
+
+	"github.com/fivetentaylor/pointy/pkg/storage/dynamo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReaction_AddRemove(t *testing.T) {
+	db, err := dynamo.NewDB()
+	require.NoError(t, err)
+
+	targetID := uuid.New().String()
+	userID := uuid.New().String()
+	otherUserID := uuid.New().String()
+
+	require.NoError(t, db.AddReaction(&dynamo.Reaction{TargetID: targetID, UserID: userID, Emoji: "👍", DocID: "docID"}))
+	require.NoError(t, db.AddReaction(&dynamo.Reaction{TargetID: targetID, UserID: otherUserID, Emoji: "#️⃣", DocID: "docID"}))
+	// reacting twice with the same emoji is a no-op
+	require.NoError(t, db.AddReaction(&dynamo.Reaction{TargetID: targetID, UserID: userID, Emoji: "👍", DocID: "docID"}))
+
+	reactions, err := db.GetReactions(targetID)
+	require.NoError(t, err)
+	require.Len(t, reactions, 2)
+
+	assert.Equal(t, userID, reactions[0].UserID)
+	assert.Equal(t, "👍", reactions[0].Emoji)
+	assert.Equal(t, otherUserID, reactions[1].UserID)
+	assert.Equal(t, "#️⃣", reactions[1].Emoji)
+	assert.Equal(t, "docID", reactions[1].DocID)
+
+	require.NoError(t, db.RemoveReaction(targetID, userID, "👍"))
+
+	reactions, err = db.GetReactions(targetID)
+	require.NoError(t, err)
+	require.Len(t, reactions, 1)
+	assert.Equal(t, otherUserID, reactions[0].UserID)
+}
+
+func TestReaction_AIFeedback(t *testing.T) {
+	db, err := dynamo.NewDB()
+	require.NoError(t, err)
+
+	promptName := uuid.New().String()
+	userID := uuid.New().String()
+
+	f := &dynamo.AIFeedback{PromptName: promptName, MessageID: "messageID", UserID: userID, Positive: true}
+	require.NoError(t, db.UpsertAIFeedback(f))
+
+	// removing the other kind of feedback leaves it alone
+	require.NoError(t, db.DeleteAIFeedback(promptName, "messageID", userID, false))
+
+	feedback, err := db.GetAIFeedbackForPrompt(promptName)
+	require.NoError(t, err)
+	require.Len(t, feedback, 1)
+	assert.Equal(t, *f, feedback[0])
+
+	require.NoError(t, db.DeleteAIFeedback(promptName, "messageID", userID, true))
+
+	feedback, err = db.GetAIFeedbackForPrompt(promptName)
+	require.NoError(t, err)
+	assert.Empty(t, feedback)
+}
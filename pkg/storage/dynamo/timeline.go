@@ -177,6 +177,13 @@ func (db *DB) GetDocumentTimelineReplies(docId string, eventID string) ([]*Timel
 	return db.getTimelineEvents(docId, fmt.Sprintf("reply#%s", eventID))
 }
 
+// GetAllDocumentTimelineReplies returns the replies to every comment in the
+// document, grouped by the comment they reply to and in the order they were
+// created
+func (db *DB) GetAllDocumentTimelineReplies(docId string) ([]*TimelineEvent, error) {
+	return db.getTimelineEvents(docId, "reply#")
+}
+
 func (db *DB) GetLastUserUpdate(docId, userID string) (*TimelineEvent, error) {
 	events, err := db.getTimelineEvents(docId, DefaultTimelineChain)
 	if err != nil {
@@ -370,24 +377,25 @@ func (db *DB) getTimelineEvents(docId, chain string) ([]*TimelineEvent, error) {
 		ScanIndexForward: aws.Bool(true),
 	}
 
-	results, err := db.Client.Query(query)
+	var events []*TimelineEvent
+	var unmarshalErr error
+	err := db.Client.QueryPages(query, func(page *dynamodb.QueryOutput, lastPage bool) bool {
+		for _, item := range page.Items {
+			msg := TimelineEvent{}
+			unmarshalErr = dynamodbattribute.UnmarshalMap(item, &msg)
+			if unmarshalErr != nil {
+				return false
+			}
+			events = append(events, &msg)
+		}
+		return true
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	if len(results.Items) == 0 {
-		return nil, nil
-	}
-
-	events := make([]*TimelineEvent, len(results.Items))
-	for i, item := range results.Items {
-		msg := TimelineEvent{}
-		err := dynamodbattribute.UnmarshalMap(item, &msg)
-		if err != nil {
-			log.Errorf("failed to unmarshal message map: %s", err)
-			return nil, err
-		}
-		events[i] = &msg
+	if unmarshalErr != nil {
+		log.Errorf("failed to unmarshal message map: %s", unmarshalErr)
+		return nil, unmarshalErr
 	}
 
 	return events, nil
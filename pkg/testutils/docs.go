@@ -48,12 +48,16 @@ func CreateTestDocument(t *testing.T, ctx context.Context, docID string, content
 }
 
 func AddOwnerToDocument(t *testing.T, ctx context.Context, docID string, userID string) {
+	AddUserToDocument(t, ctx, docID, userID, "owner")
+}
+
+func AddUserToDocument(t *testing.T, ctx context.Context, docID string, userID string, accessLevel string) {
 	q := env.Query(ctx)
 	documentTbl := q.Document
 
 	document, err := documentTbl.Where(documentTbl.ID.Eq(docID)).First()
 	if err != nil {
-		t.Fatalf("AddOwnerToDocument() failed to get document: error = %v", err)
+		t.Fatalf("AddUserToDocument() failed to get document: error = %v", err)
 	}
 
 	docAccessTbl := q.DocumentAccess
@@ -61,12 +65,12 @@ func AddOwnerToDocument(t *testing.T, ctx context.Context, docID string, userID
 	accessModel := &models.DocumentAccess{
 		UserID:      userID,
 		DocumentID:  document.ID,
-		AccessLevel: "owner",
+		AccessLevel: accessLevel,
 	}
 
 	err = docAccessTbl.Create(accessModel)
 	if err != nil {
-		t.Fatalf("AddOwnerToDocument() failed to create document access: error = %v", err)
+		t.Fatalf("AddUserToDocument() failed to create document access: error = %v", err)
 	}
 }
 
@@ -0,0 +1,73 @@
+package v3
+
+import "fmt"
+
+// Anchor is where a span of text selected at some content address, e.g. the
+// text a comment is attached to, is in the current document
+type Anchor struct {
+	StartID ID
+	EndID   ID
+
+	// Orphaned is set when all of the anchored text has been deleted, the span
+	// is then the line the text used to be on
+	Orphaned bool
+
+	// Changed is set when the anchored text has been edited since the address
+	Changed bool
+}
+
+// ResolveAnchor finds the current visible span of the text that was between
+// startID and endID at address, a nil address means the text as it is now
+func (r *Rogue) ResolveAnchor(startID, endID ID, address *ContentAddress) (*Anchor, error) {
+	orig, err := r.Filter(startID, endID, address)
+	if err != nil {
+		return nil, fmt.Errorf("Filter(%v, %v): %w", startID, endID, err)
+	}
+
+	survivors := []ID{}
+	if orig != nil {
+		for _, id := range orig.IDs {
+			isDel, err := r.IsDeletedAt(id, nil)
+			if err != nil {
+				return nil, fmt.Errorf("IsDeletedAt(%v): %w", id, err)
+			}
+
+			if !isDel {
+				survivors = append(survivors, id)
+			}
+		}
+	}
+
+	if len(survivors) == 0 {
+		nearest, err := r.NearestAt(startID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("NearestAt(%v): %w", startID, err)
+		}
+
+		lineStartID, lineEndID, err := r.IDsToEnclosingSpan([]ID{nearest}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("IDsToEnclosingSpan(%v): %w", nearest, err)
+		}
+
+		return &Anchor{
+			StartID:  lineStartID,
+			EndID:    lineEndID,
+			Orphaned: true,
+			Changed:  true,
+		}, nil
+	}
+
+	anchor := &Anchor{
+		StartID: survivors[0],
+		EndID:   survivors[len(survivors)-1],
+	}
+
+	// catches deletes as well as text inserted inside the span
+	text, err := r.GetPlaintext(anchor.StartID, anchor.EndID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("GetPlaintext(%v, %v): %w", anchor.StartID, anchor.EndID, err)
+	}
+	anchor.Changed = text != Uint16ToStr(orig.Text)
+
+	return anchor, nil
+}
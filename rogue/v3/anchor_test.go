@@ -0,0 +1,90 @@
+package v3_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+func TestResolveAnchor(t *testing.T) {
+	setup := func(t *testing.T) (r *v3.Rogue, startID, endID v3.ID, address *v3.ContentAddress) {
+		r = v3.NewRogueForQuill("0")
+		_, err := r.Insert(0, "first line\nhello world\nlast line")
+		require.NoError(t, err)
+
+		// anchored to "world"
+		startID, err = r.Rope.GetVisID(17)
+		require.NoError(t, err)
+		endID, err = r.Rope.GetVisID(21)
+		require.NoError(t, err)
+
+		address, err = r.GetFullAddress()
+		require.NoError(t, err)
+
+		return r, startID, endID, address
+	}
+
+	anchorText := func(t *testing.T, r *v3.Rogue, anchor *v3.Anchor) string {
+		text, err := r.GetPlaintext(anchor.StartID, anchor.EndID, nil)
+		require.NoError(t, err)
+		return text
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		r, startID, endID, address := setup(t)
+
+		// edits outside of the anchor don't count
+		_, err := r.Insert(0, "new ")
+		require.NoError(t, err)
+
+		anchor, err := r.ResolveAnchor(startID, endID, address)
+		require.NoError(t, err)
+		require.False(t, anchor.Orphaned)
+		require.False(t, anchor.Changed)
+		require.Equal(t, startID, anchor.StartID)
+		require.Equal(t, endID, anchor.EndID)
+	})
+
+	t.Run("edges deleted", func(t *testing.T) {
+		r, startID, endID, address := setup(t)
+
+		_, err := r.Delete(21, 1)
+		require.NoError(t, err)
+		_, err = r.Delete(17, 1)
+		require.NoError(t, err)
+
+		anchor, err := r.ResolveAnchor(startID, endID, address)
+		require.NoError(t, err)
+		require.False(t, anchor.Orphaned)
+		require.True(t, anchor.Changed)
+		require.Equal(t, "orl", anchorText(t, r, anchor))
+	})
+
+	t.Run("text inserted", func(t *testing.T) {
+		r, startID, endID, address := setup(t)
+
+		_, err := r.Insert(19, "RR")
+		require.NoError(t, err)
+
+		anchor, err := r.ResolveAnchor(startID, endID, address)
+		require.NoError(t, err)
+		require.False(t, anchor.Orphaned)
+		require.True(t, anchor.Changed)
+		require.Equal(t, "woRRrld", anchorText(t, r, anchor))
+	})
+
+	t.Run("orphaned", func(t *testing.T) {
+		r, startID, endID, address := setup(t)
+
+		_, err := r.Delete(16, 6)
+		require.NoError(t, err)
+
+		anchor, err := r.ResolveAnchor(startID, endID, address)
+		require.NoError(t, err)
+		require.True(t, anchor.Orphaned)
+		require.True(t, anchor.Changed)
+		require.Equal(t, "hello\n", anchorText(t, r, anchor))
+	})
+}
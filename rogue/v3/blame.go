@@ -0,0 +1,176 @@
+package v3
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+)
+
+const BlameColors = 8
+
+// BlameSpan is a contiguous run of visible text written by one author. The
+// LastAuthor is whoever last brought the text back, e.g. by undoing a delete
+// or rewinding to an older version, and is the Author if nobody has.
+type BlameSpan struct {
+	StartID    ID     `json:"startID"`
+	EndID      ID     `json:"endID"`
+	Author     string `json:"author"`
+	LastAuthor string `json:"lastAuthor"`
+	FirstSeq   int    `json:"firstSeq"`
+	LastSeq    int    `json:"lastSeq"`
+	Text       string `json:"text"`
+}
+
+// IsAI reports whether the span was written by an AI author
+func (s BlameSpan) IsAI() bool {
+	return IsAIAuthor(s.Author)
+}
+
+func IsAIAuthor(author string) bool {
+	return len(author) > 0 && author[0] == '!'
+}
+
+// Blame attributes the visible text between startID and endID at the
+// address, or the current text if address is nil, to the authors that wrote it
+func (r *Rogue) Blame(startID, endID ID, address *ContentAddress) ([]BlameSpan, error) {
+	vis, err := r.Filter(startID, endID, address)
+	if err != nil {
+		return nil, fmt.Errorf("Filter(%v, %v, %v): %w", startID, endID, address, err)
+	}
+
+	if vis == nil || len(vis.IDs) == 0 {
+		return []BlameSpan{}, nil
+	}
+
+	return r.blameVis(vis, address)
+}
+
+func (r *Rogue) blameVis(vis *FugueDiff, address *ContentAddress) ([]BlameSpan, error) {
+	addrStartIx, addrEndIx := 0, r.TotSize
+	if address != nil {
+		var err error
+		_, addrStartIx, err = r.Rope.GetIndex(address.StartID)
+		if err != nil {
+			return nil, err
+		}
+
+		_, addrEndIx, err = r.Rope.GetIndex(address.EndID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	spans := []BlameSpan{}
+	start := 0
+	for i, id := range vis.IDs {
+		addr := address
+		if addr != nil && (vis.TotIxs[i] < addrStartIx || vis.TotIxs[i] > addrEndIx) {
+			addr = nil
+		}
+
+		last, err := r.lastRestore(id, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(spans) > 0 {
+			span := &spans[len(spans)-1]
+			if span.Author == id.Author && span.LastAuthor == last.Author {
+				span.EndID = id
+				span.FirstSeq = min(span.FirstSeq, id.Seq)
+				span.LastSeq = max(span.LastSeq, last.Seq)
+				continue
+			}
+
+			span.Text = Uint16ToStr(vis.Text[start:i])
+			start = i
+		}
+
+		spans = append(spans, BlameSpan{
+			StartID:    id,
+			EndID:      id,
+			Author:     id.Author,
+			LastAuthor: last.Author,
+			FirstSeq:   id.Seq,
+			LastSeq:    last.Seq,
+		})
+	}
+
+	spans[len(spans)-1].Text = Uint16ToStr(vis.Text[start:])
+
+	return spans, nil
+}
+
+// lastRestore returns the ID of the op that last brought back the visible
+// char id after it was deleted, or id itself if it never was. Rewinds mark
+// every char in their range so only the first undelete after a delete counts.
+func (r *Rogue) lastRestore(id ID, address *ContentAddress) (ID, error) {
+	ch := r.CharHistory[id]
+	if ch == nil {
+		return id, nil
+	}
+
+	last, restore := id, id
+	err := ch.ReverseDft(func(m *Marker) error {
+		if address != nil && !address.Contains(m.ID) {
+			return nil
+		}
+
+		if m.IsDel {
+			last = restore
+			return ErrorStopIteration{}
+		}
+
+		restore = m.ID
+		return nil
+	})
+	if err != nil && !errors.As(err, &ErrorStopIteration{}) {
+		return NoID, err
+	}
+
+	return last, nil
+}
+
+// GetHtmlBlame renders the html like GetHtmlAtAddress with each author's
+// text wrapped in a span so it can be color coded
+func (r *Rogue) GetHtmlBlame(startID, endID ID, address *ContentAddress, includeIDs, smartQuote bool) (string, error) {
+	vis, spanNOS, lineNOS, err := r.ToIndexNos(startID, endID, address, smartQuote)
+	if err != nil {
+		return "", fmt.Errorf("r.ToIndexNos(%v, %v, %v): %w", startID, endID, address, err)
+	}
+
+	if vis == nil {
+		return "", nil
+	}
+
+	spans, err := r.blameVis(vis, address)
+	if err != nil {
+		return "", fmt.Errorf("blameVis(): %w", err)
+	}
+
+	ix := 0
+	for _, span := range spans {
+		n := len(StrToUint16(span.Text))
+		spanNOS.Insert(NOSNode{
+			StartIx: ix,
+			EndIx:   ix + n - 1,
+			Format:  FormatV3Span{"blame": span.Author},
+		})
+		ix += n
+	}
+
+	fVis := &FugueVis{
+		Text: vis.Text,
+		IDs:  vis.IDs,
+	}
+
+	return ToHtml(fVis, spanNOS, lineNOS, includeIDs)
+}
+
+// BlameColor picks a stable color index for an author so they get the same
+// color across renders
+func BlameColor(author string) int {
+	h := fnv.New32a()
+	h.Write([]byte(author))
+	return int(h.Sum32() % BlameColors)
+}
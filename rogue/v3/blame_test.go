@@ -0,0 +1,106 @@
+package v3_test
+
+import (
+	"fmt"
+	"testing"
+
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlame(t *testing.T) {
+	t.Parallel()
+
+	r := v3.NewRogueForQuill("1")
+	_, err := r.Insert(0, "Hello World!")
+	require.NoError(t, err)
+
+	r.Author = "!1"
+	_, err = r.Insert(12, " Nice to meet you.")
+	require.NoError(t, err)
+
+	blame, err := r.Blame(v3.RootID, v3.LastID, nil)
+	require.NoError(t, err)
+	require.Equal(t, []v3.BlameSpan{
+		{
+			StartID:    v3.ID{Author: "1", Seq: 3},
+			EndID:      v3.ID{Author: "1", Seq: 14},
+			Author:     "1",
+			LastAuthor: "1",
+			FirstSeq:   3,
+			LastSeq:    14,
+			Text:       "Hello World!",
+		},
+		{
+			StartID:    v3.ID{Author: "!1", Seq: 15},
+			EndID:      v3.ID{Author: "!1", Seq: 32},
+			Author:     "!1",
+			LastAuthor: "!1",
+			FirstSeq:   15,
+			LastSeq:    32,
+			Text:       " Nice to meet you.",
+		},
+		{
+			StartID:    v3.LastID,
+			EndID:      v3.LastID,
+			Author:     "q",
+			LastAuthor: "q",
+			FirstSeq:   1,
+			LastSeq:    1,
+			Text:       "\n",
+		},
+	}, blame)
+	require.True(t, blame[1].IsAI())
+
+	address, err := r.GetFullAddress()
+	require.NoError(t, err)
+
+	// someone else deletes the greeting and rewinds to get it back
+	r.Author = "2"
+	_, err = r.Delete(0, 6)
+	require.NoError(t, err)
+
+	blame, err = r.Blame(v3.RootID, v3.LastID, nil)
+	require.NoError(t, err)
+	require.Equal(t, "World!", blame[0].Text)
+	require.Equal(t, v3.ID{Author: "1", Seq: 9}, blame[0].StartID)
+
+	_, err = r.Rewind(v3.RootID, v3.LastID, *address)
+	require.NoError(t, err)
+
+	blame, err = r.Blame(v3.RootID, v3.LastID, nil)
+	require.NoError(t, err)
+	require.Len(t, blame, 4)
+	require.Equal(t, "Hello ", blame[0].Text)
+	require.Equal(t, "1", blame[0].Author)
+	require.Equal(t, "2", blame[0].LastAuthor)
+	require.Equal(t, 3, blame[0].FirstSeq)
+	require.Equal(t, "World!", blame[1].Text)
+	require.Equal(t, "1", blame[1].LastAuthor)
+
+	// at the address the restore hasn't happened yet
+	blame, err = r.Blame(v3.RootID, v3.LastID, address)
+	require.NoError(t, err)
+	require.Len(t, blame, 3)
+	require.Equal(t, "Hello World!", blame[0].Text)
+	require.Equal(t, "1", blame[0].LastAuthor)
+}
+
+func TestGetHtmlBlame(t *testing.T) {
+	t.Parallel()
+
+	r := v3.NewRogueForQuill("1")
+	_, err := r.Insert(0, "Hello World!")
+	require.NoError(t, err)
+
+	r.Author = "!1"
+	_, err = r.Insert(12, " Hi!")
+	require.NoError(t, err)
+
+	html, err := r.GetHtmlBlame(v3.RootID, v3.LastID, nil, false, false)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf(
+		`<p><span data-blame-author="1" data-author-prefix="1" data-blame-color="%d">Hello World!</span><span data-blame-author="!1" data-author-prefix="!"> Hi!</span></p>`,
+		v3.BlameColor("1"),
+	), html)
+}
@@ -0,0 +1,302 @@
+package v3
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// branchText is the visible text of a doc along with the span and line
+// format of each char, line formats are only set on newlines
+type branchText struct {
+	text   []uint16
+	ids    []ID
+	totIxs []int
+	spans  []FormatV3Span
+	lines  []FormatV3
+}
+
+func (r *Rogue) branchText(address *ContentAddress) (*branchText, error) {
+	firstID, lastID, err := r.GetWrappingTotIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	vis, spanNOS, lineNOS, err := r.ToIndexNos(firstID, lastID, address, false)
+	if err != nil {
+		return nil, fmt.Errorf("ToIndexNos(%v, %v, %v): %w", firstID, lastID, address, err)
+	}
+
+	if vis == nil {
+		return &branchText{}, nil
+	}
+
+	bt := &branchText{
+		text:   vis.Text,
+		ids:    vis.IDs,
+		totIxs: vis.TotIxs,
+		spans:  make([]FormatV3Span, len(vis.Text)),
+		lines:  make([]FormatV3, len(vis.Text)),
+	}
+
+	for _, node := range spanNOS.AsSlice() {
+		f, ok := node.Format.DropNull().(FormatV3Span)
+		if !ok {
+			continue
+		}
+
+		for i := node.StartIx; i <= node.EndIx && i < len(bt.spans); i++ {
+			bt.spans[i] = f
+		}
+	}
+
+	for _, node := range lineNOS.AsSlice() {
+		if node.EndIx < len(bt.lines) {
+			bt.lines[node.EndIx] = node.Format
+		}
+	}
+
+	for i := range bt.text {
+		if bt.spans[i] == nil {
+			bt.spans[i] = FormatV3Span{}
+		}
+
+		if bt.text[i] == '\n' && bt.lines[i] == nil {
+			bt.lines[i] = FormatV3Line{}
+		}
+	}
+
+	return bt, nil
+}
+
+// MergeBranch replays the edits made on branch since it was forked from r at
+// base. The branch's text is diffed against r's text at base and the changes
+// are made relative to the ids they touched, so anything r has changed since
+// the fork is kept. The branch's formatting is copied over wherever it
+// differs from base.
+func (r *Rogue) MergeBranch(author string, base *ContentAddress, branch *Rogue) (MultiOp, error) {
+	mop := MultiOp{}
+
+	authorBefore := r.Author
+	defer func() { r.Author = authorBefore }()
+	r.Author = author
+
+	baseText, err := r.branchText(base)
+	if err != nil {
+		return mop, fmt.Errorf("branchText(%v): %w", base, err)
+	}
+
+	branchText, err := branch.branchText(nil)
+	if err != nil {
+		return mop, fmt.Errorf("branch.branchText(): %w", err)
+	}
+
+	firstID, err := r.GetFirstTotID()
+	if err != nil {
+		return mop, fmt.Errorf("GetFirstTotID(): %w", err)
+	}
+
+	// the id in r of each of the branch's chars and the index of the char
+	// in base, or -1 if the branch inserted it
+	mapped := make([]ID, len(branchText.text))
+	baseIxs := make([]int, len(branchText.text))
+	deleted := []ID{}
+
+	baseIx, branchIx := 0, 0
+	diffs := DiffWords(Uint16ToStr(baseText.text), Uint16ToStr(branchText.text))
+	for _, d := range diffs {
+		n := UTF16Length(d.Text)
+
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			for k := 0; k < n; k++ {
+				mapped[branchIx+k] = baseText.ids[baseIx+k]
+				baseIxs[branchIx+k] = baseIx + k
+			}
+			baseIx += n
+			branchIx += n
+		case diffmatchpatch.DiffDelete:
+			deleted = append(deleted, baseText.ids[baseIx:baseIx+n]...)
+			baseIx += n
+		case diffmatchpatch.DiffInsert:
+			anchor := firstID
+			if baseIx > 0 {
+				anchor = baseText.ids[baseIx-1]
+			}
+
+			op, err := r.InsertRightOf(anchor, d.Text)
+			if err != nil {
+				return mop, fmt.Errorf("InsertRightOf(%v, %q): %w", anchor, d.Text, err)
+			}
+			mop = mop.Append(op)
+
+			for k := 0; k < n; k++ {
+				mapped[branchIx+k] = ID{Author: op.ID.Author, Seq: op.ID.Seq + k}
+				baseIxs[branchIx+k] = -1
+			}
+			branchIx += n
+		}
+	}
+
+	dop, err := r.deleteIDs(deleted)
+	if err != nil {
+		return mop, fmt.Errorf("deleteIDs(): %w", err)
+	}
+	mop = mop.Append(dop)
+
+	fop, err := r.copyBranchFormats(baseText, branchText, mapped, baseIxs)
+	if err != nil {
+		return mop, fmt.Errorf("copyBranchFormats(): %w", err)
+	}
+	mop = mop.Append(fop)
+
+	if len(mop.Mops) > 0 {
+		r.OpIndex.Put(mop)
+	}
+
+	return mop, nil
+}
+
+// deleteIDs deletes the chars that are still visible, working backwards so
+// the indices of the remaining runs don't move
+func (r *Rogue) deleteIDs(ids []ID) (MultiOp, error) {
+	mop := MultiOp{}
+
+	visIxs := make([]int, 0, len(ids))
+	for _, id := range ids {
+		isDeleted, err := r.Rope.IsDeleted(id)
+		if err != nil {
+			return mop, err
+		}
+
+		if isDeleted {
+			continue
+		}
+
+		visIx, _, err := r.Rope.GetIndex(id)
+		if err != nil {
+			return mop, err
+		}
+
+		visIxs = append(visIxs, visIx)
+	}
+
+	slices.Sort(visIxs)
+
+	end := len(visIxs) - 1
+	for i := end; i >= 0; i-- {
+		if i > 0 && visIxs[i-1] == visIxs[i]-1 {
+			continue
+		}
+
+		op, err := r.Delete(visIxs[i], visIxs[end]-visIxs[i]+1)
+		if err != nil {
+			return mop, err
+		}
+		mop = mop.Append(op)
+
+		end = i - 1
+	}
+
+	return mop, nil
+}
+
+// copyBranchFormats formats the merged chars like they are on the branch.
+// Only chars the branch inserted or reformatted are touched so formatting
+// done on r since the fork is kept everywhere else.
+func (r *Rogue) copyBranchFormats(baseText, branchText *branchText, mapped []ID, baseIxs []int) (MultiOp, error) {
+	mop := MultiOp{}
+
+	type run struct {
+		visIx  int
+		length int
+		format FormatV3Span
+	}
+
+	runs := []run{}
+	for i, id := range mapped {
+		if branchText.text[i] == '\n' {
+			continue
+		}
+
+		want := branchText.spans[i]
+		if baseIxs[i] >= 0 && want.Equals(baseText.spans[baseIxs[i]]) {
+			continue
+		}
+
+		visIx, ok, err := r.visIndex(id)
+		if err != nil {
+			return mop, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		if len(runs) > 0 {
+			last := &runs[len(runs)-1]
+			if last.visIx+last.length == visIx && last.format.Equals(want) {
+				last.length++
+				continue
+			}
+		}
+
+		runs = append(runs, run{visIx: visIx, length: 1, format: want})
+	}
+
+	for _, run := range runs {
+		f := run.format.Copy().(FormatV3Span)
+		f["e"] = "true"
+		f["en"] = "true"
+
+		op, err := r.Format(run.visIx, run.length, f)
+		if err != nil {
+			return mop, fmt.Errorf("Format(%d, %d, %v): %w", run.visIx, run.length, f, err)
+		}
+		mop = mop.Append(op)
+	}
+
+	for i, id := range mapped {
+		if branchText.text[i] != '\n' {
+			continue
+		}
+
+		want := branchText.lines[i]
+		if baseIxs[i] >= 0 && want.Equals(baseText.lines[baseIxs[i]]) {
+			continue
+		}
+
+		visIx, ok, err := r.visIndex(id)
+		if err != nil {
+			return mop, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		op, err := r.Format(visIx, 1, want)
+		if err != nil {
+			return mop, fmt.Errorf("Format(%d, 1, %v): %w", visIx, want, err)
+		}
+		mop = mop.Append(op)
+	}
+
+	return mop, nil
+}
+
+// visIndex returns the visible index of id, ok is false if it's deleted
+func (r *Rogue) visIndex(id ID) (visIx int, ok bool, err error) {
+	isDeleted, err := r.Rope.IsDeleted(id)
+	if err != nil || isDeleted {
+		return -1, false, err
+	}
+
+	visIx, _, err = r.Rope.GetIndex(id)
+	if err != nil {
+		return -1, false, err
+	}
+
+	return visIx, true, nil
+}
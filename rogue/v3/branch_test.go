@@ -0,0 +1,100 @@
+package v3_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+func forkBranch(t *testing.T, parent *v3.Rogue) (*v3.ContentAddress, *v3.Rogue) {
+	address, err := parent.GetFullAddress()
+	require.NoError(t, err)
+
+	branch, err := parent.Compact(address)
+	require.NoError(t, err)
+	branch.Author = "b"
+
+	return address, branch
+}
+
+func TestMergeBranch(t *testing.T) {
+	t.Parallel()
+
+	parent := v3.NewRogueForQuill("1")
+	_, _, err := parent.InsertMarkdown(0, "# Plan\n\nWe should ship it on Monday.\n\nThe end")
+	require.NoError(t, err)
+
+	address, branch := forkBranch(t, parent)
+
+	// the branch rewrites the middle and bolds the title
+	_, err = branch.Delete(26, 6)
+	require.NoError(t, err)
+	_, err = branch.Insert(26, "Friday")
+	require.NoError(t, err)
+	_, err = branch.Format(0, 4, v3.FormatV3Span{"b": "true"})
+	require.NoError(t, err)
+
+	// meanwhile the parent edits the last line
+	_, err = parent.Insert(parent.VisSize-1, " for real")
+	require.NoError(t, err)
+
+	bts, err := json.Marshal(parent)
+	require.NoError(t, err)
+	other := v3.NewRogue("3")
+	require.NoError(t, json.Unmarshal(bts, other))
+
+	mop, err := parent.MergeBranch("2", address, branch)
+	require.NoError(t, err)
+	require.NotEmpty(t, mop.Mops)
+
+	require.Equal(t, "Plan\nWe should ship it on Friday.\nThe end for real\n", parent.GetText())
+
+	md, err := parent.GetFullMarkdown()
+	require.NoError(t, err)
+	require.Equal(t, "# **Plan**\n\nWe should ship it on Friday.\n\nThe end for real\n\n", md)
+
+	// merging the op into another copy ends up the same
+	_, err = other.MergeOp(mop)
+	require.NoError(t, err)
+
+	otherMd, err := other.GetFullMarkdown()
+	require.NoError(t, err)
+	require.Equal(t, md, otherMd)
+}
+
+func TestMergeBranchConcurrentDelete(t *testing.T) {
+	t.Parallel()
+
+	parent := v3.NewRogueForQuill("1")
+	_, err := parent.Insert(0, "one two three")
+	require.NoError(t, err)
+
+	address, branch := forkBranch(t, parent)
+
+	_, err = branch.Delete(4, 4)
+	require.NoError(t, err)
+	_, err = branch.Insert(0, "zero ")
+	require.NoError(t, err)
+
+	// both sides delete the same word, the parent also deletes "one "
+	_, err = parent.Delete(0, 8)
+	require.NoError(t, err)
+
+	_, err = parent.MergeBranch("2", address, branch)
+	require.NoError(t, err)
+	require.Equal(t, "zero three\n", parent.GetText())
+
+	// there's nothing left to merge
+	branch2, err := parent.Compact(nil)
+	require.NoError(t, err)
+	address, err = parent.GetFullAddress()
+	require.NoError(t, err)
+
+	mop, err := parent.MergeBranch("2", address, branch2)
+	require.NoError(t, err)
+	require.Empty(t, mop.Mops)
+	require.Equal(t, "zero three\n", parent.GetText())
+}
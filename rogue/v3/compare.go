@@ -0,0 +1,269 @@
+package v3
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+type ChangeKind string
+
+const (
+	ChangeInsert ChangeKind = "insert"
+	ChangeDelete ChangeKind = "delete"
+	ChangeFormat ChangeKind = "format"
+)
+
+// Change is a run of text that was inserted, deleted or reformatted between
+// two versions of the doc. Deleted runs have the ids they had in the from
+// version, everything else has the ids they have in the to version.
+type Change struct {
+	Kind    ChangeKind `json:"kind"`
+	StartID ID         `json:"startID"`
+	EndID   ID         `json:"endID"`
+	Authors []string   `json:"authors"`
+	Text    string     `json:"text"`
+	Words   int        `json:"words"`
+}
+
+// Compare lists the changes that take the doc from the from address to the
+// to address, either address can be nil for the current version
+func (r *Rogue) Compare(from, to *ContentAddress) ([]Change, error) {
+	fromText, err := r.branchText(from)
+	if err != nil {
+		return nil, fmt.Errorf("branchText(%v): %w", from, err)
+	}
+
+	toText, err := r.branchText(to)
+	if err != nil {
+		return nil, fmt.Errorf("branchText(%v): %w", to, err)
+	}
+
+	formatOps, err := r.formatOpsBetween(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("formatOpsBetween(%v, %v): %w", from, to, err)
+	}
+
+	changes := []Change{}
+	var cur *Change
+	var curText []uint16
+
+	flush := func() {
+		if cur == nil {
+			return
+		}
+
+		cur.Text = Uint16ToStr(curText)
+		cur.Words = len(strings.Fields(cur.Text))
+		changes = append(changes, *cur)
+		cur, curText = nil, nil
+	}
+
+	add := func(kind ChangeKind, id ID, char uint16, authors ...string) {
+		if cur == nil || cur.Kind != kind {
+			flush()
+			cur = &Change{Kind: kind, StartID: id, Authors: []string{}}
+		}
+
+		cur.EndID = id
+		curText = append(curText, char)
+		for _, author := range authors {
+			if author != "" && !slices.Contains(cur.Authors, author) {
+				cur.Authors = append(cur.Authors, author)
+			}
+		}
+	}
+
+	fromIx, toIx := 0, 0
+	diffs := DiffWords(Uint16ToStr(fromText.text), Uint16ToStr(toText.text))
+	for _, d := range diffs {
+		n := UTF16Length(d.Text)
+
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			for k := 0; k < n; k++ {
+				if sameFormat(fromText, toText, fromIx, toIx) {
+					flush()
+				} else {
+					authors := formatAuthors(formatOps, toText.totIxs[toIx])
+					add(ChangeFormat, toText.ids[toIx], toText.text[toIx], authors...)
+				}
+				fromIx++
+				toIx++
+			}
+		case diffmatchpatch.DiffDelete:
+			// a replacement is a delete followed by an insert, keep them apart
+			flush()
+			for k := 0; k < n; k++ {
+				id := fromText.ids[fromIx]
+				author, err := r.deletedBy(id, from, to)
+				if err != nil {
+					return nil, err
+				}
+
+				add(ChangeDelete, id, fromText.text[fromIx], author)
+				fromIx++
+			}
+		case diffmatchpatch.DiffInsert:
+			flush()
+			for k := 0; k < n; k++ {
+				id := toText.ids[toIx]
+				last, err := r.lastRestore(id, to)
+				if err != nil {
+					return nil, err
+				}
+
+				add(ChangeInsert, id, toText.text[toIx], last.Author)
+				toIx++
+			}
+		}
+	}
+	flush()
+
+	return changes, nil
+}
+
+func sameFormat(fromText, toText *branchText, fromIx, toIx int) bool {
+	if !fromText.spans[fromIx].Equals(toText.spans[toIx]) {
+		return false
+	}
+
+	if toText.text[toIx] == '\n' {
+		return fromText.lines[fromIx].Equals(toText.lines[toIx])
+	}
+
+	return true
+}
+
+// opBetween reports whether id is in exactly one of the two addresses, nil
+// addresses contain everything
+func opBetween(id ID, from, to *ContentAddress) bool {
+	inFrom := from == nil || from.Contains(id)
+	inTo := to == nil || to.Contains(id)
+	return inFrom != inTo
+}
+
+type formatOpSpan struct {
+	startIx, endIx int
+	author         string
+}
+
+// formatOpsBetween finds the tot index range of the format ops made between
+// the two addresses
+func (r *Rogue) formatOpsBetween(from, to *ContentAddress) ([]formatOpSpan, error) {
+	spans := []formatOpSpan{}
+
+	visit := func(op Op) error {
+		fop, ok := op.(FormatOp)
+		if !ok || !opBetween(fop.ID, from, to) {
+			return nil
+		}
+
+		_, startIx, err := r.Rope.GetIndex(fop.StartID)
+		if err != nil {
+			return err
+		}
+
+		_, endIx, err := r.Rope.GetIndex(fop.EndID)
+		if err != nil {
+			return err
+		}
+
+		spans = append(spans, formatOpSpan{startIx, endIx, fop.ID.Author})
+		return nil
+	}
+
+	for _, tree := range r.OpIndex.AuthorOps {
+		err := tree.Dft(func(op Op) error {
+			if mop, ok := op.(MultiOp); ok {
+				for _, o := range mop.Mops {
+					if err := visit(o); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+
+			return visit(op)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return spans, nil
+}
+
+func formatAuthors(spans []formatOpSpan, totIx int) []string {
+	authors := []string{}
+	for _, span := range spans {
+		if span.startIx <= totIx && totIx <= span.endIx {
+			authors = append(authors, span.author)
+		}
+	}
+
+	return authors
+}
+
+// deletedBy returns the author of the latest delete of id made between the
+// two addresses, or "" if there isn't one
+func (r *Rogue) deletedBy(id ID, from, to *ContentAddress) (string, error) {
+	ch := r.CharHistory[id]
+	if ch == nil {
+		return "", nil
+	}
+
+	author := ""
+	err := ch.ReverseDft(func(m *Marker) error {
+		if m.IsDel && opBetween(m.ID, from, to) {
+			author = m.ID.Author
+			return ErrorStopIteration{}
+		}
+
+		return nil
+	})
+	if err != nil && !errors.As(err, &ErrorStopIteration{}) {
+		return "", err
+	}
+
+	return author, nil
+}
+
+// GetMarkdownDiff renders the doc as markdown at both addresses and returns
+// a unified diff of the two, either address can be nil for the current version
+func (r *Rogue) GetMarkdownDiff(from, to *ContentAddress, fromName, toName string) (string, error) {
+	fromMd, err := r.markdownAt(from)
+	if err != nil {
+		return "", fmt.Errorf("markdownAt(%v): %w", from, err)
+	}
+
+	toMd, err := r.markdownAt(to)
+	if err != nil {
+		return "", fmt.Errorf("markdownAt(%v): %w", to, err)
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(fromMd),
+		B:        difflib.SplitLines(toMd),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  3,
+	})
+}
+
+func (r *Rogue) markdownAt(address *ContentAddress) (string, error) {
+	firstID, lastID, err := r.GetWrappingTotIDs()
+	if err != nil {
+		return "", err
+	}
+
+	if address == nil {
+		return r.GetMarkdown(firstID, lastID)
+	}
+
+	return r.GetMarkdownAt(firstID, lastID, *address)
+}
@@ -0,0 +1,80 @@
+package v3_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	r := v3.NewRogueForQuill("1")
+	_, err := r.Insert(0, "The quick brown fox")
+	require.NoError(t, err)
+
+	from, err := r.GetFullAddress()
+	require.NoError(t, err)
+
+	r.Author = "2"
+	_, err = r.Delete(4, 6)
+	require.NoError(t, err)
+	_, err = r.Insert(13, " jumps over the dog")
+	require.NoError(t, err)
+
+	r.Author = "3"
+	_, err = r.Format(4, 5, v3.FormatV3Span{"b": "true"})
+	require.NoError(t, err)
+
+	changes, err := r.Compare(from, nil)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+
+	require.Equal(t, v3.ChangeDelete, changes[0].Kind)
+	require.Equal(t, "quick ", changes[0].Text)
+	require.Equal(t, []string{"2"}, changes[0].Authors)
+	require.Equal(t, 1, changes[0].Words)
+
+	require.Equal(t, v3.ChangeFormat, changes[1].Kind)
+	require.Equal(t, "brown", changes[1].Text)
+	require.Equal(t, []string{"3"}, changes[1].Authors)
+
+	require.Equal(t, v3.ChangeInsert, changes[2].Kind)
+	require.Equal(t, " jumps over the dog", changes[2].Text)
+	require.Equal(t, []string{"2"}, changes[2].Authors)
+	require.Equal(t, 4, changes[2].Words)
+
+	// comparing the other way around swaps inserts and deletes
+	changes, err = r.Compare(nil, from)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	require.Equal(t, v3.ChangeInsert, changes[0].Kind)
+	require.Equal(t, "quick ", changes[0].Text)
+	require.Equal(t, v3.ChangeDelete, changes[2].Kind)
+	// the text was never deleted, it just didn't exist yet
+	require.Empty(t, changes[2].Authors)
+
+	changes, err = r.Compare(from, from)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
+func TestGetMarkdownDiff(t *testing.T) {
+	t.Parallel()
+
+	r := v3.NewRogueForQuill("1")
+	_, _, err := r.InsertMarkdown(0, "# Title\n\nOne\n\nTwo")
+	require.NoError(t, err)
+
+	from, err := r.GetFullAddress()
+	require.NoError(t, err)
+
+	_, err = r.Insert(13, "\nThree")
+	require.NoError(t, err)
+
+	diff, err := r.GetMarkdownDiff(from, nil, "before", "after")
+	require.NoError(t, err)
+	require.Equal(t, "--- before\n+++ after\n@@ -4,4 +4,6 @@\n \n Two\n \n+Three\n \n+\n", diff)
+}
@@ -0,0 +1,601 @@
+package v3
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"strconv"
+	"strings"
+)
+
+// ImageLoader returns the contents of the image at src so it can be embedded
+// in an exported file. Nil data leaves the image as a link to src.
+type ImageLoader func(src string) ([]byte, error)
+
+// GetDocx renders the span between startID and endID as a WordprocessingML
+// (.docx) file. If address is non-nil the doc is rendered as of that address.
+// Images are embedded with loadImage, if loadImage is nil they're linked.
+func (r *Rogue) GetDocx(startID, endID ID, address *ContentAddress, loadImage ImageLoader) ([]byte, error) {
+	vis, spanNOS, lineNOS, err := r.ToIndexNos(startID, endID, address, true)
+	if err != nil {
+		return nil, fmt.Errorf("r.ToIndexNos(%v, %v): %w", startID, endID, err)
+	}
+
+	fVis := &FugueVis{}
+	if vis != nil {
+		fVis.Text = vis.Text
+		fVis.IDs = vis.IDs
+	} else {
+		spanNOS, lineNOS = NewNOS(), NewNOS()
+	}
+
+	return ToDocx(fVis, spanNOS, lineNOS, loadImage)
+}
+
+func (r *Rogue) GetFullDocx(address *ContentAddress, loadImage ImageLoader) ([]byte, error) {
+	firstID, err := r.GetFirstID()
+	if err != nil {
+		return nil, fmt.Errorf("r.GetFirstID(): %w", err)
+	}
+
+	lastID, err := r.GetLastID()
+	if err != nil {
+		return nil, fmt.Errorf("r.GetLastID(): %w", err)
+	}
+
+	return r.GetDocx(firstID, lastID, address, loadImage)
+}
+
+const (
+	docxBulletNumID   = 1
+	docxOrderedAbsID  = 2
+	docxIndentTwips   = 720
+	docxEmuPerPx      = 9525
+	docxDefaultWidth  = 5486400 // 6in
+	docxDefaultHeight = 3657600 // 4in
+)
+
+type docxRel struct {
+	ID         string
+	Type       string
+	Target     string
+	TargetMode string
+}
+
+// docxMedia is an image embedded under word/media
+type docxMedia struct {
+	Name string
+	Data []byte
+}
+
+// docxImageTypes are the content types of the image formats word can show,
+// keyed by the format name image.DecodeConfig returns
+var docxImageTypes = map[string]string{
+	"png":  "image/png",
+	"jpeg": "image/jpeg",
+	"gif":  "image/gif",
+}
+
+type docxWriter struct {
+	body       strings.Builder
+	rels       []docxRel
+	media      []docxMedia
+	imageRels  map[string]docxImage // by src, so an image used twice is embedded once
+	loadImage  ImageLoader
+	orderedNum []int // numIds of the ordered list instances, each restarts at 1
+	drawingID  int
+}
+
+type docxImage struct {
+	RelID    string
+	Embedded bool
+	Width    int // natural size in px of an embedded image
+	Height   int
+}
+
+func (w *docxWriter) addRel(relType, target string, external bool) string {
+	rel := docxRel{
+		ID:     fmt.Sprintf("rId%d", len(w.rels)+10), // leave room for the fixed parts
+		Type:   relType,
+		Target: target,
+	}
+	if external {
+		rel.TargetMode = "External"
+	}
+
+	w.rels = append(w.rels, rel)
+	return rel.ID
+}
+
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+func ToDocx(vis *FugueVis, spanNOS, lineNOS *NOS, loadImage ImageLoader) ([]byte, error) {
+	w := &docxWriter{
+		imageRels: map[string]docxImage{},
+		loadImage: loadImage,
+	}
+
+	lines := lineNOS.tree.AsSlice()
+
+	prevIx := 0
+	var prevFmt FormatV3
+	orderedNumID := 0
+	for _, line := range lines {
+		var curFmt FormatV3 = line.Format
+
+		// a new ordered list starts numbering from 1 again, a list nested
+		// in it keeps it going
+		cur := getListMeta(curFmt)
+		prev := getListMeta(prevFmt)
+		if !cur.isList {
+			orderedNumID = 0
+		} else if cur.isOrdered && (orderedNumID == 0 || (prev.isBullet && prev.indent == cur.indent)) {
+			orderedNumID = w.newOrderedList()
+		}
+		prevFmt = curFmt
+
+		err := w.writeLine(line, curFmt, orderedNumID, vis, spanNOS)
+		if err != nil {
+			return nil, err
+		}
+
+		prevIx = line.EndIx + 1
+	}
+
+	// write any remaining content without a trailing newline
+	if prevIx < len(vis.Text) {
+		w.body.WriteString("<w:p>")
+		w.writeRun(Uint16ToStr(vis.Text[prevIx:]), nil)
+		w.body.WriteString("</w:p>")
+	}
+
+	return w.pack()
+}
+
+func (w *docxWriter) newOrderedList() int {
+	numID := docxBulletNumID + len(w.orderedNum) + 1
+	w.orderedNum = append(w.orderedNum, numID)
+	return numID
+}
+
+func (w *docxWriter) writeLine(line *NOSNode, format FormatV3, orderedNumID int, vis *FugueVis, spanNOS *NOS) error {
+	eix := min(line.EndIx, len(vis.Text))
+
+	switch f := format.(type) {
+	case FormatV3Rule:
+		w.body.WriteString(`<w:p><w:pPr><w:pBdr><w:bottom w:val="single" w:sz="6" w:space="1" w:color="auto"/></w:pBdr></w:pPr></w:p>`)
+		return nil
+	case FormatV3CodeBlock:
+		// code blocks are raw, the formatting spans aren't applied
+		w.body.WriteString(`<w:p><w:pPr><w:pStyle w:val="Code"/></w:pPr>`)
+		w.writeRun(Uint16ToStr(vis.Text[line.StartIx:eix]), nil)
+		w.body.WriteString("</w:p>")
+		return nil
+	case FormatV3Image:
+		if f.Src != "" {
+			err := w.writeImage(f)
+			if err != nil {
+				return err
+			}
+		}
+
+		// the line's text is the image's caption
+		if line.StartIx == eix {
+			return nil
+		}
+
+		w.body.WriteString(`<w:p><w:pPr><w:pStyle w:val="Caption"/></w:pPr>`)
+	default:
+		w.body.WriteString("<w:p>")
+		w.writeParagraphProps(format, orderedNumID)
+	}
+
+	err := w.writeSpans(line, vis, spanNOS)
+	if err != nil {
+		return err
+	}
+
+	w.body.WriteString("</w:p>")
+	return nil
+}
+
+func (w *docxWriter) writeParagraphProps(format FormatV3, orderedNumID int) {
+	switch f := format.(type) {
+	case FormatV3Header:
+		w.body.WriteString(fmt.Sprintf(`<w:pPr><w:pStyle w:val="Heading%d"/></w:pPr>`, min(max(int(f), 1), 6)))
+	case FormatV3BulletList:
+		w.body.WriteString(fmt.Sprintf(`<w:pPr><w:pStyle w:val="ListParagraph"/><w:numPr><w:ilvl w:val="%d"/><w:numId w:val="%d"/></w:numPr></w:pPr>`, min(int(f), 8), docxBulletNumID))
+	case FormatV3OrderedList:
+		w.body.WriteString(fmt.Sprintf(`<w:pPr><w:pStyle w:val="ListParagraph"/><w:numPr><w:ilvl w:val="%d"/><w:numId w:val="%d"/></w:numPr></w:pPr>`, min(int(f), 8), orderedNumID))
+	case FormatV3IndentedLine:
+		w.body.WriteString(fmt.Sprintf(`<w:pPr><w:ind w:left="%d"/></w:pPr>`, (int(f)+1)*docxIndentTwips))
+	case FormatV3BlockQuote:
+		w.body.WriteString(`<w:pPr><w:pStyle w:val="Quote"/></w:pPr>`)
+	}
+}
+
+// writeSpans writes the runs of a line, the same way _htmlWriteLine walks the span NOS
+func (w *docxWriter) writeSpans(line *NOSNode, vis *FugueVis, spanNOS *NOS) error {
+	prevIx := line.StartIx
+	err := spanNOS.between(line.StartIx, line.EndIx-1, func(n *NOSNode) error {
+		w.writeRun(Uint16ToStr(vis.Text[prevIx:n.StartIx]), nil)
+		w.writeRun(Uint16ToStr(vis.Text[n.StartIx:n.EndIx+1]), n.Format)
+		prevIx = n.EndIx + 1
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("between(%v, %v): %w", line.StartIx, line.EndIx, err)
+	}
+
+	// write any remaining unformated text before end of line
+	if prevIx < line.EndIx {
+		w.writeRun(Uint16ToStr(vis.Text[prevIx:min(line.EndIx, len(vis.Text))]), nil)
+	}
+
+	return nil
+}
+
+func (w *docxWriter) writeRun(text string, format FormatV3) {
+	if len(text) == 0 {
+		return
+	}
+
+	var bold, italic, strike, underline, code bool
+	link := ""
+
+	if span, ok := format.(FormatV3Span); ok {
+		f := span.DropNull().(FormatV3Span)
+		for k, v := range f {
+			switch k {
+			case "b", "bold":
+				bold = true
+			case "i", "italic":
+				italic = true
+			case "u", "underline":
+				underline = true
+			case "s", "strike", "del":
+				strike = true
+			case "c":
+				code = true
+			case "a", "link":
+				link = v
+			case "ql":
+				if text == "'" {
+					text = "‘"
+				} else if text == "\"" {
+					text = "“"
+				}
+			case "qr":
+				if text == "'" {
+					text = "’"
+				} else if text == "\"" {
+					text = "”"
+				}
+			}
+		}
+	}
+
+	// run properties have to be in schema order
+	rPr := strings.Builder{}
+	if link != "" {
+		rPr.WriteString(`<w:rStyle w:val="Hyperlink"/>`)
+	} else if code {
+		rPr.WriteString(`<w:rStyle w:val="CodeChar"/>`)
+	}
+	if link != "" && code {
+		rPr.WriteString(`<w:rFonts w:ascii="Courier New" w:hAnsi="Courier New" w:cs="Courier New"/>`)
+	}
+	if bold {
+		rPr.WriteString("<w:b/>")
+	}
+	if italic {
+		rPr.WriteString("<w:i/>")
+	}
+	if strike {
+		rPr.WriteString("<w:strike/>")
+	}
+	if underline {
+		rPr.WriteString(`<w:u w:val="single"/>`)
+	}
+
+	if link != "" {
+		rID := w.addRel("http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink", link, true)
+		w.body.WriteString(fmt.Sprintf(`<w:hyperlink r:id="%s">`, rID))
+	}
+
+	w.body.WriteString("<w:r>")
+	if rPr.Len() > 0 {
+		w.body.WriteString("<w:rPr>" + rPr.String() + "</w:rPr>")
+	}
+
+	for i, part := range strings.Split(text, "\t") {
+		if i > 0 {
+			w.body.WriteString("<w:tab/>")
+		}
+
+		if part != "" {
+			w.body.WriteString(`<w:t xml:space="preserve">` + xmlEscape(part) + "</w:t>")
+		}
+	}
+
+	w.body.WriteString("</w:r>")
+	if link != "" {
+		w.body.WriteString("</w:hyperlink>")
+	}
+}
+
+// docxLength converts a css pixel length to EMUs, anything else (e.g. a
+// percentage) isn't supported and returns 0
+func docxLength(s string) int {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(s, "px")
+
+	px, err := strconv.ParseFloat(s, 64)
+	if err != nil || px <= 0 {
+		return 0
+	}
+
+	return int(px * docxEmuPerPx)
+}
+
+// addImage embeds the image at src under word/media and returns its
+// relationship, images that can't be loaded or that word can't show are
+// linked instead and fetched when the document is opened
+func (w *docxWriter) addImage(src string) (docxImage, error) {
+	if img, ok := w.imageRels[src]; ok {
+		return img, nil
+	}
+
+	var data []byte
+	if w.loadImage != nil {
+		var err error
+		data, err = w.loadImage(src)
+		if err != nil {
+			return docxImage{}, fmt.Errorf("error loading image %s: %w", src, err)
+		}
+	}
+
+	var img docxImage
+	if data != nil {
+		config, format, err := image.DecodeConfig(bytes.NewReader(data))
+		if _, ok := docxImageTypes[format]; err == nil && ok {
+			name := fmt.Sprintf("image%d.%s", len(w.media)+1, format)
+			w.media = append(w.media, docxMedia{Name: name, Data: data})
+
+			img = docxImage{
+				RelID:    w.addRel("http://schemas.openxmlformats.org/officeDocument/2006/relationships/image", "media/"+name, false),
+				Embedded: true,
+				Width:    config.Width,
+				Height:   config.Height,
+			}
+		}
+	}
+	if !img.Embedded {
+		img.RelID = w.addRel("http://schemas.openxmlformats.org/officeDocument/2006/relationships/image", src, true)
+	}
+
+	w.imageRels[src] = img
+	return img, nil
+}
+
+// writeImage sizes the image from the format, falling back to its natural
+// size for embedded images
+func (w *docxWriter) writeImage(f FormatV3Image) error {
+	img, err := w.addImage(f.Src)
+	if err != nil {
+		return err
+	}
+
+	blip := fmt.Sprintf(`r:link="%s"`, img.RelID)
+	if img.Embedded {
+		blip = fmt.Sprintf(`r:embed="%s"`, img.RelID)
+	}
+
+	// a missing side keeps the image's own aspect ratio if it's known
+	ratioW, ratioH := docxDefaultWidth, docxDefaultHeight
+	if img.Width > 0 && img.Height > 0 {
+		ratioW, ratioH = img.Width, img.Height
+	}
+
+	cx, cy := docxLength(f.Width), docxLength(f.Height)
+	if cx == 0 && cy == 0 && img.Width > 0 && img.Height > 0 {
+		cx, cy = img.Width*docxEmuPerPx, img.Height*docxEmuPerPx
+		if cx > docxDefaultWidth {
+			// scale it down to fit the page
+			cx, cy = docxDefaultWidth, cy*docxDefaultWidth/cx
+		}
+	} else if cx == 0 && cy == 0 {
+		cx, cy = docxDefaultWidth, docxDefaultHeight
+	} else if cx == 0 {
+		cx = cy * ratioW / ratioH
+	} else if cy == 0 {
+		cy = cx * ratioH / ratioW
+	}
+
+	w.drawingID++
+	alt := xmlEscape(f.Alt)
+
+	w.body.WriteString(fmt.Sprintf(`<w:p><w:r><w:drawing><wp:inline distT="0" distB="0" distL="0" distR="0">`+
+		`<wp:extent cx="%d" cy="%d"/><wp:docPr id="%d" name="Picture %d" descr="%s"/>`+
+		`<a:graphic xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">`+
+		`<a:graphicData uri="http://schemas.openxmlformats.org/drawingml/2006/picture">`+
+		`<pic:pic xmlns:pic="http://schemas.openxmlformats.org/drawingml/2006/picture">`+
+		`<pic:nvPicPr><pic:cNvPr id="%d" name="Picture %d" descr="%s"/><pic:cNvPicPr/></pic:nvPicPr>`+
+		`<pic:blipFill><a:blip %s/><a:stretch><a:fillRect/></a:stretch></pic:blipFill>`+
+		`<pic:spPr><a:xfrm><a:off x="0" y="0"/><a:ext cx="%d" cy="%d"/></a:xfrm><a:prstGeom prst="rect"><a:avLst/></a:prstGeom></pic:spPr>`+
+		`</pic:pic></a:graphicData></a:graphic></wp:inline></w:drawing></w:r></w:p>`,
+		cx, cy, w.drawingID, w.drawingID, alt, w.drawingID, w.drawingID, alt, blip, cx, cy))
+
+	return nil
+}
+
+func (w *docxWriter) pack() ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	files := []struct {
+		name    string
+		content []byte
+	}{
+		{"[Content_Types].xml", []byte(w.contentTypes())},
+		{"_rels/.rels", []byte(docxRootRels)},
+		{"word/document.xml", []byte(docxDocumentStart + w.body.String() + docxDocumentEnd)},
+		{"word/styles.xml", []byte(docxStyles)},
+		{"word/numbering.xml", []byte(w.numbering())},
+		{"word/_rels/document.xml.rels", []byte(w.documentRels())},
+	}
+	for _, m := range w.media {
+		files = append(files, struct {
+			name    string
+			content []byte
+		}{"word/media/" + m.Name, m.Data})
+	}
+
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return nil, fmt.Errorf("zw.Create(%q): %w", f.name, err)
+		}
+
+		_, err = fw.Write(f.content)
+		if err != nil {
+			return nil, fmt.Errorf("writing %q: %w", f.name, err)
+		}
+	}
+
+	err := zw.Close()
+	if err != nil {
+		return nil, fmt.Errorf("zw.Close(): %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (w *docxWriter) documentRels() string {
+	b := strings.Builder{}
+	b.WriteString(xml.Header)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	b.WriteString(`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`)
+	b.WriteString(`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/numbering" Target="numbering.xml"/>`)
+
+	for _, rel := range w.rels {
+		mode := ""
+		if rel.TargetMode != "" {
+			mode = fmt.Sprintf(` TargetMode="%s"`, rel.TargetMode)
+		}
+
+		b.WriteString(fmt.Sprintf(`<Relationship Id="%s" Type="%s" Target="%s"%s/>`, rel.ID, rel.Type, xmlEscape(rel.Target), mode))
+	}
+
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
+
+func docxAbstractNum(id int, ordered bool) string {
+	b := strings.Builder{}
+	b.WriteString(fmt.Sprintf(`<w:abstractNum w:abstractNumId="%d"><w:multiLevelType w:val="hybridMultilevel"/>`, id))
+
+	bullets := []string{"•", "◦", "▪"}
+	orderedFmts := []string{"decimal", "lowerLetter", "lowerRoman"}
+	for lvl := 0; lvl < 9; lvl++ {
+		numFmt, text := "bullet", bullets[lvl%len(bullets)]
+		if ordered {
+			numFmt, text = orderedFmts[lvl%len(orderedFmts)], fmt.Sprintf("%%%d.", lvl+1)
+		}
+
+		b.WriteString(fmt.Sprintf(`<w:lvl w:ilvl="%d"><w:start w:val="1"/><w:numFmt w:val="%s"/><w:lvlText w:val="%s"/><w:lvlJc w:val="left"/><w:pPr><w:ind w:left="%d" w:hanging="360"/></w:pPr></w:lvl>`,
+			lvl, numFmt, text, (lvl+1)*docxIndentTwips))
+	}
+
+	b.WriteString(`</w:abstractNum>`)
+	return b.String()
+}
+
+func (w *docxWriter) numbering() string {
+	b := strings.Builder{}
+	b.WriteString(xml.Header)
+	b.WriteString(`<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">`)
+	b.WriteString(docxAbstractNum(docxBulletNumID, false))
+	b.WriteString(docxAbstractNum(docxOrderedAbsID, true))
+
+	b.WriteString(fmt.Sprintf(`<w:num w:numId="%d"><w:abstractNumId w:val="%d"/></w:num>`, docxBulletNumID, docxBulletNumID))
+	for _, numID := range w.orderedNum {
+		b.WriteString(fmt.Sprintf(`<w:num w:numId="%d"><w:abstractNumId w:val="%d"/>`, numID, docxOrderedAbsID))
+		for lvl := 0; lvl < 9; lvl++ {
+			b.WriteString(fmt.Sprintf(`<w:lvlOverride w:ilvl="%d"><w:startOverride w:val="1"/></w:lvlOverride>`, lvl))
+		}
+		b.WriteString(`</w:num>`)
+	}
+
+	b.WriteString(`</w:numbering>`)
+	return b.String()
+}
+
+// contentTypes adds a default for each kind of embedded image
+func (w *docxWriter) contentTypes() string {
+	b := strings.Builder{}
+	b.WriteString(xml.Header)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+
+	seen := map[string]bool{}
+	for _, m := range w.media {
+		ext := m.Name[strings.LastIndex(m.Name, ".")+1:]
+		if seen[ext] {
+			continue
+		}
+		seen[ext] = true
+
+		b.WriteString(fmt.Sprintf(`<Default Extension="%s" ContentType="%s"/>`, ext, docxImageTypes[ext]))
+	}
+
+	b.WriteString(`<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>`)
+	b.WriteString(`<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>`)
+	b.WriteString(`<Override PartName="/word/numbering.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.numbering+xml"/>`)
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+const docxRootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>` +
+	`</Relationships>`
+
+const docxDocumentStart = xml.Header + `<w:document ` +
+	`xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+	`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" ` +
+	`xmlns:wp="http://schemas.openxmlformats.org/drawingml/2006/wordprocessingDrawing"><w:body>`
+
+const docxDocumentEnd = `<w:sectPr><w:pgSz w:w="12240" w:h="15840"/><w:pgMar w:top="1440" w:right="1440" w:bottom="1440" w:left="1440" w:header="720" w:footer="720" w:gutter="0"/></w:sectPr></w:body></w:document>`
+
+func docxHeadingStyle(level, size int) string {
+	return fmt.Sprintf(`<w:style w:type="paragraph" w:styleId="Heading%d"><w:name w:val="heading %d"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/>`+
+		`<w:pPr><w:keepNext/><w:spacing w:before="240" w:after="120"/><w:outlineLvl w:val="%d"/></w:pPr><w:rPr><w:b/><w:sz w:val="%d"/></w:rPr></w:style>`,
+		level, level, level-1, size)
+}
+
+var docxStyles = xml.Header + `<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+	`<w:docDefaults><w:rPrDefault><w:rPr><w:rFonts w:ascii="Calibri" w:hAnsi="Calibri" w:eastAsia="Calibri" w:cs="Calibri"/><w:sz w:val="22"/></w:rPr></w:rPrDefault>` +
+	`<w:pPrDefault><w:pPr><w:spacing w:after="160" w:line="259" w:lineRule="auto"/></w:pPr></w:pPrDefault></w:docDefaults>` +
+	`<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/><w:qFormat/></w:style>` +
+	docxHeadingStyle(1, 40) + docxHeadingStyle(2, 32) + docxHeadingStyle(3, 28) +
+	docxHeadingStyle(4, 24) + docxHeadingStyle(5, 22) + docxHeadingStyle(6, 22) +
+	`<w:style w:type="paragraph" w:styleId="ListParagraph"><w:name w:val="List Paragraph"/><w:basedOn w:val="Normal"/><w:pPr><w:contextualSpacing/></w:pPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Quote"><w:name w:val="Quote"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/>` +
+	`<w:pPr><w:pBdr><w:left w:val="single" w:sz="18" w:space="8" w:color="BFBFBF"/></w:pBdr><w:ind w:left="720"/></w:pPr><w:rPr><w:i/><w:color w:val="595959"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Code"><w:name w:val="Code"/><w:basedOn w:val="Normal"/>` +
+	`<w:pPr><w:shd w:val="clear" w:color="auto" w:fill="F2F2F2"/><w:spacing w:after="0" w:line="240" w:lineRule="auto"/></w:pPr>` +
+	`<w:rPr><w:rFonts w:ascii="Courier New" w:hAnsi="Courier New" w:cs="Courier New"/><w:sz w:val="20"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Caption"><w:name w:val="caption"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:rPr><w:i/><w:sz w:val="18"/></w:rPr></w:style>` +
+	`<w:style w:type="character" w:styleId="Hyperlink"><w:name w:val="Hyperlink"/><w:rPr><w:color w:val="0563C1"/><w:u w:val="single"/></w:rPr></w:style>` +
+	`<w:style w:type="character" w:styleId="CodeChar"><w:name w:val="Code Char"/><w:rPr><w:rFonts w:ascii="Courier New" w:hAnsi="Courier New" w:cs="Courier New"/></w:rPr></w:style>` +
+	`</w:styles>`
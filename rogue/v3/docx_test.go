@@ -0,0 +1,178 @@
+package v3_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func readDocx(t *testing.T, docx []byte) map[string]string {
+	zr, err := zip.NewReader(bytes.NewReader(docx), int64(len(docx)))
+	require.NoError(t, err)
+
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		b, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+
+		parts[f.Name] = string(b)
+		if strings.HasPrefix(f.Name, "word/media/") {
+			continue
+		}
+
+		// every other part should be well formed xml
+		dec := xml.NewDecoder(bytes.NewReader(b))
+		for {
+			_, err := dec.Token()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err, f.Name)
+		}
+	}
+
+	return parts
+}
+
+func TestGetDocx(t *testing.T) {
+	r := v3.NewRogueForQuill("0")
+	_, err := r.Insert(0, "Title\nfirst\nnested\nsecond\nbullet\nquote\nfmt.Println(\"<hi>\")\ncaption\nbold link\n")
+	require.NoError(t, err)
+
+	_, err = r.Format(5, 1, v3.FormatV3Header(1))
+	require.NoError(t, err)
+	_, err = r.Format(11, 1, v3.FormatV3OrderedList(0))
+	require.NoError(t, err)
+	_, err = r.Format(18, 1, v3.FormatV3OrderedList(1))
+	require.NoError(t, err)
+	_, err = r.Format(25, 1, v3.FormatV3OrderedList(0))
+	require.NoError(t, err)
+	_, err = r.Format(32, 1, v3.FormatV3BulletList(0))
+	require.NoError(t, err)
+	_, err = r.Format(38, 1, v3.FormatV3BlockQuote{})
+	require.NoError(t, err)
+	_, err = r.Format(58, 1, v3.FormatV3CodeBlock("go"))
+	require.NoError(t, err)
+	_, err = r.Format(66, 1, v3.FormatV3Image{Src: "https://example.com/a.png", Alt: "a & b", Width: "200px"})
+	require.NoError(t, err)
+	_, err = r.Format(67, 4, v3.FormatV3Span{"b": "true"})
+	require.NoError(t, err)
+	_, err = r.Format(72, 4, v3.FormatV3Span{"a": "https://example.com?a=1&b=2"})
+	require.NoError(t, err)
+
+	docx, err := r.GetFullDocx(nil, nil)
+	require.NoError(t, err)
+
+	parts := readDocx(t, docx)
+	require.Contains(t, parts, "[Content_Types].xml")
+	require.Contains(t, parts, "word/styles.xml")
+
+	doc := parts["word/document.xml"]
+	require.Contains(t, doc, `<w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t xml:space="preserve">Title</w:t></w:r></w:p>`)
+	require.Contains(t, doc, `<w:ilvl w:val="0"/><w:numId w:val="2"/></w:numPr></w:pPr><w:r><w:t xml:space="preserve">first</w:t>`)
+	require.Contains(t, doc, `<w:ilvl w:val="1"/><w:numId w:val="2"/></w:numPr></w:pPr><w:r><w:t xml:space="preserve">nested</w:t>`)
+	require.Contains(t, doc, `<w:ilvl w:val="0"/><w:numId w:val="2"/></w:numPr></w:pPr><w:r><w:t xml:space="preserve">second</w:t>`)
+	require.Contains(t, doc, `<w:ilvl w:val="0"/><w:numId w:val="1"/></w:numPr></w:pPr><w:r><w:t xml:space="preserve">bullet</w:t>`)
+	require.Contains(t, doc, `<w:pStyle w:val="Quote"/></w:pPr><w:r><w:t xml:space="preserve">quote</w:t>`)
+	require.Contains(t, doc, `<w:pStyle w:val="Code"/></w:pPr><w:r><w:t xml:space="preserve">fmt.Println(&#34;&lt;hi&gt;&#34;)</w:t>`)
+	require.Contains(t, doc, `<wp:extent cx="1905000" cy="1270000"/>`)
+	require.Contains(t, doc, `descr="a &amp; b"`)
+	require.Contains(t, doc, `<w:pStyle w:val="Caption"/></w:pPr><w:r><w:t xml:space="preserve">caption</w:t>`)
+	require.Contains(t, doc, `<w:r><w:rPr><w:b/></w:rPr><w:t xml:space="preserve">bold</w:t></w:r>`)
+	require.Contains(t, doc, `<w:hyperlink r:id="rId11"><w:r><w:rPr><w:rStyle w:val="Hyperlink"/></w:rPr><w:t xml:space="preserve">link</w:t></w:r></w:hyperlink>`)
+
+	rels := parts["word/_rels/document.xml.rels"]
+	require.Contains(t, rels, `Id="rId10" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="https://example.com/a.png" TargetMode="External"`)
+	require.Contains(t, rels, `Id="rId11" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" Target="https://example.com?a=1&amp;b=2" TargetMode="External"`)
+
+	require.Contains(t, parts["word/numbering.xml"], `<w:num w:numId="2"><w:abstractNumId w:val="2"/>`)
+}
+
+func TestGetDocxAtAddress(t *testing.T) {
+	r := v3.NewRogueForQuill("0")
+	_, err := r.Insert(0, "hello world")
+	require.NoError(t, err)
+
+	address, err := r.GetFullAddress()
+	require.NoError(t, err)
+
+	_, err = r.Insert(5, " cruel")
+	require.NoError(t, err)
+
+	docx, err := r.GetFullDocx(address, nil)
+	require.NoError(t, err)
+	doc := readDocx(t, docx)["word/document.xml"]
+	require.Contains(t, doc, "hello world")
+	require.NotContains(t, doc, "cruel")
+
+	docx, err = r.GetFullDocx(nil, nil)
+	require.NoError(t, err)
+	doc = readDocx(t, docx)["word/document.xml"]
+	require.Contains(t, doc, "hello cruel world")
+}
+
+func TestGetDocxEmbedsImages(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 30, 20))))
+	pngData := buf.Bytes()
+
+	r := v3.NewRogueForQuill("0")
+	_, err := r.Insert(0, "one\ntwo\nthree\n")
+	require.NoError(t, err)
+
+	_, err = r.Format(3, 1, v3.FormatV3Image{Src: "https://app.example.com/a.png"})
+	require.NoError(t, err)
+	_, err = r.Format(7, 1, v3.FormatV3Image{Src: "https://example.com/b.png"})
+	require.NoError(t, err)
+	_, err = r.Format(13, 1, v3.FormatV3Image{Src: "https://app.example.com/a.png", Width: "60px"})
+	require.NoError(t, err)
+
+	loads := 0
+	docx, err := r.GetFullDocx(nil, func(src string) ([]byte, error) {
+		loads++
+		if src == "https://app.example.com/a.png" {
+			return pngData, nil
+		}
+		return nil, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, loads)
+
+	parts := readDocx(t, docx)
+	require.Equal(t, string(pngData), parts["word/media/image1.png"])
+	require.NotContains(t, parts, "word/media/image2.png")
+	require.Contains(t, parts["[Content_Types].xml"], `<Default Extension="png" ContentType="image/png"/>`)
+
+	doc := parts["word/document.xml"]
+	require.Contains(t, doc, `<wp:extent cx="285750" cy="190500"/>`)
+	require.Contains(t, doc, `<wp:extent cx="571500" cy="381000"/>`)
+	require.Contains(t, doc, `<a:blip r:embed="rId10"/>`)
+	require.Contains(t, doc, `<a:blip r:link="rId11"/>`)
+
+	rels := parts["word/_rels/document.xml.rels"]
+	require.Contains(t, rels, `Id="rId10" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="media/image1.png"/>`)
+	require.Contains(t, rels, `Id="rId11" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="https://example.com/b.png" TargetMode="External"`)
+	require.NotContains(t, rels, "rId12")
+
+	// importing it again gets the embedded image back
+	saved := map[string][]byte{}
+	_, spans, err := v3.ParseDocx(docx, func(name string, data []byte) (string, error) {
+		saved[name] = data
+		return "https://app.example.com/imported.png", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, pngData, saved["image1.png"])
+	require.NotEmpty(t, spans)
+}
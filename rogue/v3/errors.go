@@ -95,3 +95,24 @@ type ErrorNotCodeblock struct {
 func (e ErrorNotCodeblock) Error() string {
 	return fmt.Sprintf("not a codeblock at address %q for id: %v", e.Address, e.ID)
 }
+
+type ErrorInvalidFormat struct {
+	ID  ID
+	Err error
+}
+
+func (e ErrorInvalidFormat) Error() string {
+	return fmt.Sprintf("invalid format op %v: %v", e.ID, e.Err)
+}
+
+func (e ErrorInvalidFormat) Unwrap() error {
+	return e.Err
+}
+
+type ErrorUnsupportedOp struct {
+	Op Op
+}
+
+func (e ErrorUnsupportedOp) Error() string {
+	return fmt.Sprintf("unsupported op: %T", e.Op)
+}
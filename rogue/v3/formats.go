@@ -26,7 +26,7 @@ func NewFormats(rope *Rope) *Formats {
 
 func (f FormatV3Span) SplitSticky() (sticky, noSticky FormatV3Span) {
 	sticky, noSticky = FormatV3Span{}, FormatV3Span{}
-	noStick := set.NewSet("a", "ql", "qr", "en", "del", "ins")
+	noStick := set.NewSet("a", "ql", "qr", "en", "del", "ins", SuggestInsertKey, SuggestDeleteKey)
 
 	for k, v := range f {
 		if noStick.Has(k) {
@@ -113,15 +113,15 @@ func _spanTag(k string) string {
 		return "s"
 	} else if k == "underline" || k == "u" {
 		return "u"
-	} else if k == "del" {
+	} else if k == "del" || k == SuggestDeleteKey {
 		return "del"
-	} else if k == "ins" {
+	} else if k == "ins" || k == SuggestInsertKey {
 		return "ins"
 	} else if k == "link" || k == "a" {
 		return "a"
 	} else if k == "c" {
 		return "code"
-	} else if k == "ql" || k == "qr" || k == "author" {
+	} else if k == "ql" || k == "qr" || k == "author" || k == "blame" {
 		return "span"
 	}
 
@@ -169,10 +169,29 @@ func _wrapTags(startID, endID *ID, text string, format FormatV3) string {
 			tagAttrs = fmt.Sprintf("%s data-delta-start=%q data-delta-end=%q", tagAttrs, startID, endID)
 		}
 
+		if k == SuggestInsertKey || k == SuggestDeleteKey {
+			tagAttrs = fmt.Sprintf("%s data-suggestion-author=%q", tagAttrs, f[k])
+			if startID != nil && endID != nil {
+				tagAttrs = fmt.Sprintf("%s data-suggestion-start=%q data-suggestion-end=%q", tagAttrs, startID, endID)
+			}
+		}
+
 		if (k == "author") && startID != nil && endID != nil {
 			tagAttrs = fmt.Sprintf("%s data-author-prefix=%q", tagAttrs, f[k])
 		}
 
+		if k == "blame" {
+			author, prefix := f[k], ""
+			if len(author) > 0 {
+				prefix = author[0:1]
+			}
+
+			tagAttrs = fmt.Sprintf("%s data-blame-author=%q data-author-prefix=%q", tagAttrs, author, prefix)
+			if !IsAIAuthor(author) {
+				tagAttrs = fmt.Sprintf("%s data-blame-color=\"%d\"", tagAttrs, BlameColor(author))
+			}
+		}
+
 		if k == "ql" {
 			if text == "'" {
 				text = "‘"
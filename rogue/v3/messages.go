@@ -687,6 +687,10 @@ func (m *Message) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	if len(op) == 0 {
+		return fmt.Errorf("empty message")
+	}
+
 	var msgType int
 	if err := json.Unmarshal(op[0], &msgType); err != nil {
 		return err
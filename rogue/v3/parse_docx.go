@@ -0,0 +1,444 @@
+package v3
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+type docxStyle struct {
+	name       string
+	basedOn    string
+	numID      string
+	ilvl       string
+	outlineLvl string
+}
+
+type docxTarget struct {
+	target   string
+	external bool
+}
+
+type docxParser struct {
+	b         spanBuilder
+	styles    map[string]docxStyle
+	numFmts   map[string]map[string]string // numId -> ilvl -> numFmt
+	rels      map[string]docxTarget
+	files     *zipArchive
+	saveImage ImageSaver
+}
+
+var monospaceFonts = []string{"courier", "consolas", "menlo", "monaco", "mono"}
+
+// ParseDocx converts a WordprocessingML (.docx) file into plain text and
+// format spans, the same shape ParseHtml produces, so it can be inserted with
+// InsertSpans. Embedded images are handed to saveImage, if saveImage is nil
+// only linked images are kept.
+func ParseDocx(data []byte, saveImage ImageSaver) (string, []TextSpan, error) {
+	files, err := readZipFiles(data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	doc, err := readZipXML(files, "word/document.xml")
+	if err != nil {
+		return "", nil, err
+	}
+	if doc == nil {
+		return "", nil, fmt.Errorf("not a docx file: missing word/document.xml")
+	}
+
+	p := &docxParser{
+		styles:    map[string]docxStyle{},
+		numFmts:   map[string]map[string]string{},
+		rels:      map[string]docxTarget{},
+		files:     files,
+		saveImage: saveImage,
+	}
+
+	if err := p.loadStyles(); err != nil {
+		return "", nil, err
+	}
+	if err := p.loadNumbering(); err != nil {
+		return "", nil, err
+	}
+	if err := p.loadRels(); err != nil {
+		return "", nil, err
+	}
+
+	body := doc.find("body")
+	if body == nil {
+		return "", nil, fmt.Errorf("not a docx file: missing body")
+	}
+
+	if err := p.walkBody(body); err != nil {
+		return "", nil, err
+	}
+
+	text, spans := p.b.result()
+	return text, spans, nil
+}
+
+func (p *docxParser) loadStyles() error {
+	styles, err := readZipXML(p.files, "word/styles.xml")
+	if err != nil || styles == nil {
+		return err
+	}
+
+	for _, s := range styles.find("styles").childrenNamed("style") {
+		style := docxStyle{
+			name:    strings.ToLower(s.child("name").attr("val")),
+			basedOn: s.child("basedOn").attr("val"),
+		}
+
+		pPr := s.child("pPr")
+		if numPr := pPr.child("numPr"); numPr != nil {
+			style.numID = numPr.child("numId").attr("val")
+			style.ilvl = numPr.child("ilvl").attr("val")
+		}
+		style.outlineLvl = pPr.child("outlineLvl").attr("val")
+
+		p.styles[s.attr("styleId")] = style
+	}
+
+	return nil
+}
+
+func (p *docxParser) loadNumbering() error {
+	numbering, err := readZipXML(p.files, "word/numbering.xml")
+	if err != nil || numbering == nil {
+		return err
+	}
+
+	root := numbering.find("numbering")
+	abstract := map[string]map[string]string{}
+	for _, a := range root.childrenNamed("abstractNum") {
+		lvls := map[string]string{}
+		for _, lvl := range a.childrenNamed("lvl") {
+			lvls[lvl.attr("ilvl")] = lvl.child("numFmt").attr("val")
+		}
+		abstract[a.attr("abstractNumId")] = lvls
+	}
+
+	for _, n := range root.childrenNamed("num") {
+		p.numFmts[n.attr("numId")] = abstract[n.child("abstractNumId").attr("val")]
+	}
+
+	return nil
+}
+
+func (p *docxParser) loadRels() error {
+	rels, err := readZipXML(p.files, "word/_rels/document.xml.rels")
+	if err != nil || rels == nil {
+		return err
+	}
+
+	for _, rel := range rels.find("Relationships").childrenNamed("Relationship") {
+		p.rels[rel.attr("Id")] = docxTarget{
+			target:   rel.attr("Target"),
+			external: rel.attr("TargetMode") == "External",
+		}
+	}
+
+	return nil
+}
+
+// styleChain returns the style and the styles it's based on, nearest first
+func (p *docxParser) styleChain(styleID string) []docxStyle {
+	chain := []docxStyle{}
+	seen := map[string]bool{}
+	for styleID != "" && !seen[styleID] {
+		seen[styleID] = true
+		style, ok := p.styles[styleID]
+		if !ok {
+			break
+		}
+		chain = append(chain, style)
+		styleID = style.basedOn
+	}
+
+	return chain
+}
+
+func (p *docxParser) walkBody(node *xmlNode) error {
+	for _, c := range node.Children {
+		switch c.Name.Local {
+		case "p":
+			if err := p.paragraph(c); err != nil {
+				return err
+			}
+		case "sectPr", "del":
+		default:
+			// tables, content controls, etc. are flattened into their paragraphs
+			if err := p.walkBody(c); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *docxParser) paragraph(para *xmlNode) error {
+	pPr := para.child("pPr")
+	pieces := []importPiece{}
+	images := []FormatV3Image{}
+
+	err := p.walkRuns(para, "", &pieces, &images)
+	if err != nil {
+		return err
+	}
+
+	isCaption := false
+	for _, style := range p.styleChain(pPr.child("pStyle").attr("val")) {
+		if style.name == "caption" {
+			isCaption = true
+		}
+	}
+
+	lineFmt := p.lineFormat(pPr)
+	if len(pieces) == 0 && len(images) == 0 && pPr.child("pBdr").child("bottom") != nil {
+		lineFmt = FormatV3Rule{}
+	}
+
+	p.b.paragraph(pieces, images, lineFmt, isCaption)
+	return nil
+}
+
+func (p *docxParser) lineFormat(pPr *xmlNode) FormatV3 {
+	styleID := pPr.child("pStyle").attr("val")
+	chain := p.styleChain(styleID)
+
+	numID, ilvl := "", ""
+	if numPr := pPr.child("numPr"); numPr != nil {
+		numID = numPr.child("numId").attr("val")
+		ilvl = numPr.child("ilvl").attr("val")
+	}
+	for _, style := range chain {
+		if numID == "" {
+			numID = style.numID
+		}
+		if ilvl == "" {
+			ilvl = style.ilvl
+		}
+	}
+
+	if numID != "" && numID != "0" {
+		level, _ := strconv.Atoi(ilvl)
+		if p.numFmts[numID][strconv.Itoa(level)] == "bullet" {
+			return FormatV3BulletList(level)
+		}
+		return FormatV3OrderedList(level)
+	}
+
+	outlineLvl := pPr.child("outlineLvl").attr("val")
+	for _, style := range chain {
+		name := style.name
+		switch {
+		case name == "title":
+			return FormatV3Header(1)
+		case name == "subtitle":
+			return FormatV3Header(2)
+		case strings.HasPrefix(name, "heading "):
+			level, err := strconv.Atoi(strings.TrimPrefix(name, "heading "))
+			if err == nil && level >= 1 && level <= 6 {
+				return FormatV3Header(level)
+			}
+		case name == "quote" || name == "intense quote" || name == "block text":
+			return FormatV3BlockQuote{}
+		case strings.Contains(name, "code") || strings.Contains(name, "preformatted"):
+			return FormatV3CodeBlock("")
+		}
+
+		if outlineLvl == "" {
+			outlineLvl = style.outlineLvl
+		}
+	}
+
+	// outline level 9 is body text
+	if level, err := strconv.Atoi(outlineLvl); err == nil && level < 6 {
+		return FormatV3Header(level + 1)
+	}
+
+	if left, err := strconv.Atoi(pPr.child("ind").attr("left")); err == nil && left > 0 {
+		return FormatV3IndentedLine(max(0, left/docxIndentTwips-1))
+	}
+
+	return FormatV3Line{}
+}
+
+func (p *docxParser) walkRuns(node *xmlNode, link string, pieces *[]importPiece, images *[]FormatV3Image) error {
+	for _, c := range node.Children {
+		switch c.Name.Local {
+		case "r":
+			if err := p.run(c, link, pieces, images); err != nil {
+				return err
+			}
+		case "hyperlink":
+			target := link
+			if rel, ok := p.rels[c.attr("id")]; ok {
+				target = rel.target
+			}
+			if err := p.walkRuns(c, target, pieces, images); err != nil {
+				return err
+			}
+		case "ins", "smartTag", "sdt", "sdtContent", "fldSimple", "customXml":
+			if err := p.walkRuns(c, link, pieces, images); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *docxParser) run(run *xmlNode, link string, pieces *[]importPiece, images *[]FormatV3Image) error {
+	format := p.runFormat(run.child("rPr"))
+	if link != "" {
+		format["a"] = link
+	}
+
+	for _, c := range run.Children {
+		switch c.Name.Local {
+		case "t":
+			*pieces = append(*pieces, importPiece{text: c.text(), format: format})
+		case "tab":
+			*pieces = append(*pieces, importPiece{text: "\t", format: format})
+		case "noBreakHyphen":
+			*pieces = append(*pieces, importPiece{text: "-", format: format})
+		case "br", "cr":
+			*pieces = append(*pieces, importPiece{br: true})
+		case "drawing", "pict":
+			img, err := p.image(c)
+			if err != nil {
+				return err
+			}
+			if img != nil {
+				*images = append(*images, *img)
+			}
+		}
+	}
+
+	return nil
+}
+
+func docxOn(n *xmlNode) bool {
+	if n == nil {
+		return false
+	}
+
+	switch n.attr("val") {
+	case "0", "false", "off", "none":
+		return false
+	}
+
+	return true
+}
+
+func (p *docxParser) runFormat(rPr *xmlNode) FormatV3Span {
+	format := FormatV3Span{}
+	if rPr == nil {
+		return format
+	}
+
+	for _, style := range p.styleChain(rPr.child("rStyle").attr("val")) {
+		switch {
+		case style.name == "strong":
+			format["b"] = "true"
+		case style.name == "emphasis":
+			format["i"] = "true"
+		case strings.Contains(style.name, "code"):
+			format["c"] = "true"
+		}
+	}
+
+	if docxOn(rPr.child("b")) {
+		format["b"] = "true"
+	}
+	if docxOn(rPr.child("i")) {
+		format["i"] = "true"
+	}
+	if docxOn(rPr.child("u")) {
+		format["u"] = "true"
+	}
+	if docxOn(rPr.child("strike")) || docxOn(rPr.child("dstrike")) {
+		format["s"] = "true"
+	}
+
+	font := strings.ToLower(rPr.child("rFonts").attr("ascii"))
+	for _, mono := range monospaceFonts {
+		if font != "" && strings.Contains(font, mono) {
+			format["c"] = "true"
+		}
+	}
+
+	return format
+}
+
+func (p *docxParser) image(node *xmlNode) (*FormatV3Image, error) {
+	var rID string
+	if blip := node.find("blip"); blip != nil {
+		rID = blip.attr("embed")
+		if rID == "" {
+			rID = blip.attr("link")
+		}
+	} else if imageData := node.find("imagedata"); imageData != nil {
+		rID = imageData.attr("id")
+	}
+
+	rel, ok := p.rels[rID]
+	if !ok {
+		return nil, nil
+	}
+
+	img := &FormatV3Image{}
+	if docPr := node.find("docPr"); docPr != nil {
+		img.Alt = docPr.attr("descr")
+		if img.Alt == "" {
+			img.Alt = docPr.attr("title")
+		}
+	}
+
+	if extent := node.find("extent"); extent != nil {
+		if cx, err := strconv.Atoi(extent.attr("cx")); err == nil && cx > 0 {
+			img.Width = fmt.Sprintf("%dpx", cx/docxEmuPerPx)
+		}
+		if cy, err := strconv.Atoi(extent.attr("cy")); err == nil && cy > 0 {
+			img.Height = fmt.Sprintf("%dpx", cy/docxEmuPerPx)
+		}
+	}
+
+	if rel.external {
+		img.Src = rel.target
+		return img, nil
+	}
+
+	if p.saveImage == nil {
+		return nil, nil
+	}
+
+	// targets are relative to word/ unless they're absolute
+	name := strings.TrimPrefix(rel.target, "/")
+	if !strings.HasPrefix(rel.target, "/") {
+		name = path.Join("word", rel.target)
+	}
+
+	data, err := readZipFile(p.files, name)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	img.Src, err = p.saveImage(path.Base(name), data)
+	if err != nil {
+		return nil, fmt.Errorf("error saving image %s: %w", name, err)
+	}
+	if img.Src == "" {
+		return nil, nil
+	}
+
+	return img, nil
+}
@@ -0,0 +1,448 @@
+package v3
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+type odtStyle struct {
+	name      string
+	parent    string
+	listStyle string
+	format    FormatV3Span
+}
+
+type odtParser struct {
+	b          spanBuilder
+	styles     map[string]odtStyle
+	listStyles map[string]map[int]bool // list style -> level -> is bullet
+	files      *zipArchive
+	saveImage  ImageSaver
+}
+
+// ParseOdt converts an OpenDocument text (.odt) file into plain text and
+// format spans, see ParseDocx.
+func ParseOdt(data []byte, saveImage ImageSaver) (string, []TextSpan, error) {
+	files, err := readZipFiles(data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	content, err := readZipXML(files, "content.xml")
+	if err != nil {
+		return "", nil, err
+	}
+	if content == nil {
+		return "", nil, fmt.Errorf("not an odt file: missing content.xml")
+	}
+
+	p := &odtParser{
+		styles:     map[string]odtStyle{},
+		listStyles: map[string]map[int]bool{},
+		files:      files,
+		saveImage:  saveImage,
+	}
+
+	styles, err := readZipXML(files, "styles.xml")
+	if err != nil {
+		return "", nil, err
+	}
+	p.loadStyles(styles)
+	p.loadStyles(content)
+
+	text := content.find("body").child("text")
+	if text == nil {
+		return "", nil, fmt.Errorf("not an odt file: missing office:text")
+	}
+
+	if err := p.walkBody(text, "", -1); err != nil {
+		return "", nil, err
+	}
+
+	out, spans := p.b.result()
+	return out, spans, nil
+}
+
+// loadStyles loads the named and automatic styles, automatic styles (P1, T1,
+// etc.) are what direct formatting is stored as
+func (p *odtParser) loadStyles(root *xmlNode) {
+	if root == nil {
+		return
+	}
+
+	for _, section := range []string{"styles", "automatic-styles"} {
+		node := root.find(section)
+		if node == nil {
+			continue
+		}
+
+		for _, s := range node.childrenNamed("style") {
+			name := s.attr("display-name")
+			if name == "" {
+				name = s.attr("name")
+			}
+
+			p.styles[s.attr("name")] = odtStyle{
+				name:      strings.ToLower(strings.ReplaceAll(name, "_20_", " ")),
+				parent:    s.attr("parent-style-name"),
+				listStyle: s.attr("list-style-name"),
+				format:    odtTextFormat(s.child("text-properties")),
+			}
+		}
+
+		for _, ls := range node.childrenNamed("list-style") {
+			levels := map[int]bool{}
+			for _, c := range ls.Children {
+				level, err := strconv.Atoi(c.attr("level"))
+				if err != nil {
+					continue
+				}
+				levels[level] = c.Name.Local != "list-level-style-number"
+			}
+			p.listStyles[ls.attr("name")] = levels
+		}
+	}
+}
+
+func odtTextFormat(props *xmlNode) FormatV3Span {
+	format := FormatV3Span{}
+	if props == nil {
+		return format
+	}
+
+	if props.attr("font-weight") == "bold" {
+		format["b"] = "true"
+	}
+	if props.attr("font-style") == "italic" {
+		format["i"] = "true"
+	}
+	if s := props.attr("text-underline-style"); s != "" && s != "none" {
+		format["u"] = "true"
+	}
+	if s := props.attr("text-line-through-style"); s != "" && s != "none" {
+		format["s"] = "true"
+	}
+
+	font := strings.ToLower(props.attr("font-name") + props.attr("font-family"))
+	for _, mono := range monospaceFonts {
+		if strings.Contains(font, mono) {
+			format["c"] = "true"
+		}
+	}
+
+	return format
+}
+
+// styleChain returns the style and its parents, nearest first
+func (p *odtParser) styleChain(name string) []odtStyle {
+	chain := []odtStyle{}
+	seen := map[string]bool{}
+	for name != "" && !seen[name] {
+		seen[name] = true
+		style, ok := p.styles[name]
+		if !ok {
+			break
+		}
+		chain = append(chain, style)
+		name = style.parent
+	}
+
+	return chain
+}
+
+// walkBody writes the paragraphs under node, listStyle and listLevel are set
+// inside of a text:list
+func (p *odtParser) walkBody(node *xmlNode, listStyle string, listLevel int) error {
+	for _, c := range node.Children {
+		switch c.Name.Local {
+		case "p", "h":
+			if err := p.paragraph(c, nil); err != nil {
+				return err
+			}
+		case "list":
+			style := c.attr("style-name")
+			if style == "" {
+				style = listStyle
+			}
+			if err := p.list(c, style, listLevel+1); err != nil {
+				return err
+			}
+		case "sequence-decls", "tracked-changes", "variable-decls", "user-field-decls", "soft-page-break", "table-columns", "table-column":
+		default:
+			// tables, sections, etc. are flattened into their paragraphs
+			if err := p.walkBody(c, listStyle, listLevel); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *odtParser) list(list *xmlNode, listStyle string, level int) error {
+	for _, item := range list.Children {
+		if item.Name.Local != "list-item" && item.Name.Local != "list-header" {
+			continue
+		}
+
+		first := true
+		for _, c := range item.Children {
+			switch c.Name.Local {
+			case "p", "h":
+				var lineFmt FormatV3 = FormatV3IndentedLine(level)
+				if first && item.Name.Local == "list-item" {
+					lineFmt = p.listFormat(c, listStyle, level)
+				}
+				first = false
+
+				if err := p.paragraph(c, lineFmt); err != nil {
+					return err
+				}
+			case "list":
+				style := c.attr("style-name")
+				if style == "" {
+					style = listStyle
+				}
+				if err := p.list(c, style, level+1); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *odtParser) listFormat(para *xmlNode, listStyle string, level int) FormatV3 {
+	if listStyle == "" {
+		for _, style := range p.styleChain(para.attr("style-name")) {
+			if style.listStyle != "" {
+				listStyle = style.listStyle
+				break
+			}
+		}
+	}
+
+	// odt levels are 1 based
+	bullet, ok := p.listStyles[listStyle][level+1]
+	if !ok || bullet {
+		return FormatV3BulletList(level)
+	}
+
+	return FormatV3OrderedList(level)
+}
+
+// paragraph writes a text:p or text:h, lineFmt overrides the paragraph's own
+// format for list items
+func (p *odtParser) paragraph(para *xmlNode, lineFmt FormatV3) error {
+	pieces := []importPiece{}
+	images := []FormatV3Image{}
+
+	chain := p.styleChain(para.attr("style-name"))
+	if lineFmt == nil {
+		lineFmt = p.lineFormat(para, chain)
+	}
+
+	// headings are bold by style, only direct formatting of body text is kept
+	base := FormatV3Span{}
+	switch lineFmt.(type) {
+	case FormatV3Header, FormatV3CodeBlock:
+	default:
+		for i := len(chain) - 1; i >= 0; i-- {
+			for k, v := range chain[i].format {
+				base[k] = v
+			}
+		}
+	}
+
+	err := p.walkInline(para, base, &pieces, &images)
+	if err != nil {
+		return err
+	}
+
+	isCaption := false
+	for _, style := range chain {
+		if style.name == "caption" || style.name == "illustration" || style.name == "figure" {
+			isCaption = true
+		}
+	}
+
+	p.b.paragraph(pieces, images, lineFmt, isCaption)
+	return nil
+}
+
+func (p *odtParser) lineFormat(para *xmlNode, chain []odtStyle) FormatV3 {
+	if para.Name.Local == "h" {
+		level, err := strconv.Atoi(para.attr("outline-level"))
+		if err != nil {
+			level = 1
+		}
+		return FormatV3Header(min(max(level, 1), 6))
+	}
+
+	for _, style := range chain {
+		name := style.name
+		switch {
+		case name == "title":
+			return FormatV3Header(1)
+		case name == "subtitle":
+			return FormatV3Header(2)
+		case strings.HasPrefix(name, "heading "):
+			level, err := strconv.Atoi(strings.TrimPrefix(name, "heading "))
+			if err == nil && level >= 1 && level <= 6 {
+				return FormatV3Header(level)
+			}
+		case name == "quotations" || name == "quote":
+			return FormatV3BlockQuote{}
+		case name == "preformatted text" || strings.Contains(name, "code"):
+			return FormatV3CodeBlock("")
+		case name == "horizontal line":
+			return FormatV3Rule{}
+		}
+	}
+
+	return FormatV3Line{}
+}
+
+func copySpan(f FormatV3Span) FormatV3Span {
+	out := make(FormatV3Span, len(f))
+	for k, v := range f {
+		out[k] = v
+	}
+	return out
+}
+
+func (p *odtParser) walkInline(node *xmlNode, format FormatV3Span, pieces *[]importPiece, images *[]FormatV3Image) error {
+	for _, c := range node.Children {
+		if c.isText() {
+			// odf collapses whitespace, runs of spaces are written as text:s
+			text := spacePattern.ReplaceAllString(c.Text, " ")
+			*pieces = append(*pieces, importPiece{text: text, format: format})
+			continue
+		}
+
+		switch c.Name.Local {
+		case "s":
+			count, err := strconv.Atoi(c.attr("c"))
+			if err != nil || count < 1 {
+				count = 1
+			}
+			*pieces = append(*pieces, importPiece{text: strings.Repeat(" ", count), format: format})
+		case "tab":
+			*pieces = append(*pieces, importPiece{text: "\t", format: format})
+		case "line-break":
+			*pieces = append(*pieces, importPiece{br: true})
+		case "span":
+			f := copySpan(format)
+			chain := p.styleChain(c.attr("style-name"))
+			for i := len(chain) - 1; i >= 0; i-- {
+				for k, v := range chain[i].format {
+					f[k] = v
+				}
+			}
+			if err := p.walkInline(c, f, pieces, images); err != nil {
+				return err
+			}
+		case "a":
+			f := copySpan(format)
+			if href := c.attr("href"); href != "" {
+				f["a"] = href
+			}
+			if err := p.walkInline(c, f, pieces, images); err != nil {
+				return err
+			}
+		case "frame":
+			img, err := p.image(c)
+			if err != nil {
+				return err
+			}
+			if img != nil {
+				*images = append(*images, *img)
+			}
+		case "note", "annotation", "bookmark", "bookmark-start", "bookmark-end", "soft-page-break":
+		default:
+			if err := p.walkInline(c, format, pieces, images); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// odtLength converts an odf length (e.g. 6.5in, 2.54cm) to a css pixel length
+func odtLength(s string) string {
+	units := []struct {
+		suffix string
+		px     float64
+	}{
+		{"in", 96},
+		{"cm", 96 / 2.54},
+		{"mm", 96 / 25.4},
+		{"pt", 96.0 / 72},
+		{"px", 1},
+	}
+
+	for _, u := range units {
+		if strings.HasSuffix(s, u.suffix) {
+			v, err := strconv.ParseFloat(strings.TrimSuffix(s, u.suffix), 64)
+			if err != nil || v <= 0 {
+				return ""
+			}
+			return fmt.Sprintf("%dpx", int(v*u.px))
+		}
+	}
+
+	return ""
+}
+
+func (p *odtParser) image(frame *xmlNode) (*FormatV3Image, error) {
+	imgNode := frame.find("image")
+	if imgNode == nil {
+		return nil, nil
+	}
+
+	href := imgNode.attr("href")
+	if href == "" {
+		return nil, nil
+	}
+
+	img := &FormatV3Image{
+		Width:  odtLength(frame.attr("width")),
+		Height: odtLength(frame.attr("height")),
+		Alt:    strings.TrimSpace(frame.child("desc").text()),
+	}
+	if img.Alt == "" {
+		img.Alt = strings.TrimSpace(frame.child("title").text())
+	}
+
+	if strings.HasPrefix(href, "http://") || strings.HasPrefix(href, "https://") {
+		img.Src = href
+		return img, nil
+	}
+
+	if p.saveImage == nil {
+		return nil, nil
+	}
+
+	name := strings.TrimPrefix(href, "./")
+	data, err := readZipFile(p.files, name)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	img.Src, err = p.saveImage(path.Base(name), data)
+	if err != nil {
+		return nil, fmt.Errorf("error saving image %s: %w", name, err)
+	}
+	if img.Src == "" {
+		return nil, nil
+	}
+
+	return img, nil
+}
@@ -0,0 +1,340 @@
+package v3
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+)
+
+// ImageSaver stores an image embedded in an imported file and returns the src
+// the image line should point at. An empty src drops the image.
+type ImageSaver func(name string, data []byte) (string, error)
+
+// xmlNode is a minimal DOM, office formats mix text and elements (e.g. odt's
+// <text:s/>) so the order of the children matters
+type xmlNode struct {
+	Name     xml.Name
+	Attrs    []xml.Attr
+	Children []*xmlNode
+	Text     string // only set on text nodes, which have an empty Name
+}
+
+func parseXMLTree(data []byte) (*xmlNode, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	root := &xmlNode{}
+	stack := []*xmlNode{root}
+
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing xml: %w", err)
+		}
+
+		cur := stack[len(stack)-1]
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &xmlNode{Name: t.Name, Attrs: t.Attr}
+			cur.Children = append(cur.Children, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			cur.Children = append(cur.Children, &xmlNode{Text: string(t)})
+		}
+	}
+
+	return root, nil
+}
+
+func (n *xmlNode) isText() bool {
+	return n.Name.Local == ""
+}
+
+// attr looks an attribute up by its local name, the namespace prefixes used
+// by the different office suites aren't consistent
+func (n *xmlNode) attr(local string) string {
+	if n == nil {
+		return ""
+	}
+
+	for _, a := range n.Attrs {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+
+	return ""
+}
+
+func (n *xmlNode) child(local string) *xmlNode {
+	if n == nil {
+		return nil
+	}
+
+	for _, c := range n.Children {
+		if c.Name.Local == local {
+			return c
+		}
+	}
+
+	return nil
+}
+
+func (n *xmlNode) childrenNamed(local string) []*xmlNode {
+	if n == nil {
+		return nil
+	}
+
+	out := []*xmlNode{}
+	for _, c := range n.Children {
+		if c.Name.Local == local {
+			out = append(out, c)
+		}
+	}
+
+	return out
+}
+
+// find returns the first descendant with the given local name
+func (n *xmlNode) find(local string) *xmlNode {
+	if n == nil {
+		return nil
+	}
+
+	for _, c := range n.Children {
+		if c.Name.Local == local {
+			return c
+		}
+		if found := c.find(local); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// text concatenates all of the text nodes under n
+func (n *xmlNode) text() string {
+	if n == nil {
+		return ""
+	}
+	if n.isText() {
+		return n.Text
+	}
+
+	out := ""
+	for _, c := range n.Children {
+		out += c.text()
+	}
+
+	return out
+}
+
+const (
+	// maxZipEntrySize caps a single decompressed part of an imported file
+	maxZipEntrySize = 100 * 1024 * 1024
+	// maxZipTotalSize caps everything decompressed from one imported file
+	maxZipTotalSize = 200 * 1024 * 1024
+)
+
+// ErrArchiveTooLarge is returned when an imported file decompresses to more
+// than the zip limits allow, e.g. a zip bomb
+var ErrArchiveTooLarge = errors.New("archive is too large once decompressed")
+
+// zipArchive keeps a running total of what's been decompressed so the total
+// limit holds across every part that's read
+type zipArchive struct {
+	files map[string]*zip.File
+	read  int64
+}
+
+func readZipFiles(data []byte) (*zipArchive, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("error opening archive: %w", err)
+	}
+
+	files := map[string]*zip.File{}
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	return &zipArchive{files: files}, nil
+}
+
+func readZipFile(z *zipArchive, name string) ([]byte, error) {
+	f, ok := z.files[name]
+	if !ok {
+		return nil, nil
+	}
+
+	if f.UncompressedSize64 > maxZipEntrySize {
+		return nil, fmt.Errorf("%s: %w", name, ErrArchiveTooLarge)
+	}
+
+	// the declared size can't be trusted, so the reader is capped as well
+	limit := min(int64(maxZipEntrySize), maxZipTotalSize-z.read)
+
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s: %w", name, err)
+	}
+	defer rc.Close()
+
+	b, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", name, err)
+	}
+
+	if int64(len(b)) > limit {
+		return nil, fmt.Errorf("%s: %w", name, ErrArchiveTooLarge)
+	}
+	z.read += int64(len(b))
+
+	return b, nil
+}
+
+func readZipXML(z *zipArchive, name string) (*xmlNode, error) {
+	b, err := readZipFile(z, name)
+	if err != nil || b == nil {
+		return nil, err
+	}
+
+	return parseXMLTree(b)
+}
+
+// importPiece is a run of text in an imported paragraph, or a line break
+type importPiece struct {
+	text   string
+	format FormatV3Span
+	br     bool
+}
+
+// spanBuilder accumulates the plain text and format spans of an imported
+// document, in the same shape ParseHtml returns them
+type spanBuilder struct {
+	text  PlainText
+	spans []TextSpan
+
+	// an image waiting to see if the next paragraph is its caption
+	pendingImage *FormatV3Image
+}
+
+// paragraph writes an imported paragraph, images become lines of their own
+// and a caption paragraph directly after an image becomes the image line's text
+func (b *spanBuilder) paragraph(pieces []importPiece, images []FormatV3Image, lineFmt FormatV3, isCaption bool) {
+	hasText := false
+	for _, piece := range pieces {
+		if piece.text != "" {
+			hasText = true
+			break
+		}
+	}
+
+	if b.pendingImage != nil && isCaption && len(images) == 0 {
+		img := *b.pendingImage
+		b.pendingImage = nil
+		b.writePieces(pieces, img)
+		b.endLine(img)
+		return
+	}
+	b.flushImage()
+
+	for i := range images {
+		if i == len(images)-1 && !hasText {
+			b.pendingImage = &images[i]
+			return
+		}
+		b.endLine(images[i])
+	}
+
+	if len(images) > 0 && !hasText {
+		return
+	}
+
+	b.writePieces(pieces, lineFmt)
+	b.endLine(lineFmt)
+}
+
+func (b *spanBuilder) flushImage() {
+	if b.pendingImage != nil {
+		b.endLine(*b.pendingImage)
+		b.pendingImage = nil
+	}
+}
+
+func (b *spanBuilder) writePieces(pieces []importPiece, lineFmt FormatV3) {
+	_, isCode := lineFmt.(FormatV3CodeBlock)
+	for _, piece := range pieces {
+		if piece.br {
+			b.endLine(lineFmt)
+			continue
+		}
+
+		// code blocks are raw text
+		if isCode {
+			b.writeText(piece.text, nil)
+		} else {
+			b.writeText(piece.text, piece.format)
+		}
+	}
+}
+
+func (b *spanBuilder) writeText(s string, format FormatV3Span) {
+	if s == "" {
+		return
+	}
+
+	start := b.text.Len()
+	b.text.WriteString(s)
+	if len(format) == 0 {
+		return
+	}
+
+	// extend the previous span rather than formatting each run separately
+	if n := len(b.spans); n > 0 {
+		prev := &b.spans[n-1]
+		if prevFmt, ok := prev.Format.(FormatV3Span); ok && prev.EndIndex == start && maps.Equal(prevFmt, format) {
+			prev.EndIndex = b.text.Len()
+			return
+		}
+	}
+
+	b.spans = append(b.spans, TextSpan{StartIndex: start, EndIndex: b.text.Len(), Format: format})
+}
+
+// endLine writes the newline that ends a line, line formats live on the newline
+func (b *spanBuilder) endLine(format FormatV3) {
+	start := b.text.Len()
+	b.text.WriteString("\n")
+
+	if format == nil {
+		return
+	}
+	if _, ok := format.(FormatV3Line); ok {
+		return
+	}
+
+	b.spans = append(b.spans, TextSpan{StartIndex: start, EndIndex: start + 1, Format: format})
+}
+
+// result drops the final newline, when inserted into a new doc the doc's own
+// trailing newline ends the last line and picks up its format
+func (b *spanBuilder) result() (string, []TextSpan) {
+	b.flushImage()
+
+	if b.text.Len() > 0 && b.text[b.text.Len()-1] == '\n' {
+		b.text = b.text[:b.text.Len()-1]
+	}
+
+	return b.text.String(), b.spans
+}
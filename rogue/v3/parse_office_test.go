@@ -0,0 +1,234 @@
+package v3_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"testing"
+
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func zipFiles(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	return buf.Bytes()
+}
+
+func importInto(t *testing.T, text string, spans []v3.TextSpan) *v3.Rogue {
+	r := v3.NewRogueForQuill("1")
+	_, err := r.InsertSpans(0, text, spans)
+	require.NoError(t, err)
+
+	return r
+}
+
+func TestParseDocx_roundTrip(t *testing.T) {
+	r := v3.NewRogueForQuill("0")
+	_, err := r.Insert(0, "Title\nfirst\nnested\nsecond\nbullet\nquote\nfmt.Println(\"<hi>\")\ncaption\nbold link\n")
+	require.NoError(t, err)
+
+	_, err = r.Format(5, 1, v3.FormatV3Header(1))
+	require.NoError(t, err)
+	_, err = r.Format(11, 1, v3.FormatV3OrderedList(0))
+	require.NoError(t, err)
+	_, err = r.Format(18, 1, v3.FormatV3OrderedList(1))
+	require.NoError(t, err)
+	_, err = r.Format(25, 1, v3.FormatV3OrderedList(0))
+	require.NoError(t, err)
+	_, err = r.Format(32, 1, v3.FormatV3BulletList(0))
+	require.NoError(t, err)
+	_, err = r.Format(38, 1, v3.FormatV3BlockQuote{})
+	require.NoError(t, err)
+	_, err = r.Format(58, 1, v3.FormatV3CodeBlock(""))
+	require.NoError(t, err)
+	_, err = r.Format(66, 1, v3.FormatV3Image{Src: "https://example.com/a.png", Alt: "a & b", Width: "200px", Height: "100px"})
+	require.NoError(t, err)
+	_, err = r.Format(67, 4, v3.FormatV3Span{"b": "true"})
+	require.NoError(t, err)
+	_, err = r.Format(72, 4, v3.FormatV3Span{"a": "https://example.com?a=1&b=2"})
+	require.NoError(t, err)
+
+	docx, err := r.GetFullDocx(nil, nil)
+	require.NoError(t, err)
+
+	text, spans, err := v3.ParseDocx(docx, nil)
+	require.NoError(t, err)
+
+	imported := importInto(t, text, spans)
+
+	expected, err := r.GetFullMarkdown()
+	require.NoError(t, err)
+	actual, err := imported.GetFullMarkdown()
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+}
+
+const docxRels = `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+<Relationship Id="rId5" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="media/image1.png"/>
+</Relationships>`
+
+const docxStyles = `<?xml version="1.0" encoding="UTF-8"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:style w:type="paragraph" w:styleId="Heading2"><w:name w:val="heading 2"/><w:pPr><w:outlineLvl w:val="1"/></w:pPr></w:style>
+<w:style w:type="paragraph" w:styleId="ListBullet"><w:name w:val="List Bullet"/><w:pPr><w:numPr><w:numId w:val="7"/></w:numPr></w:pPr></w:style>
+<w:style w:type="character" w:styleId="Strong"><w:name w:val="Strong"/></w:style>
+</w:styles>`
+
+const docxNumbering = `<?xml version="1.0" encoding="UTF-8"?>
+<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:abstractNum w:abstractNumId="3"><w:lvl w:ilvl="0"><w:numFmt w:val="bullet"/></w:lvl></w:abstractNum>
+<w:num w:numId="7"><w:abstractNumId w:val="3"/></w:num>
+</w:numbering>`
+
+const docxDocument = `<?xml version="1.0" encoding="UTF-8"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:wp="http://schemas.openxmlformats.org/drawingml/2006/wordprocessingDrawing" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">
+<w:body>
+<w:p><w:pPr><w:pStyle w:val="Heading2"/></w:pPr><w:r><w:t>Plan</w:t></w:r></w:p>
+<w:p><w:pPr><w:pStyle w:val="ListBullet"/></w:pPr><w:r><w:rPr><w:rStyle w:val="Strong"/></w:rPr><w:t>one</w:t></w:r><w:r><w:rPr><w:b w:val="0"/></w:rPr><w:t xml:space="preserve"> two</w:t></w:r></w:p>
+<w:tbl><w:tr><w:tc><w:p><w:r><w:t>cell</w:t></w:r><w:r><w:br/><w:t>break</w:t></w:r></w:p></w:tc></w:tr></w:tbl>
+<w:p><w:r><w:drawing><wp:inline><wp:extent cx="952500" cy="476250"/><wp:docPr id="1" name="Picture 1" descr="chart"/><a:graphic><a:graphicData><a:blip r:embed="rId5"/></a:graphicData></a:graphic></wp:inline></w:drawing></w:r></w:p>
+<w:p><w:r><w:rPr><w:i/><w:rFonts w:ascii="Consolas"/></w:rPr><w:t>end</w:t></w:r></w:p>
+<w:sectPr/>
+</w:body>
+</w:document>`
+
+func TestParseDocx(t *testing.T) {
+	docx := zipFiles(t, map[string]string{
+		"word/document.xml":            docxDocument,
+		"word/styles.xml":              docxStyles,
+		"word/numbering.xml":           docxNumbering,
+		"word/_rels/document.xml.rels": docxRels,
+		"word/media/image1.png":        "png bytes",
+	})
+
+	saved := map[string]string{}
+	text, spans, err := v3.ParseDocx(docx, func(name string, data []byte) (string, error) {
+		saved[name] = string(data)
+		return "https://images.example.com/" + name, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"image1.png": "png bytes"}, saved)
+	require.Equal(t, "Plan\none two\ncell\nbreak\n\nend", text)
+
+	require.Equal(t, []v3.TextSpan{
+		{StartIndex: 4, EndIndex: 5, Format: v3.FormatV3Header(2)},
+		{StartIndex: 5, EndIndex: 8, Format: v3.FormatV3Span{"b": "true"}},
+		{StartIndex: 12, EndIndex: 13, Format: v3.FormatV3BulletList(0)},
+		{StartIndex: 24, EndIndex: 25, Format: v3.FormatV3Image{Src: "https://images.example.com/image1.png", Alt: "chart", Width: "100px", Height: "50px"}},
+		{StartIndex: 25, EndIndex: 28, Format: v3.FormatV3Span{"i": "true", "c": "true"}},
+	}, spans)
+
+	r := importInto(t, text, spans)
+	html, err := r.GetFullHtml(false, false)
+	require.NoError(t, err)
+	require.Contains(t, html, "<h2><span>Plan</span></h2>")
+	require.Contains(t, html, `<img src="https://images.example.com/image1.png"`)
+}
+
+func TestParseDocx_notDocx(t *testing.T) {
+	_, _, err := v3.ParseDocx([]byte("not a zip"), nil)
+	require.Error(t, err)
+
+	_, _, err = v3.ParseDocx(zipFiles(t, map[string]string{"content.xml": "<x/>"}), nil)
+	require.Error(t, err)
+}
+
+// zipBomb is a docx whose document.xml expands to size bytes, with a header
+// that claims declared bytes
+func zipBomb(t *testing.T, size int, declared uint64) []byte {
+	var compressed bytes.Buffer
+	fw, err := flate.NewWriter(&compressed, flate.BestCompression)
+	require.NoError(t, err)
+	_, err = fw.Write(bytes.Repeat([]byte(" "), size))
+	require.NoError(t, err)
+	require.NoError(t, fw.Close())
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.CreateRaw(&zip.FileHeader{
+		Name:               "word/document.xml",
+		Method:             zip.Deflate,
+		CompressedSize64:   uint64(compressed.Len()),
+		UncompressedSize64: declared,
+	})
+	require.NoError(t, err)
+	_, err = w.Write(compressed.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	return buf.Bytes()
+}
+
+func TestParseDocx_zipBomb(t *testing.T) {
+	const size = 101 * 1024 * 1024
+
+	_, _, err := v3.ParseDocx(zipBomb(t, 16, size), nil)
+	require.ErrorIs(t, err, v3.ErrArchiveTooLarge)
+
+	// a header that understates the size is caught while reading
+	_, _, err = v3.ParseDocx(zipBomb(t, size, 16), nil)
+	require.Error(t, err)
+}
+
+const odtContent = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0" xmlns:draw="urn:oasis:names:tc:opendocument:xmlns:drawing:1.0" xmlns:svg="urn:oasis:names:tc:opendocument:xmlns:svg-compatible:1.0" xmlns:xlink="http://www.w3.org/1999/xlink">
+<office:automatic-styles>
+<style:style style:name="T1" style:family="text"><style:text-properties fo:font-weight="bold"/></style:style>
+<style:style style:name="P1" style:family="paragraph" style:parent-style-name="Quotations"/>
+<text:list-style style:name="L1"><text:list-level-style-number text:level="1"/><text:list-level-style-bullet text:level="2"/></text:list-style>
+</office:automatic-styles>
+<office:body><office:text>
+<text:h text:outline-level="1">Title</text:h>
+<text:p>Some <text:span text:style-name="T1">bold</text:span>  text<text:s text:c="2"/>and <text:a xlink:href="https://example.com">a link</text:a></text:p>
+<text:list text:style-name="L1"><text:list-item><text:p>first</text:p><text:list><text:list-item><text:p>nested</text:p></text:list-item></text:list></text:list-item></text:list>
+<text:p text:style-name="P1">quoted</text:p>
+<text:p><draw:frame svg:width="1in" svg:height="0.5in"><draw:image xlink:href="Pictures/a.png"/><svg:desc>diagram</svg:desc></draw:frame></text:p>
+<text:p text:style-name="Caption">the caption</text:p>
+</office:text></office:body>
+</office:document-content>`
+
+const odtStyles = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-styles xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0">
+<office:styles>
+<style:style style:name="Quotations" style:family="paragraph"/>
+<style:style style:name="Caption" style:family="paragraph"/>
+</office:styles>
+</office:document-styles>`
+
+func TestParseOdt(t *testing.T) {
+	odt := zipFiles(t, map[string]string{
+		"content.xml":      odtContent,
+		"styles.xml":       odtStyles,
+		"Pictures/a.png":   "png bytes",
+		"mimetype":         "application/vnd.oasis.opendocument.text",
+		"META-INF/any.xml": "<x/>",
+	})
+
+	text, spans, err := v3.ParseOdt(odt, func(name string, data []byte) (string, error) {
+		return "https://images.example.com/" + name, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Title\nSome bold text  and a link\nfirst\nnested\nquoted\nthe caption", text)
+
+	require.Equal(t, []v3.TextSpan{
+		{StartIndex: 5, EndIndex: 6, Format: v3.FormatV3Header(1)},
+		{StartIndex: 11, EndIndex: 15, Format: v3.FormatV3Span{"b": "true"}},
+		{StartIndex: 26, EndIndex: 32, Format: v3.FormatV3Span{"a": "https://example.com"}},
+		{StartIndex: 38, EndIndex: 39, Format: v3.FormatV3OrderedList(0)},
+		{StartIndex: 45, EndIndex: 46, Format: v3.FormatV3BulletList(1)},
+		{StartIndex: 52, EndIndex: 53, Format: v3.FormatV3BlockQuote{}},
+		{StartIndex: 64, EndIndex: 65, Format: v3.FormatV3Image{Src: "https://images.example.com/a.png", Alt: "diagram", Width: "96px", Height: "48px"}},
+	}, spans)
+}
@@ -20,14 +20,23 @@ func (r *Rogue) PasteHtml(idx int, html string) (MultiOp, error) {
 		return mop, fmt.Errorf("ParseHtml(%q): failed to parse HTML: %w", html, err)
 	}
 
-	mop.Mops = make([]Op, 0, len(spans)+1)
+	return r.InsertSpans(idx, text, spans)
+}
 
-	op, err := r.Insert(idx, text)
-	if err != nil {
-		return mop, err
-	}
+// InsertSpans inserts text at idx and applies the format spans, as returned
+// by ParseHtml, ParseDocx and ParseOdt, relative to idx.
+func (r *Rogue) InsertSpans(idx int, text string, spans []TextSpan) (MultiOp, error) {
+	mop := MultiOp{Mops: make([]Op, 0, len(spans)+1)}
 
-	mop = mop.Append(op)
+	// an import with a single line may only format the line it's inserted into
+	if text != "" {
+		op, err := r.Insert(idx, text)
+		if err != nil {
+			return mop, err
+		}
+
+		mop = mop.Append(op)
+	}
 
 	for _, span := range spans {
 		// This is currently happening with image tags that have no content,
@@ -2,6 +2,7 @@ package v3
 
 import (
 	"errors"
+	"slices"
 )
 
 type RenderSpan struct {
@@ -33,6 +34,127 @@ func (r *Rogue) RenderOp(op Op) (renderSpan *RenderSpan, err error) {
 	return r.RenderSpanBetween(opSpan.StartID, opSpan.EndID, beforeAddr, afterAddr)
 }
 
+// MergeSpan is a run of blocks touched by a merged op, the embedded
+// RenderSpan is rendered as of after the merge
+type MergeSpan struct {
+	RenderSpan
+	DiffHtml   string // just the op's changes to the span
+	Concurrent bool   // the span was also edited by ops the author hadn't seen
+}
+
+// SummarizeMerge describes where op landed in the doc. It should be called
+// after the op has been merged, baseAddr is the address the op was authored
+// against so spans that have since been edited by others can be flagged.
+func (r *Rogue) SummarizeMerge(op Op, baseAddr *ContentAddress) ([]MergeSpan, error) {
+	beforeAddr, err := r.AddressBeforeOp(op)
+	if err != nil {
+		return nil, err
+	}
+
+	afterAddr, err := r.AddressAfterOp(op)
+	if err != nil {
+		return nil, err
+	}
+
+	// the base may already include the op if the author's current address
+	// was sent, the op's own changes shouldn't count as concurrent
+	author := op.GetID().Author
+	baseAddr = baseAddr.DeepCopy()
+	baseAddr.MaxIDs[author] = min(baseAddr.MaxIDs[author], beforeAddr.MaxIDs[author])
+
+	ops := []Op{op}
+	if mop, ok := op.(MultiOp); ok {
+		ops = mop.Mops
+	}
+
+	// the tot index range of the blocks touched by each op
+	ixSpans := make([][2]int, 0, len(ops))
+	for _, o := range ops {
+		span, err := r.opSpan(o)
+		if err != nil {
+			return nil, err
+		}
+
+		startID, _, err := r.GetBlockAt(span.StartID, afterAddr)
+		if err != nil {
+			return nil, err
+		}
+
+		_, endID, err := r.GetBlockAt(span.EndID, afterAddr)
+		if err != nil {
+			return nil, err
+		}
+
+		_, startIx, err := r.Rope.GetIndex(startID)
+		if err != nil {
+			return nil, err
+		}
+
+		_, endIx, err := r.Rope.GetIndex(endID)
+		if err != nil {
+			return nil, err
+		}
+
+		ixSpans = append(ixSpans, [2]int{startIx, endIx})
+	}
+
+	slices.SortFunc(ixSpans, func(a, b [2]int) int {
+		return a[0] - b[0]
+	})
+
+	merged := make([][2]int, 0, len(ixSpans))
+	for _, span := range ixSpans {
+		if len(merged) > 0 && span[0] <= merged[len(merged)-1][1] {
+			last := &merged[len(merged)-1]
+			last[1] = max(last[1], span[1])
+			continue
+		}
+
+		merged = append(merged, span)
+	}
+
+	out := make([]MergeSpan, 0, len(merged))
+	for _, span := range merged {
+		startID, err := r.Rope.GetTotID(span[0])
+		if err != nil {
+			return nil, err
+		}
+
+		endID, err := r.Rope.GetTotID(span[1])
+		if err != nil {
+			return nil, err
+		}
+
+		renderSpan, err := r.RenderSpanBetween(startID, endID, beforeAddr, afterAddr)
+		if err != nil {
+			return nil, err
+		}
+
+		diffHtml, err := r.GetHtmlDiffBetween(renderSpan.ToStartID, renderSpan.ToEndID, beforeAddr, afterAddr, false, true)
+		if err != nil {
+			return nil, err
+		}
+
+		baseHtml, err := r.GetHtmlAt(renderSpan.ToStartID, renderSpan.ToEndID, baseAddr, false, true)
+		if err != nil {
+			return nil, err
+		}
+
+		beforeHtml, err := r.GetHtmlAt(renderSpan.ToStartID, renderSpan.ToEndID, beforeAddr, false, true)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, MergeSpan{
+			RenderSpan: *renderSpan,
+			DiffHtml:   diffHtml,
+			Concurrent: baseHtml != beforeHtml,
+		})
+	}
+
+	return out, nil
+}
+
 func (r *Rogue) RenderSpan(startID, endID ID, address *ContentAddress) (renderSpan *RenderSpan, err error) {
 	firstBlockStartID, _, err := r.GetBlockAt(startID, address)
 	if err != nil {
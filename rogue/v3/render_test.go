@@ -117,3 +117,59 @@ func TestGetBlockAt(t *testing.T) {
 		})
 	}
 }
+
+func TestSummarizeMerge(t *testing.T) {
+	server := v3.NewRogueForQuill("0")
+	_, err := server.Insert(0, "hello world\nsecond line\nthird line")
+	require.NoError(t, err)
+
+	ops, err := server.ToOps()
+	require.NoError(t, err)
+
+	client := v3.NewRogueForQuill("1")
+	for _, op := range ops {
+		_, err := client.MergeOp(op)
+		require.NoError(t, err)
+	}
+
+	base, err := client.GetFullAddress()
+	require.NoError(t, err)
+
+	// concurrent edit to the first line while the client is offline
+	server.Author = "2"
+	_, err = server.Insert(0, "big ")
+	require.NoError(t, err)
+
+	// offline edits to the first and third lines
+	op1, err := client.Insert(5, " there")
+	require.NoError(t, err)
+	op2, err := client.Insert(len("hello there world\nsecond line\nthird"), " and final")
+	require.NoError(t, err)
+
+	// the client's current address includes its own offline edits
+	current, err := client.GetFullAddress()
+	require.NoError(t, err)
+
+	batch := v3.MultiOp{Mops: []v3.Op{op1, op2}}
+	_, err = server.MergeOp(batch)
+	require.NoError(t, err)
+
+	spans, err := server.SummarizeMerge(batch, base)
+	require.NoError(t, err)
+	require.Len(t, spans, 2)
+
+	require.True(t, spans[0].Concurrent)
+	require.Contains(t, spans[0].Html, "big hello there world")
+	require.Contains(t, spans[0].DiffHtml, "<ins>there </ins>")
+
+	require.False(t, spans[1].Concurrent)
+	require.Contains(t, spans[1].Html, "third and final line")
+	require.Contains(t, spans[1].DiffHtml, "<ins>")
+	require.NotContains(t, spans[1].DiffHtml, "big")
+
+	spans, err = server.SummarizeMerge(batch, current)
+	require.NoError(t, err)
+	require.Len(t, spans, 2)
+	require.True(t, spans[0].Concurrent)
+	require.False(t, spans[1].Concurrent)
+}
@@ -198,6 +198,58 @@ func (r *Rogue) GetPlaintext(startID, endID ID, addr *ContentAddress) (string, e
 	}
 }
 
+// TextLine is the plain text of a line along with the ID of its first
+// character and of the newline that ends it
+type TextLine struct {
+	StartID ID
+	EndID   ID
+	Text    string
+}
+
+// GetTextLines returns the visible lines of the doc that have non whitespace text
+func (r *Rogue) GetTextLines() ([]TextLine, error) {
+	firstID, err := r.GetFirstID()
+	if err != nil {
+		return nil, fmt.Errorf("r.GetFirstID(): %w", err)
+	}
+
+	lastID, err := r.GetLastID()
+	if err != nil {
+		return nil, fmt.Errorf("r.GetLastID(): %w", err)
+	}
+
+	vis, err := r.Rope.GetBetween(firstID, lastID)
+	if err != nil {
+		return nil, fmt.Errorf("r.Rope.GetBetween(%v, %v): %w", firstID, lastID, err)
+	}
+
+	lines := []TextLine{}
+	start := 0
+	for i, c := range vis.Text {
+		if c != '\n' && i < len(vis.Text)-1 {
+			continue
+		}
+
+		end := i
+		if c != '\n' {
+			end = i + 1
+		}
+
+		text := Uint16ToStr(vis.Text[start:end])
+		if strings.TrimSpace(text) != "" {
+			lines = append(lines, TextLine{
+				StartID: vis.IDs[start],
+				EndID:   vis.IDs[i],
+				Text:    text,
+			})
+		}
+
+		start = i + 1
+	}
+
+	return lines, nil
+}
+
 func (r *Rogue) GetAllNodes() []FugueNode {
 	out := []FugueNode{}
 
@@ -1012,6 +1064,94 @@ func (r *Rogue) ValidateFormat(op FormatOp) error {
 	return nil
 }
 
+// ValidateOp checks that op only references IDs that already exist in the
+// document (or are inserted earlier in the same MultiOp) and that its format
+// ranges are valid. It does not mutate the document.
+func (r *Rogue) ValidateOp(op Op) error {
+	switch op := op.(type) {
+	case MultiOp:
+		pending := make([]InsertOp, 0)
+		for _, mop := range op.Mops {
+			if _, ok := mop.(MultiOp); ok {
+				return ErrorUnsupportedOp{Op: mop}
+			}
+
+			err := r.validateOp(mop, pending)
+			if err != nil {
+				return err
+			}
+
+			if iop, ok := mop.(InsertOp); ok {
+				pending = append(pending, iop)
+			}
+		}
+
+		return nil
+	default:
+		return r.validateOp(op, nil)
+	}
+}
+
+func (r *Rogue) validateOp(op Op, pending []InsertOp) error {
+	hasID := func(id ID) bool {
+		if r.RopeIndex.Get(id) != nil {
+			return true
+		}
+
+		for _, iop := range pending {
+			if iop.ID.Author == id.Author && iop.ID.Seq <= id.Seq && id.Seq < iop.ID.Seq+UTF16Length(iop.Text) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	switch op := op.(type) {
+	case InsertOp:
+		if op.Side == Root {
+			return nil
+		}
+
+		if !hasID(op.ParentID) {
+			return newParentNotFoundError(fmt.Sprintf("Cannot insert: Parent node with ID %+v doesn't exist.", op.ParentID))
+		}
+	case DeleteOp:
+		if !hasID(op.TargetID) {
+			return newParentNotFoundError(fmt.Sprintf("Cannot delete: Node with ID %+v doesn't exist.", op.TargetID))
+		}
+	case ShowOp:
+		if !hasID(op.TargetID) {
+			return newParentNotFoundError(fmt.Sprintf("Cannot show: Node with ID %+v doesn't exist.", op.TargetID))
+		}
+	case FormatOp:
+		if !hasID(op.StartID) {
+			return newParentNotFoundError(fmt.Sprintf("Cannot format: Start node with ID %+v doesn't exist.", op.StartID))
+		}
+
+		if !hasID(op.EndID) {
+			return newParentNotFoundError(fmt.Sprintf("Cannot format: End node with ID %+v doesn't exist.", op.EndID))
+		}
+
+		if r.RopeIndex.Get(op.StartID) == nil || r.RopeIndex.Get(op.EndID) == nil {
+			// the range is inserted by this same multi op so it can't be
+			// indexed until it's merged
+			return nil
+		}
+
+		err := r.ValidateFormat(op)
+		if err != nil {
+			return ErrorInvalidFormat{ID: op.ID, Err: err}
+		}
+	case RewindOp:
+		return nil
+	default:
+		return ErrorUnsupportedOp{Op: op}
+	}
+
+	return nil
+}
+
 func (r *Rogue) _insertListFormat(ix, length int, format FormatV3) (Op, error) {
 	lm := getListMeta(format)
 	if !lm.isList {
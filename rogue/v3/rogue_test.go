@@ -1559,3 +1559,95 @@ func TestFormatSpans(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateOp(t *testing.T) {
+	testCases := []struct {
+		name   string
+		op     Op
+		errAs  any
+		errNil bool
+	}{
+		{
+			name:   "insert with known parent",
+			op:     InsertOp{ID: ID{"b", 10}, Text: "x", ParentID: ID{"auth0", 3}, Side: Right},
+			errNil: true,
+		},
+		{
+			name:  "insert with unknown parent",
+			op:    InsertOp{ID: ID{"b", 10}, Text: "x", ParentID: ID{"c", 99}, Side: Right},
+			errAs: &ErrorParentNotFound{},
+		},
+		{
+			name:  "delete unknown target",
+			op:    DeleteOp{ID: ID{"b", 10}, TargetID: ID{"c", 99}, SpanLength: 1},
+			errAs: &ErrorParentNotFound{},
+		},
+		{
+			name: "multi op referencing its own insert",
+			op: MultiOp{Mops: []Op{
+				InsertOp{ID: ID{"b", 10}, Text: "xyz", ParentID: ID{"auth0", 3}, Side: Right},
+				InsertOp{ID: ID{"b", 13}, Text: "!", ParentID: ID{"b", 11}, Side: Left},
+				FormatOp{ID: ID{"b", 14}, StartID: ID{"b", 10}, EndID: ID{"b", 12}, Format: FormatV3Span{"b": "true"}},
+			}},
+			errNil: true,
+		},
+		{
+			name: "multi op with unknown parent after valid insert",
+			op: MultiOp{Mops: []Op{
+				InsertOp{ID: ID{"b", 10}, Text: "xyz", ParentID: ID{"auth0", 3}, Side: Right},
+				InsertOp{ID: ID{"b", 13}, Text: "!", ParentID: ID{"b", 13}, Side: Left},
+			}},
+			errAs: &ErrorParentNotFound{},
+		},
+		{
+			name:  "line format with different start and end",
+			op:    FormatOp{ID: ID{"b", 10}, StartID: ID{"auth0", 3}, EndID: ID{"auth0", 4}, Format: FormatV3Header(1)},
+			errAs: &ErrorInvalidFormat{},
+		},
+		{
+			name:  "span format with inverted range",
+			op:    FormatOp{ID: ID{"b", 10}, StartID: ID{"auth0", 6}, EndID: ID{"auth0", 3}, Format: FormatV3Span{"b": "true"}},
+			errAs: &ErrorInvalidFormat{},
+		},
+		{
+			name:  "snapshot op",
+			op:    SnapshotOp{},
+			errAs: &ErrorUnsupportedOp{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewRogueForQuill("auth0")
+			_, err := r.Insert(0, "hello world")
+			require.NoError(t, err)
+
+			err = r.ValidateOp(tc.op)
+			if tc.errNil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			require.True(t, errors.As(err, tc.errAs), "unexpected error type %T: %v", err, err)
+
+			// validation must not mutate the document
+			require.Equal(t, "hello world\n", r.GetText())
+		})
+	}
+}
+
+func TestGetTextLines(t *testing.T) {
+	r := NewRogueForQuill("a")
+	_, err := r.Insert(0, "hello world\n\n  \nsecond line")
+	require.NoError(t, err)
+	_, err = r.Delete(0, 6)
+	require.NoError(t, err)
+
+	lines, err := r.GetTextLines()
+	require.NoError(t, err)
+	require.Equal(t, []TextLine{
+		{StartID: ID{"a", 9}, EndID: ID{"a", 14}, Text: "world"},
+		{StartID: ID{"a", 19}, EndID: LastID, Text: "second line"},
+	}, lines)
+}
@@ -45,6 +45,67 @@ func (r *Rogue) ToOps() ([]Op, error) {
 	return out, nil
 }
 
+// OpsSince returns the ops that aren't covered by address, sorted the same way
+// as ToOps, so a replica at address can be caught up without a snapshot.
+// Ops already seen by the replica may be included, merging them is a no-op.
+func (r *Rogue) OpsSince(address *ContentAddress) ([]Op, error) {
+	out := make([]Op, 0)
+
+	for author, tree := range r.OpIndex.AuthorOps {
+		maxSeq, ok := address.MaxIDs[author]
+		if !ok {
+			tree.Dft(func(op Op) error {
+				out = append(out, op)
+				return nil
+			})
+			continue
+		}
+
+		node, err := tree.FindLeftSibNode(maxSeq)
+		if err != nil {
+			return nil, fmt.Errorf("FindLeftSibNode(%d): %w", maxSeq, err)
+		}
+
+		if node == nil {
+			node = tree.MinNode()
+		}
+
+		for ; node != nil; node = node.StepRight() {
+			if MaxID(node.Value).Seq > maxSeq {
+				out = append(out, node.Value)
+			}
+		}
+	}
+
+	r.FailedOps.Tree.Dft(func(op Op) error {
+		if !address.Contains(MaxID(op)) {
+			out = append(out, op)
+		}
+		return nil
+	})
+
+	slices.SortFunc(out, func(a, b Op) int {
+		aID := a.GetID()
+		bID := b.GetID()
+
+		if aID.Seq < bID.Seq {
+			return -1
+		} else if aID.Seq > bID.Seq {
+			return 1
+		}
+
+		if aID.Author < bID.Author {
+			return -1
+		} else if aID.Author > bID.Author {
+			return 1
+		}
+
+		return 0
+	})
+
+	return out, nil
+}
+
 func (r *Rogue) Serializable() (*SerializedRogue, error) {
 	ops, err := r.ToOps()
 	if err != nil {
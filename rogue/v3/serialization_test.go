@@ -153,6 +153,52 @@ func TestToOps(t *testing.T) {
 	}
 }
 
+func TestOpsSince(t *testing.T) {
+	server := v3.NewRogueForQuill("auth0")
+	_, err := server.Insert(0, "hello world")
+	require.NoError(t, err)
+
+	ops, err := server.ToOps()
+	require.NoError(t, err)
+
+	client := v3.NewRogueForQuill("auth1")
+	for _, op := range ops {
+		_, err := client.MergeOp(op)
+		require.NoError(t, err)
+	}
+
+	address, err := client.GetFullAddress()
+	require.NoError(t, err)
+
+	since, err := server.OpsSince(address)
+	require.NoError(t, err)
+	require.Empty(t, since)
+
+	insertOp, err := server.Insert(5, " cruel")
+	require.NoError(t, err)
+	formatOp, err := server.Format(0, 5, v3.FormatV3Span{"b": "true"})
+	require.NoError(t, err)
+
+	server.Author = "auth2"
+	deleteOp, err := server.Delete(0, 1)
+	require.NoError(t, err)
+
+	since, err = server.OpsSince(address)
+	require.NoError(t, err)
+	require.Equal(t, []v3.Op{insertOp, formatOp, deleteOp}, since)
+
+	for _, op := range since {
+		_, err := client.MergeOp(op)
+		require.NoError(t, err)
+	}
+
+	expected, err := server.GetHtml(v3.RootID, v3.LastID, true, false)
+	require.NoError(t, err)
+	actual, err := client.GetHtml(v3.RootID, v3.LastID, true, false)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+}
+
 func TestSerializable_AsJS(t *testing.T) {
 	type tcs struct {
 		name string
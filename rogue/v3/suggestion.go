@@ -0,0 +1,358 @@
+package v3
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// Suggestions are pending edits that collaborators propose rather than
+// apply. They're stored as non sticky span formats so they merge like any
+// other format: a suggested insert is real text marked with SuggestInsertKey,
+// a suggested delete is text that's only marked with SuggestDeleteKey. The
+// value of either key is the author who made the suggestion.
+//
+// Accepting or rejecting a suggestion either clears the mark or deletes the
+// text. If one user accepts while another rejects the same suggestion the
+// delete always wins, so every replica ends up with the same text.
+const (
+	SuggestInsertKey = "si"
+	SuggestDeleteKey = "sd"
+)
+
+type SuggestionType string
+
+const (
+	SuggestionInsert SuggestionType = "insert"
+	SuggestionDelete SuggestionType = "delete"
+)
+
+// Suggestion is a run of visible text with a pending suggestion by Author
+type Suggestion struct {
+	Type    SuggestionType
+	Author  string
+	StartID ID
+	EndID   ID
+	Text    string
+}
+
+func suggestionKey(t SuggestionType) string {
+	if t == SuggestionInsert {
+		return SuggestInsertKey
+	}
+	return SuggestDeleteKey
+}
+
+// SuggestInsert inserts text at visIx as a pending suggestion by r.Author
+func (r *Rogue) SuggestInsert(visIx int, text string) (MultiOp, error) {
+	mop := MultiOp{}
+
+	if text == "" {
+		return mop, nil
+	}
+
+	op, err := r.Insert(visIx, text)
+	if err != nil {
+		return mop, fmt.Errorf("Insert(%d, %q): %w", visIx, text, err)
+	}
+	mop = mop.Append(op)
+
+	fop, err := r.Format(visIx, UTF16Length(text), FormatV3Span{SuggestInsertKey: r.Author})
+	if err != nil {
+		return mop, fmt.Errorf("Format(%d, %d): %w", visIx, UTF16Length(text), err)
+	}
+	mop = mop.Append(fop)
+
+	r.OpIndex.Put(mop)
+	return mop, nil
+}
+
+// SuggestDelete marks the text from visIx to visIx+length as a pending delete
+// by r.Author. Text that is itself a pending insert by r.Author is deleted
+// outright, the same as backspacing over your own tracked change.
+func (r *Rogue) SuggestDelete(visIx, length int) (MultiOp, error) {
+	mop := MultiOp{}
+
+	if length <= 0 {
+		return mop, nil
+	}
+
+	if visIx < 0 || visIx+length > r.VisSize {
+		return mop, fmt.Errorf("index: %d length: %d out of bounds for rogue size: %d", visIx, length, r.VisSize)
+	}
+
+	startID, err := r.Rope.GetVisID(visIx)
+	if err != nil {
+		return mop, fmt.Errorf("GetVisID(%d): %w", visIx, err)
+	}
+
+	endID, err := r.Rope.GetVisID(visIx + length - 1)
+	if err != nil {
+		return mop, fmt.Errorf("GetVisID(%d): %w", visIx+length-1, err)
+	}
+
+	suggestions, err := r.GetSuggestions(startID, endID)
+	if err != nil {
+		return mop, fmt.Errorf("GetSuggestions(%v, %v): %w", startID, endID, err)
+	}
+
+	own := [][2]int{}
+	for _, s := range suggestions {
+		if s.Type != SuggestionInsert || s.Author != r.Author {
+			continue
+		}
+
+		startIx, endIx, err := r.visSpan(s.StartID, s.EndID)
+		if err != nil {
+			return mop, err
+		}
+		own = append(own, [2]int{startIx, endIx})
+	}
+
+	// mark everything that isn't our own pending insert
+	ix := visIx
+	for _, span := range append(own, [2]int{visIx + length, visIx + length}) {
+		if span[0] > ix {
+			op, err := r.Format(ix, span[0]-ix, FormatV3Span{SuggestDeleteKey: r.Author})
+			if err != nil {
+				return mop, fmt.Errorf("Format(%d, %d): %w", ix, span[0]-ix, err)
+			}
+			mop = mop.Append(op)
+		}
+		ix = span[1] + 1
+	}
+
+	// delete right to left so the indices stay valid
+	for i := len(own) - 1; i >= 0; i-- {
+		op, err := r.Delete(own[i][0], own[i][1]-own[i][0]+1)
+		if err != nil {
+			return mop, fmt.Errorf("Delete(%d, %d): %w", own[i][0], own[i][1]-own[i][0]+1, err)
+		}
+		mop = mop.Append(op)
+	}
+
+	r.OpIndex.Put(mop)
+	return mop, nil
+}
+
+// visSpan returns the visible indices of a run of visible text
+func (r *Rogue) visSpan(startID, endID ID) (startIx, endIx int, err error) {
+	startIx, _, err = r.Rope.GetIndex(startID)
+	if err != nil {
+		return -1, -1, fmt.Errorf("GetIndex(%v): %w", startID, err)
+	}
+
+	endIx, _, err = r.Rope.GetIndex(endID)
+	if err != nil {
+		return -1, -1, fmt.Errorf("GetIndex(%v): %w", endID, err)
+	}
+
+	return startIx, endIx, nil
+}
+
+// GetSuggestions returns the pending suggestions between startID and endID in
+// document order, suggestions that extend past the range are clipped to it
+func (r *Rogue) GetSuggestions(startID, endID ID) ([]Suggestion, error) {
+	vis, span, _, err := r.ToIndexNos(startID, endID, nil, false)
+	if err != nil {
+		return nil, fmt.Errorf("ToIndexNos(%v, %v): %w", startID, endID, err)
+	}
+
+	out := []Suggestion{}
+	if vis == nil {
+		return out, nil
+	}
+
+	type run struct {
+		Suggestion
+		startIx, endIx int
+	}
+
+	runs := []*run{}
+	last := map[SuggestionType]*run{}
+	for _, node := range span.AsSlice() {
+		f, ok := node.Format.(FormatV3Span)
+		if !ok {
+			continue
+		}
+
+		for _, t := range []SuggestionType{SuggestionInsert, SuggestionDelete} {
+			author := f[suggestionKey(t)]
+			if author == "" || author == "null" {
+				continue
+			}
+
+			// the format tree can split a suggestion into several nodes
+			if prev := last[t]; prev != nil && prev.Author == author && prev.endIx+1 == node.StartIx {
+				prev.endIx = node.EndIx
+				continue
+			}
+
+			r := &run{
+				Suggestion: Suggestion{Type: t, Author: author},
+				startIx:    node.StartIx,
+				endIx:      node.EndIx,
+			}
+			runs = append(runs, r)
+			last[t] = r
+		}
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].startIx < runs[j].startIx
+	})
+
+	for _, r := range runs {
+		r.StartID = vis.IDs[r.startIx]
+		r.EndID = vis.IDs[r.endIx]
+		r.Text = Uint16ToStr(vis.Text[r.startIx : r.endIx+1])
+		out = append(out, r.Suggestion)
+	}
+
+	return out, nil
+}
+
+// AcceptSuggestions applies the pending suggestions between startID and
+// endID, suggested inserts become plain text and suggested deletes are deleted
+func (r *Rogue) AcceptSuggestions(startID, endID ID) (MultiOp, error) {
+	return r.resolveSuggestions(startID, endID, true)
+}
+
+// RejectSuggestions drops the pending suggestions between startID and endID,
+// suggested inserts are deleted and suggested deletes become plain text again
+func (r *Rogue) RejectSuggestions(startID, endID ID) (MultiOp, error) {
+	return r.resolveSuggestions(startID, endID, false)
+}
+
+func (r *Rogue) resolveSuggestions(startID, endID ID, accept bool) (MultiOp, error) {
+	mop := MultiOp{}
+
+	suggestions, err := r.GetSuggestions(startID, endID)
+	if err != nil {
+		return mop, fmt.Errorf("GetSuggestions(%v, %v): %w", startID, endID, err)
+	}
+
+	// resolve right to left so deletes don't move the suggestions still to do
+	for i := len(suggestions) - 1; i >= 0; i-- {
+		s := suggestions[i]
+
+		// text can carry both kinds of suggestion, once it's deleted there's
+		// nothing left to resolve
+		deleted, err := r.IsDeleted(s.StartID)
+		if err != nil {
+			return mop, fmt.Errorf("IsDeleted(%v): %w", s.StartID, err)
+		}
+		if deleted {
+			continue
+		}
+
+		startIx, endIx, err := r.visSpan(s.StartID, s.EndID)
+		if err != nil {
+			return mop, err
+		}
+		length := endIx - startIx + 1
+
+		var op Op
+		if accept == (s.Type == SuggestionDelete) {
+			op, err = r.Delete(startIx, length)
+			if err != nil {
+				return mop, fmt.Errorf("Delete(%d, %d): %w", startIx, length, err)
+			}
+		} else {
+			op, err = r.Format(startIx, length, FormatV3Span{suggestionKey(s.Type): "null"})
+			if err != nil {
+				return mop, fmt.Errorf("Format(%d, %d): %w", startIx, length, err)
+			}
+		}
+		mop = mop.Append(op)
+	}
+
+	if len(mop.Mops) > 0 {
+		r.OpIndex.Put(mop)
+	}
+
+	return mop, nil
+}
+
+// SuggestMarkdownDiff is ApplyMarkdownDiff for suggestion mode, the text
+// changes needed to turn the selection into newMd are made as suggestions by
+// author. Formatting changes aren't suggested.
+func (r *Rogue) SuggestMarkdownDiff(author, newMd string, beforeID, afterID ID) (MultiOp, error) {
+	mop := MultiOp{}
+
+	authorBefore := r.Author
+	defer func() { r.Author = authorBefore }()
+	r.Author = author
+
+	cidx, err := r._startIx(beforeID)
+	if err != nil {
+		return mop, fmt.Errorf("SelectionStartIx(%v): %w", beforeID, err)
+	}
+
+	startID, endID, err := r._getOriginalSelection(beforeID, afterID)
+	if err != nil {
+		return mop, fmt.Errorf("GetOriginalSelection(%v, %v): %v", beforeID, afterID, err)
+	}
+
+	vis, err := r.Rope.GetBetween(startID, endID)
+	if err != nil {
+		return mop, fmt.Errorf("GetBetween(%v, %v): %v", startID, endID, err)
+	}
+
+	plaintext := Uint16ToStr(vis.Text)
+	if len(plaintext) > 0 && plaintext[len(plaintext)-1] == '\n' && len(newMd) > 0 && newMd[len(newMd)-1] != '\n' {
+		newMd = newMd + "\n"
+	}
+
+	mdDiff, err := DiffMarkdown(plaintext, newMd)
+	if err != nil {
+		return mop, fmt.Errorf("DiffMarkdown(%q, %q): %w", plaintext, newMd, err)
+	}
+
+	if plaintext == "" {
+		op, err := r.InsertRightOf(beforeID, mdDiff.NewPlaintext)
+		if err != nil {
+			return mop, fmt.Errorf("InsertRightOf(%v, %q): %w", beforeID, newMd, err)
+		}
+		mop = mop.Append(op)
+
+		ix, _, err := r.Rope.GetIndex(op.ID)
+		if err != nil {
+			return mop, fmt.Errorf("GetIndex(%v): %w", op.ID, err)
+		}
+
+		length := UTF16Length(mdDiff.NewPlaintext)
+		fop, err := r.Format(ix, length, FormatV3Span{SuggestInsertKey: author})
+		if err != nil {
+			return mop, fmt.Errorf("Format(%d, %d): %w", ix, length, err)
+		}
+		mop = mop.Append(fop)
+
+		r.OpIndex.Put(mop)
+		return mop, nil
+	}
+
+	for _, d := range mdDiff.TextDiffs {
+		length := UTF16Length(d.Text)
+		switch d.Type {
+		case diffmatchpatch.DiffDelete:
+			// the text stays in place until the suggestion is accepted
+			op, err := r.Format(cidx, length, FormatV3Span{SuggestDeleteKey: author})
+			if err != nil {
+				return mop, fmt.Errorf("Format(%d, %d): %w", cidx, length, err)
+			}
+			mop = mop.Append(op)
+		case diffmatchpatch.DiffInsert:
+			op, err := r.SuggestInsert(cidx, d.Text)
+			if err != nil {
+				return mop, fmt.Errorf("SuggestInsert(%d, %q): %w", cidx, d.Text, err)
+			}
+			mop = mop.Append(op)
+		}
+		cidx += length
+	}
+
+	r.OpIndex.Put(mop)
+	return mop, nil
+}
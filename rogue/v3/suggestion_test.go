@@ -0,0 +1,180 @@
+package v3_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	v3 "github.com/fivetentaylor/pointy/rogue/v3"
+)
+
+func newSuggestionDoc(t *testing.T, text string) *v3.Rogue {
+	r := v3.NewRogueForQuill("0")
+	_, err := r.Insert(0, text)
+	require.NoError(t, err)
+
+	return r
+}
+
+func allSuggestions(t *testing.T, r *v3.Rogue) []v3.Suggestion {
+	firstID, lastID, err := r.GetWrappingIDs()
+	require.NoError(t, err)
+
+	suggestions, err := r.GetSuggestions(firstID, lastID)
+	require.NoError(t, err)
+
+	return suggestions
+}
+
+func TestSuggestions(t *testing.T) {
+	r := newSuggestionDoc(t, "hello world")
+	r.Author = "1"
+
+	_, err := r.SuggestInsert(5, " big")
+	require.NoError(t, err)
+	_, err = r.SuggestDelete(9, 6)
+	require.NoError(t, err)
+
+	// suggestions stay in the text until they're resolved
+	require.Equal(t, "hello big world\n", r.GetText())
+
+	suggestions := allSuggestions(t, r)
+	require.Len(t, suggestions, 2)
+	require.Equal(t, v3.SuggestionInsert, suggestions[0].Type)
+	require.Equal(t, "1", suggestions[0].Author)
+	require.Equal(t, " big", suggestions[0].Text)
+	require.Equal(t, v3.SuggestionDelete, suggestions[1].Type)
+	require.Equal(t, " world", suggestions[1].Text)
+
+	html, err := r.GetFullHtml(false, false)
+	require.NoError(t, err)
+	require.Contains(t, html, `<ins data-suggestion-author="1">`)
+	require.Contains(t, html, `<del data-suggestion-author="1">`)
+
+	html, err = r.GetFullHtml(true, false)
+	require.NoError(t, err)
+	require.Contains(t, html, "data-suggestion-start=")
+
+	t.Run("accept", func(t *testing.T) {
+		r, err := r.DeepCopy()
+		require.NoError(t, err)
+		firstID, lastID, err := r.GetWrappingIDs()
+		require.NoError(t, err)
+
+		_, err = r.AcceptSuggestions(firstID, lastID)
+		require.NoError(t, err)
+		require.Equal(t, "hello big\n", r.GetText())
+		require.Empty(t, allSuggestions(t, r))
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		r, err := r.DeepCopy()
+		require.NoError(t, err)
+		firstID, lastID, err := r.GetWrappingIDs()
+		require.NoError(t, err)
+
+		_, err = r.RejectSuggestions(firstID, lastID)
+		require.NoError(t, err)
+		require.Equal(t, "hello world\n", r.GetText())
+		require.Empty(t, allSuggestions(t, r))
+	})
+
+	t.Run("partial range", func(t *testing.T) {
+		r, err := r.DeepCopy()
+		require.NoError(t, err)
+		startID, err := r.Rope.GetVisID(5)
+		require.NoError(t, err)
+		endID, err := r.Rope.GetVisID(8)
+		require.NoError(t, err)
+
+		_, err = r.AcceptSuggestions(startID, endID)
+		require.NoError(t, err)
+		require.Equal(t, "hello big world\n", r.GetText())
+
+		suggestions := allSuggestions(t, r)
+		require.Len(t, suggestions, 1)
+		require.Equal(t, v3.SuggestionDelete, suggestions[0].Type)
+	})
+}
+
+func TestSuggestDeleteOwnInsert(t *testing.T) {
+	r := newSuggestionDoc(t, "hello world")
+	r.Author = "1"
+
+	_, err := r.SuggestInsert(5, " big")
+	require.NoError(t, err)
+
+	// deleting your own suggestion removes it instead of suggesting a delete
+	_, err = r.SuggestDelete(4, 5)
+	require.NoError(t, err)
+	require.Equal(t, "hello world\n", r.GetText())
+
+	suggestions := allSuggestions(t, r)
+	require.Len(t, suggestions, 1)
+	require.Equal(t, v3.SuggestionDelete, suggestions[0].Type)
+	require.Equal(t, "o", suggestions[0].Text)
+}
+
+func TestSuggestMarkdownDiff(t *testing.T) {
+	r := newSuggestionDoc(t, "Hello World!")
+
+	firstID, lastID, err := r.GetWrappingIDs()
+	require.NoError(t, err)
+
+	_, err = r.SuggestMarkdownDiff("ai", "Hello Universe!", v3.RootID, lastID)
+	require.NoError(t, err)
+	require.Equal(t, "0", r.Author)
+
+	suggestions := allSuggestions(t, r)
+	require.NotEmpty(t, suggestions)
+	for _, s := range suggestions {
+		require.Equal(t, "ai", s.Author)
+	}
+
+	accepted, err := r.DeepCopy()
+	require.NoError(t, err)
+	_, err = accepted.AcceptSuggestions(firstID, lastID)
+	require.NoError(t, err)
+	require.Equal(t, "Hello Universe!\n", accepted.GetText())
+
+	_, err = r.RejectSuggestions(firstID, lastID)
+	require.NoError(t, err)
+	require.Equal(t, "Hello World!\n", r.GetText())
+}
+
+func TestSuggestionsConcurrentResolve(t *testing.T) {
+	a := newSuggestionDoc(t, "hello world")
+	a.Author = "1"
+	_, err := a.SuggestInsert(5, " big")
+	require.NoError(t, err)
+
+	ops, err := a.ToOps()
+	require.NoError(t, err)
+
+	b := v3.NewRogueForQuill("2")
+	for _, op := range ops {
+		_, err := b.MergeOp(op)
+		require.NoError(t, err)
+	}
+	require.Equal(t, a.GetText(), b.GetText())
+
+	a.Author = "3"
+	firstID, lastID, err := a.GetWrappingIDs()
+	require.NoError(t, err)
+
+	// one replica accepts the suggestion while the other rejects it
+	acceptOp, err := a.AcceptSuggestions(firstID, lastID)
+	require.NoError(t, err)
+	rejectOp, err := b.RejectSuggestions(firstID, lastID)
+	require.NoError(t, err)
+
+	_, err = a.MergeOp(rejectOp)
+	require.NoError(t, err)
+	_, err = b.MergeOp(acceptOp)
+	require.NoError(t, err)
+
+	require.Equal(t, "hello world\n", a.GetText())
+	require.Equal(t, a.GetText(), b.GetText())
+	require.Empty(t, allSuggestions(t, a))
+	require.Empty(t, allSuggestions(t, b))
+}
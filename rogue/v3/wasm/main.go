@@ -379,6 +379,63 @@ func main() {
 
 				return html
 			}),
+			"GetHtmlBlame": js.FuncOf(func(this js.Value, args []js.Value) any {
+				defer catchPanic()
+
+				if len(args) != 2 && len(args) != 3 {
+					return map[string]interface{}{
+						"error": fmt.Sprintf("expected 2-3 arguments, got %d", len(args)),
+					}
+				}
+
+				if args[0].Type() != js.TypeObject {
+					return map[string]interface{}{
+						"error": fmt.Sprintf("expected object, got %T", args[0]),
+					}
+				}
+
+				sau := args[0].Index(0).String()
+				slt := args[0].Index(1).Int()
+
+				if args[1].Type() != js.TypeObject {
+					return map[string]interface{}{
+						"error": fmt.Sprintf("expected object, got %T", args[1]),
+					}
+				}
+
+				fau := args[1].Index(0).String()
+				flt := args[1].Index(1).Int()
+
+				startID := v3.ID{Author: sau, Seq: slt}
+				afterID := v3.ID{Author: fau, Seq: flt}
+
+				endID, err := instance.AfterIDToEndID(afterID)
+				if err != nil {
+					return map[string]interface{}{
+						"error": err.Error(),
+					}
+				}
+
+				showIds := true
+				if len(args) == 3 {
+					if args[2].Type() != js.TypeBoolean {
+						return map[string]interface{}{
+							"error": fmt.Sprintf("expected boolean, got %T", args[2]),
+						}
+					}
+					showIds = args[2].Bool()
+				}
+
+				html, err := instance.GetHtmlBlame(startID, endID, nil, showIds, true)
+				if err != nil {
+					fmt.Printf("ERROR [wasm] GetHtmlBlame(%v, %v, %v): %s\n", startID, endID, showIds, err)
+					return map[string]interface{}{
+						"error": err.Error(),
+					}
+				}
+
+				return html
+			}),
 			"GetHtmlAtAddress": js.FuncOf(func(this js.Value, args []js.Value) any {
 				defer catchPanic()
 
@@ -1012,6 +1069,93 @@ func main() {
 
 				return mop.AsArr()
 			}),
+			"SuggestInsert": js.FuncOf(func(this js.Value, args []js.Value) any {
+				defer catchPanic()
+				if len(args) != 2 || args[0].Type() != js.TypeNumber || args[1].Type() != js.TypeString {
+					return map[string]interface{}{
+						"error": fmt.Sprintf("invalid args: %v", args),
+					}
+				}
+
+				visIx := args[0].Int()
+				text := args[1].String()
+
+				mop, err := instance.SuggestInsert(visIx, text)
+				if err != nil {
+					fmt.Printf("ERROR [wasm] SuggestInsert(%d, %q): %s\n", visIx, text, err)
+					return map[string]interface{}{
+						"error": err.Error(),
+					}
+				}
+
+				return mop.AsArr()
+			}),
+			"SuggestDelete": js.FuncOf(func(this js.Value, args []js.Value) any {
+				defer catchPanic()
+				if len(args) != 2 {
+					return map[string]interface{}{
+						"error": fmt.Sprintf("invalid args: %v", args),
+					}
+				}
+
+				visIx := args[0].Int()
+				length := args[1].Int()
+
+				mop, err := instance.SuggestDelete(visIx, length)
+				if err != nil {
+					fmt.Printf("ERROR [wasm] SuggestDelete(%d, %d): %s\n", visIx, length, err)
+					return map[string]interface{}{
+						"error": err.Error(),
+					}
+				}
+
+				return mop.AsArr()
+			}),
+			"GetSuggestions": js.FuncOf(func(this js.Value, args []js.Value) any {
+				defer catchPanic()
+
+				startID, err := IdArg(args, 0)
+				if err != nil {
+					return map[string]interface{}{
+						"error": fmt.Sprintf("start id: %s", err.Error()),
+					}
+				}
+
+				endID, err := IdArg(args, 1)
+				if err != nil {
+					return map[string]interface{}{
+						"error": fmt.Sprintf("end id: %s", err.Error()),
+					}
+				}
+
+				suggestions, err := instance.GetSuggestions(startID, endID)
+				if err != nil {
+					return map[string]interface{}{
+						"error": err.Error(),
+					}
+				}
+
+				out := make([]any, len(suggestions))
+				for i, s := range suggestions {
+					out[i] = map[string]interface{}{
+						"type":    string(s.Type),
+						"author":  s.Author,
+						"startID": s.StartID.AsJS(),
+						"endID":   s.EndID.AsJS(),
+						"text":    s.Text,
+					}
+				}
+
+				return out
+			}),
+			"AcceptSuggestions": js.FuncOf(func(this js.Value, args []js.Value) any {
+				defer catchPanic()
+				return resolveSuggestions(args, instance.AcceptSuggestions)
+			}),
+			"RejectSuggestions": js.FuncOf(func(this js.Value, args []js.Value) any {
+				defer catchPanic()
+				return resolveSuggestions(args, instance.RejectSuggestions)
+			}),
 			"Undo": js.FuncOf(func(this js.Value, args []js.Value) any {
 				defer catchPanic()
 
@@ -1771,3 +1915,29 @@ func ContentAddressArg(args []js.Value, ix int) (*v3.ContentAddress, error) {
 
 	return &ca, nil
 }
+
+func resolveSuggestions(args []js.Value, resolve func(startID, endID v3.ID) (v3.MultiOp, error)) any {
+	startID, err := IdArg(args, 0)
+	if err != nil {
+		return map[string]interface{}{
+			"error": fmt.Sprintf("start id: %s", err.Error()),
+		}
+	}
+
+	endID, err := IdArg(args, 1)
+	if err != nil {
+		return map[string]interface{}{
+			"error": fmt.Sprintf("end id: %s", err.Error()),
+		}
+	}
+
+	mop, err := resolve(startID, endID)
+	if err != nil {
+		fmt.Printf("ERROR [wasm] resolveSuggestions(%v, %v): %s\n", startID, endID, err)
+		return map[string]interface{}{
+			"error": err.Error(),
+		}
+	}
+
+	return mop.AsArr()
+}